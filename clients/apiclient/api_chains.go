@@ -1317,6 +1317,182 @@ func (a *ChainsApiService) GetReceiptExecute(r ApiGetReceiptRequest) (*ReceiptRe
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiGetStateDiffRequest struct {
+	ctx context.Context
+	ApiService *ChainsApiService
+	chainID string
+	from *string
+	to *string
+	contractHname *string
+	limit *int32
+	continuationKey *string
+}
+
+// Block index or trie root of the old state
+func (r ApiGetStateDiffRequest) From(from string) ApiGetStateDiffRequest {
+	r.from = &from
+	return r
+}
+
+// Block index or trie root of the new state (latest, if omitted)
+func (r ApiGetStateDiffRequest) To(to string) ApiGetStateDiffRequest {
+	r.to = &to
+	return r
+}
+
+// The contract hname (Hex)
+func (r ApiGetStateDiffRequest) ContractHname(contractHname string) ApiGetStateDiffRequest {
+	r.contractHname = &contractHname
+	return r
+}
+
+// The maximum amount of keys returned (default: 1000, max: 10000)
+func (r ApiGetStateDiffRequest) Limit(limit int32) ApiGetStateDiffRequest {
+	r.limit = &limit
+	return r
+}
+
+// The key (Hex), from which the page starts: the nextKey of the previous page
+func (r ApiGetStateDiffRequest) ContinuationKey(continuationKey string) ApiGetStateDiffRequest {
+	r.continuationKey = &continuationKey
+	return r
+}
+
+func (r ApiGetStateDiffRequest) Execute() (*StateDiffResponse, *http.Response, error) {
+	return r.ApiService.GetStateDiffExecute(r)
+}
+
+/*
+GetStateDiff Get the key-level difference between the chain states at two blocks
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @param chainID ChainID (Bech32)
+ @return ApiGetStateDiffRequest
+*/
+func (a *ChainsApiService) GetStateDiff(ctx context.Context, chainID string) ApiGetStateDiffRequest {
+	return ApiGetStateDiffRequest{
+		ApiService: a,
+		ctx: ctx,
+		chainID: chainID,
+	}
+}
+
+// Execute executes the request
+//  @return *StateDiffResponse
+func (a *ChainsApiService) GetStateDiffExecute(r ApiGetStateDiffRequest) (*StateDiffResponse, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  *StateDiffResponse
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "ChainsApiService.GetStateDiff")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/chains/{chainID}/state-diff"
+	localVarPath = strings.Replace(localVarPath, "{"+"chainID"+"}", url.PathEscape(parameterValueToString(r.chainID, "chainID")), -1)
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+	if r.from == nil {
+		return localVarReturnValue, nil, reportError("from is required and must be specified")
+	}
+
+	parameterAddToQuery(localVarQueryParams, "from", r.from, "")
+	if r.to != nil {
+		parameterAddToQuery(localVarQueryParams, "to", r.to, "")
+	}
+	if r.contractHname != nil {
+		parameterAddToQuery(localVarQueryParams, "contractHname", r.contractHname, "")
+	}
+	if r.limit != nil {
+		parameterAddToQuery(localVarQueryParams, "limit", r.limit, "")
+	}
+	if r.continuationKey != nil {
+		parameterAddToQuery(localVarQueryParams, "continuationKey", r.continuationKey, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Authorization"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ValidationError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+					newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+					newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiGetStateValueRequest struct {
 	ctx context.Context
 	ApiService *ChainsApiService
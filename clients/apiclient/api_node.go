@@ -138,6 +138,177 @@ func (a *NodeApiService) DistrustPeerExecute(r ApiDistrustPeerRequest) (*http.Re
 	return localVarHTTPResponse, nil
 }
 
+type ApiExportAuditLogRequest struct {
+	ctx context.Context
+	ApiService *NodeApiService
+	user *string
+	method *string
+	route *string
+	since *string
+	until *string
+}
+
+// Only the calls of this user
+func (r ApiExportAuditLogRequest) User(user string) ApiExportAuditLogRequest {
+	r.user = &user
+	return r
+}
+
+// Only the calls with this HTTP method
+func (r ApiExportAuditLogRequest) Method(method string) ApiExportAuditLogRequest {
+	r.method = &method
+	return r
+}
+
+// Only the calls to routes containing this string
+func (r ApiExportAuditLogRequest) Route(route string) ApiExportAuditLogRequest {
+	r.route = &route
+	return r
+}
+
+// Only the calls at or after this time (RFC3339)
+func (r ApiExportAuditLogRequest) Since(since string) ApiExportAuditLogRequest {
+	r.since = &since
+	return r
+}
+
+// Only the calls before this time (RFC3339)
+func (r ApiExportAuditLogRequest) Until(until string) ApiExportAuditLogRequest {
+	r.until = &until
+	return r
+}
+
+func (r ApiExportAuditLogRequest) Execute() (string, *http.Response, error) {
+	return r.ApiService.ExportAuditLogExecute(r)
+}
+
+/*
+ExportAuditLog Export the log of the administrative calls changing the node as JSON lines
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiExportAuditLogRequest
+*/
+func (a *NodeApiService) ExportAuditLog(ctx context.Context) ApiExportAuditLogRequest {
+	return ApiExportAuditLogRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return string
+func (a *NodeApiService) ExportAuditLogExecute(r ApiExportAuditLogRequest) (string, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  string
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "NodeApiService.ExportAuditLog")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/node/audit/export"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.user != nil {
+		parameterAddToQuery(localVarQueryParams, "user", r.user, "")
+	}
+	if r.method != nil {
+		parameterAddToQuery(localVarQueryParams, "method", r.method, "")
+	}
+	if r.route != nil {
+		parameterAddToQuery(localVarQueryParams, "route", r.route, "")
+	}
+	if r.since != nil {
+		parameterAddToQuery(localVarQueryParams, "since", r.since, "")
+	}
+	if r.until != nil {
+		parameterAddToQuery(localVarQueryParams, "until", r.until, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/x-ndjson"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Authorization"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ValidationError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+					newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+					newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiGenerateDKSRequest struct {
 	ctx context.Context
 	ApiService *NodeApiService
@@ -392,6 +563,187 @@ func (a *NodeApiService) GetAllPeersExecute(r ApiGetAllPeersRequest) ([]PeeringN
 	return localVarReturnValue, localVarHTTPResponse, nil
 }
 
+type ApiGetAuditLogRequest struct {
+	ctx context.Context
+	ApiService *NodeApiService
+	user *string
+	method *string
+	route *string
+	since *string
+	until *string
+	limit *int32
+}
+
+// Only the calls of this user
+func (r ApiGetAuditLogRequest) User(user string) ApiGetAuditLogRequest {
+	r.user = &user
+	return r
+}
+
+// Only the calls with this HTTP method
+func (r ApiGetAuditLogRequest) Method(method string) ApiGetAuditLogRequest {
+	r.method = &method
+	return r
+}
+
+// Only the calls to routes containing this string
+func (r ApiGetAuditLogRequest) Route(route string) ApiGetAuditLogRequest {
+	r.route = &route
+	return r
+}
+
+// Only the calls at or after this time (RFC3339)
+func (r ApiGetAuditLogRequest) Since(since string) ApiGetAuditLogRequest {
+	r.since = &since
+	return r
+}
+
+// Only the calls before this time (RFC3339)
+func (r ApiGetAuditLogRequest) Until(until string) ApiGetAuditLogRequest {
+	r.until = &until
+	return r
+}
+
+// The maximum amount of entries returned, newest first (default: 100, 0 = all)
+func (r ApiGetAuditLogRequest) Limit(limit int32) ApiGetAuditLogRequest {
+	r.limit = &limit
+	return r
+}
+
+func (r ApiGetAuditLogRequest) Execute() ([]AuditLogEntry, *http.Response, error) {
+	return r.ApiService.GetAuditLogExecute(r)
+}
+
+/*
+GetAuditLog Get the log of the administrative calls changing the node
+
+ @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+ @return ApiGetAuditLogRequest
+*/
+func (a *NodeApiService) GetAuditLog(ctx context.Context) ApiGetAuditLogRequest {
+	return ApiGetAuditLogRequest{
+		ApiService: a,
+		ctx: ctx,
+	}
+}
+
+// Execute executes the request
+//  @return []AuditLogEntry
+func (a *NodeApiService) GetAuditLogExecute(r ApiGetAuditLogRequest) ([]AuditLogEntry, *http.Response, error) {
+	var (
+		localVarHTTPMethod   = http.MethodGet
+		localVarPostBody     interface{}
+		formFiles            []formFile
+		localVarReturnValue  []AuditLogEntry
+	)
+
+	localBasePath, err := a.client.cfg.ServerURLWithContext(r.ctx, "NodeApiService.GetAuditLog")
+	if err != nil {
+		return localVarReturnValue, nil, &GenericOpenAPIError{error: err.Error()}
+	}
+
+	localVarPath := localBasePath + "/v1/node/audit"
+
+	localVarHeaderParams := make(map[string]string)
+	localVarQueryParams := url.Values{}
+	localVarFormParams := url.Values{}
+
+	if r.user != nil {
+		parameterAddToQuery(localVarQueryParams, "user", r.user, "")
+	}
+	if r.method != nil {
+		parameterAddToQuery(localVarQueryParams, "method", r.method, "")
+	}
+	if r.route != nil {
+		parameterAddToQuery(localVarQueryParams, "route", r.route, "")
+	}
+	if r.since != nil {
+		parameterAddToQuery(localVarQueryParams, "since", r.since, "")
+	}
+	if r.until != nil {
+		parameterAddToQuery(localVarQueryParams, "until", r.until, "")
+	}
+	if r.limit != nil {
+		parameterAddToQuery(localVarQueryParams, "limit", r.limit, "")
+	}
+	// to determine the Content-Type header
+	localVarHTTPContentTypes := []string{}
+
+	// set Content-Type header
+	localVarHTTPContentType := selectHeaderContentType(localVarHTTPContentTypes)
+	if localVarHTTPContentType != "" {
+		localVarHeaderParams["Content-Type"] = localVarHTTPContentType
+	}
+
+	// to determine the Accept header
+	localVarHTTPHeaderAccepts := []string{"application/json"}
+
+	// set Accept header
+	localVarHTTPHeaderAccept := selectHeaderAccept(localVarHTTPHeaderAccepts)
+	if localVarHTTPHeaderAccept != "" {
+		localVarHeaderParams["Accept"] = localVarHTTPHeaderAccept
+	}
+	if r.ctx != nil {
+		// API Key Authentication
+		if auth, ok := r.ctx.Value(ContextAPIKeys).(map[string]APIKey); ok {
+			if apiKey, ok := auth["Authorization"]; ok {
+				var key string
+				if apiKey.Prefix != "" {
+					key = apiKey.Prefix + " " + apiKey.Key
+				} else {
+					key = apiKey.Key
+				}
+				localVarHeaderParams["Authorization"] = key
+			}
+		}
+	}
+	req, err := a.client.prepareRequest(r.ctx, localVarPath, localVarHTTPMethod, localVarPostBody, localVarHeaderParams, localVarQueryParams, localVarFormParams, formFiles)
+	if err != nil {
+		return localVarReturnValue, nil, err
+	}
+
+	localVarHTTPResponse, err := a.client.callAPI(req)
+	if err != nil || localVarHTTPResponse == nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	localVarBody, err := ioutil.ReadAll(localVarHTTPResponse.Body)
+	localVarHTTPResponse.Body.Close()
+	localVarHTTPResponse.Body = ioutil.NopCloser(bytes.NewBuffer(localVarBody))
+	if err != nil {
+		return localVarReturnValue, localVarHTTPResponse, err
+	}
+
+	if localVarHTTPResponse.StatusCode >= 300 {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: localVarHTTPResponse.Status,
+		}
+		if localVarHTTPResponse.StatusCode == 401 {
+			var v ValidationError
+			err = a.client.decode(&v, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+			if err != nil {
+				newErr.error = err.Error()
+				return localVarReturnValue, localVarHTTPResponse, newErr
+			}
+					newErr.error = formatErrorMessage(localVarHTTPResponse.Status, &v)
+					newErr.model = v
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	err = a.client.decode(&localVarReturnValue, localVarBody, localVarHTTPResponse.Header.Get("Content-Type"))
+	if err != nil {
+		newErr := &GenericOpenAPIError{
+			body:  localVarBody,
+			error: err.Error(),
+		}
+		return localVarReturnValue, localVarHTTPResponse, newErr
+	}
+
+	return localVarReturnValue, localVarHTTPResponse, nil
+}
+
 type ApiGetConfigurationRequest struct {
 	ctx context.Context
 	ApiService *NodeApiService
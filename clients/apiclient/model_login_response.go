@@ -21,6 +21,8 @@ var _ MappedNullable = &LoginResponse{}
 type LoginResponse struct {
 	Error string `json:"error"`
 	Jwt string `json:"jwt"`
+	// The token to get a new JWT with, before the refresh token expires
+	RefreshToken *string `json:"refreshToken,omitempty"`
 }
 
 // NewLoginResponse instantiates a new LoginResponse object
@@ -90,6 +92,38 @@ func (o *LoginResponse) SetJwt(v string) {
 	o.Jwt = v
 }
 
+// GetRefreshToken returns the RefreshToken field value if set, zero value otherwise.
+func (o *LoginResponse) GetRefreshToken() string {
+	if o == nil || isNil(o.RefreshToken) {
+		var ret string
+		return ret
+	}
+	return *o.RefreshToken
+}
+
+// GetRefreshTokenOk returns a tuple with the RefreshToken field value if set, nil otherwise
+// and a boolean to check if the value has been set.
+func (o *LoginResponse) GetRefreshTokenOk() (*string, bool) {
+	if o == nil || isNil(o.RefreshToken) {
+		return nil, false
+	}
+	return o.RefreshToken, true
+}
+
+// HasRefreshToken returns a boolean if a field has been set.
+func (o *LoginResponse) HasRefreshToken() bool {
+	if o != nil && !isNil(o.RefreshToken) {
+		return true
+	}
+
+	return false
+}
+
+// SetRefreshToken gets a reference to the given string and assigns it to the RefreshToken field.
+func (o *LoginResponse) SetRefreshToken(v string) {
+	o.RefreshToken = &v
+}
+
 func (o LoginResponse) MarshalJSON() ([]byte, error) {
 	toSerialize,err := o.ToMap()
 	if err != nil {
@@ -102,6 +136,9 @@ func (o LoginResponse) ToMap() (map[string]interface{}, error) {
 	toSerialize := map[string]interface{}{}
 	toSerialize["error"] = o.Error
 	toSerialize["jwt"] = o.Jwt
+	if !isNil(o.RefreshToken) {
+		toSerialize["refreshToken"] = o.RefreshToken
+	}
 	return toSerialize, nil
 }
 
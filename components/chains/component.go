@@ -11,6 +11,7 @@ import (
 	hiveshutdown "github.com/iotaledger/hive.go/app/shutdown"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chain/cmt_log"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
 	"github.com/iotaledger/wasp/packages/chains"
 	"github.com/iotaledger/wasp/packages/daemon"
 	"github.com/iotaledger/wasp/packages/database"
@@ -85,6 +86,41 @@ func provide(c *dig.Container) error {
 		Chains *chains.Chains
 	}
 
+	retention, err := chains.NewRetention(
+		ParamsRetention.Mode,
+		ParamsStateManager.PruningMinStatesToKeep,
+		ParamsRetention.Blocks,
+		ParamsRetention.Receipts,
+		ParamsRetention.EVMIndex,
+	)
+	if err != nil {
+		Component.LogPanicf("invalid history retention configuration: %v", err)
+	}
+	Component.LogInfof("Node history retention: %s", retention)
+
+	var walSegmentedOptions *sm_gpa_utils.SegmentedBlockWALOptions
+	switch ParamsWAL.Layout {
+	case "files", "":
+	case "segmented":
+		compression, err := sm_gpa_utils.ParseBlockWALCompression(ParamsWAL.Compression)
+		if err != nil {
+			Component.LogPanicf("invalid WAL configuration: %v", err)
+		}
+		walSegmentedOptions = sm_gpa_utils.NewSegmentedBlockWALOptions()
+		walSegmentedOptions.SegmentMaxSize = ParamsWAL.SegmentMaxSize
+		walSegmentedOptions.Compression = compression
+		walSegmentedOptions.Retention.MaxAge = ParamsWAL.RetentionMaxAge
+		walSegmentedOptions.Retention.MaxSize = ParamsWAL.RetentionMaxSize
+	default:
+		Component.LogPanicf("invalid WAL configuration: unknown layout %q", ParamsWAL.Layout)
+	}
+
+	// a non-positive batch size would make the pruning loop spin without
+	// releasing any trie nodes
+	if ParamsStateManager.PruningBatchSize <= 0 {
+		Component.LogPanicf("invalid state manager configuration: pruningBatchSize must be positive, got %v", ParamsStateManager.PruningBatchSize)
+	}
+
 	if err := c.Provide(func(deps chainsDeps) chainsResult {
 		return chainsResult{
 			Chains: chains.New(
@@ -105,14 +141,17 @@ func provide(c *dig.Container) error {
 				ParamsWAL.LoadToStore,
 				ParamsWAL.Enabled,
 				ParamsWAL.Path,
+				walSegmentedOptions,
 				ParamsStateManager.BlockCacheMaxSize,
 				ParamsStateManager.BlockCacheBlocksInCacheDuration,
 				ParamsStateManager.BlockCacheBlockCleaningPeriod,
 				ParamsStateManager.StateManagerGetBlockRetry,
 				ParamsStateManager.StateManagerRequestCleaningPeriod,
 				ParamsStateManager.StateManagerTimerTickPeriod,
-				ParamsStateManager.PruningMinStatesToKeep,
 				ParamsStateManager.PruningMaxStatesToDelete,
+				ParamsStateManager.PruningBatchSize,
+				ParamsStateManager.PruningMaxNodesPerSecond,
+				retention,
 				ParamsSnapshotManager.SnapshotsToLoad,
 				ParamsSnapshotManager.Period,
 				ParamsSnapshotManager.Delay,
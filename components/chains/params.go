@@ -18,9 +18,14 @@ type ParametersChains struct {
 }
 
 type ParametersWAL struct {
-	LoadToStore bool   `default:"false" usage:"load blocks from \"write-ahead log\" to the store on node start-up"`
-	Enabled     bool   `default:"true" usage:"whether the \"write-ahead logging\" is enabled"`
-	Path        string `default:"waspdb/wal" usage:"the path to the \"write-ahead logging\" folder"`
+	LoadToStore      bool          `default:"false" usage:"load blocks from \"write-ahead log\" to the store on node start-up"`
+	Enabled          bool          `default:"true" usage:"whether the \"write-ahead logging\" is enabled"`
+	Path             string        `default:"waspdb/wal" usage:"the path to the \"write-ahead logging\" folder"`
+	Layout           string        `default:"files" usage:"'files' - each block is stored in its own file; 'segmented' - blocks are appended to the indexed segment files; the blocks of 'files' layout are migrated to the segments on node start-up"`
+	SegmentMaxSize   int64         `default:"67108864" usage:"the size of the segment file in bytes, after which a new segment is started; 'segmented' layout only"`
+	Compression      string        `default:"none" usage:"the compression of the blocks: 'none', 'zstd' or 'snappy'; 'segmented' layout only"`
+	RetentionMaxAge  time.Duration `default:"0s" usage:"the segments, which were last written to longer ago, are deleted; 0 - disabled; 'segmented' layout only"`
+	RetentionMaxSize int64         `default:"0" usage:"the oldest segments are deleted, if the total size of the segments in bytes exceeds this; 0 - disabled; 'segmented' layout only"`
 }
 
 type ParametersValidator struct {
@@ -34,8 +39,17 @@ type ParametersStateManager struct {
 	StateManagerGetBlockRetry         time.Duration `default:"3s" usage:"how often get block requests should be repeated"`
 	StateManagerRequestCleaningPeriod time.Duration `default:"1s" usage:"how often requests waiting for response should be checked for expired context"`
 	StateManagerTimerTickPeriod       time.Duration `default:"1s" usage:"how often timer tick fires in state manager"`
-	PruningMinStatesToKeep            int           `default:"10000" usage:"this number of states will always be available in the store; if 0 - store pruning is disabled"`
+	PruningMinStatesToKeep            int           `default:"10000" usage:"this number of states will always be available in the store; if 0 - store pruning is disabled; this is the trie states retention of the node mode"`
 	PruningMaxStatesToDelete          int           `default:"1000" usage:"on single store pruning attempt at most this number of states will be deleted"`
+	PruningBatchSize                  int           `default:"1000" usage:"the tries of the pruned states are deleted in the background in batches of at most this number of trie nodes; the store is locked only while a batch is deleted"`
+	PruningMaxNodesPerSecond          int           `default:"10000" usage:"at most this number of trie nodes per second is deleted by the background pruning; if 0 - no limit"`
+}
+
+type ParametersRetention struct {
+	Mode     string `default:"" usage:"the node mode: 'archive' keeps the whole history, 'full' prunes old trie states but keeps all the blocks and the EVM JSON-RPC index, 'pruned' prunes all the history; if empty, the mode is derived from the retention values"`
+	Blocks   int    `default:"-1" usage:"how many latest blocks are kept in the \"write-ahead log\"; 0 - all the blocks are kept, -1 - derived from the node mode"`
+	Receipts int    `default:"-1" usage:"for how many latest blocks the blocklog receipts and events must be available; the trie states needed to serve the ones pruned by the chain are kept longer; 0 - all the blocks, -1 - derived from the node mode"`
+	EVMIndex int    `default:"-1" usage:"how many latest blocks are kept in the EVM JSON-RPC index; 0 - all the blocks are kept, -1 - derived from the node mode"`
 }
 
 type ParametersSnapshotManager struct {
@@ -43,7 +57,7 @@ type ParametersSnapshotManager struct {
 	Period          uint32   `default:"0" usage:"how often state snapshots should be made: 1000 meaning \"every 1000th state\", 0 meaning \"making snapshots is disabled\""`
 	Delay           uint32   `default:"20" usage:"how many states should pass before snapshot is produced"`
 	LocalPath       string   `default:"waspdb/snap" usage:"the path to the snapshots folder in this node's disk"`
-	NetworkPaths    []string `default:"" usage:"the list of paths to the remote (http(s)) snapshot locations; each of listed locations must contain 'INDEX' file with list of snapshot files"`
+	NetworkPaths    []string `default:"" usage:"the list of paths to the remote (http(s)) snapshot locations; each of listed locations must contain 'INDEX' file with list of snapshot files; the same snapshot found in several locations is downloaded from all of them in parallel and verified with its '.chunks' manifest, if provided"`
 }
 
 var (
@@ -51,6 +65,7 @@ var (
 	ParamsWAL             = &ParametersWAL{}
 	ParamsValidator       = &ParametersValidator{}
 	ParamsStateManager    = &ParametersStateManager{}
+	ParamsRetention       = &ParametersRetention{}
 	ParamsSnapshotManager = &ParametersSnapshotManager{}
 )
 
@@ -60,6 +75,7 @@ var params = &app.ComponentParams{
 		"wal":          ParamsWAL,
 		"validator":    ParamsValidator,
 		"stateManager": ParamsStateManager,
+		"retention":    ParamsRetention,
 		"snapshots":    ParamsSnapshotManager,
 	},
 	Masked: nil,
@@ -11,6 +11,7 @@ import (
 	"github.com/iotaledger/inx-app/pkg/nodebridge"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/daemon"
+	"github.com/iotaledger/wasp/packages/l1simclient"
 	"github.com/iotaledger/wasp/packages/nodeconn"
 )
 
@@ -37,6 +38,23 @@ type dependencies struct {
 }
 
 func provide(c *dig.Container) error {
+	if ParamsL1Sim.URL != "" {
+		if err := c.Provide(func() chain.NodeConnection {
+			nodeConnection, err := l1simclient.New(
+				Component.Daemon().ContextStopped(),
+				ParamsL1Sim.URL,
+				Component.Logger().Named("nc"),
+			)
+			if err != nil {
+				Component.LogPanicf("Creating NodeConnection failed: %s", err.Error())
+			}
+			return nodeConnection
+		}); err != nil {
+			Component.LogPanic(err)
+		}
+		return nil
+	}
+
 	if err := c.Provide(func() (*nodebridge.NodeBridge, error) {
 		nodeBridge := nodebridge.NewNodeBridge(
 			Component.Logger(),
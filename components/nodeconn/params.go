@@ -5,11 +5,19 @@ import (
 	"github.com/iotaledger/inx-app/core/inx"
 )
 
-var ParamsINX = &inx.ParametersINX{}
+type ParametersL1Sim struct {
+	URL string `default:"" usage:"the URL of the L1 ledger simulator (see l1sim) to use instead of the INX connection; for testing only"`
+}
+
+var (
+	ParamsINX   = &inx.ParametersINX{}
+	ParamsL1Sim = &ParametersL1Sim{}
+)
 
 var params = &app.ComponentParams{
 	Params: map[string]any{
-		"inx": ParamsINX,
+		"inx":   ParamsINX,
+		"l1sim": ParamsL1Sim,
 	},
 	Masked: nil,
 }
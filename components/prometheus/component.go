@@ -48,10 +48,11 @@ type dependencies struct {
 	PrometheusEcho     *echo.Echo `name:"prometheusEcho"`
 	PrometheusRegistry *prometheus.Registry
 
-	AppInfo        *app.Info
-	ChainMetrics   *metrics.ChainMetricsProvider
-	PeeringMetrics *metrics.PeeringMetricsProvider
-	WebAPIEcho     *echo.Echo `name:"webapiEcho" optional:"true"`
+	AppInfo          *app.Info
+	ChainMetrics     *metrics.ChainMetricsProvider
+	PeeringMetrics   *metrics.PeeringMetricsProvider
+	RateLimitMetrics *metrics.WebAPIRateLimitMetricsProvider
+	WebAPIEcho       *echo.Echo `name:"webapiEcho" optional:"true"`
 }
 
 func provide(c *dig.Container) error {
@@ -63,6 +64,10 @@ func provide(c *dig.Container) error {
 		Component.LogPanic(err)
 	}
 
+	if err := c.Provide(metrics.NewWebAPIRateLimitMetricsProvider); err != nil {
+		Component.LogPanic(err)
+	}
+
 	type depsOut struct {
 		dig.Out
 		PrometheusEcho     *echo.Echo `name:"prometheusEcho"`
@@ -94,6 +99,7 @@ func configure() error {
 	registerNodeMetrics(reg, deps.AppInfo)
 	deps.PeeringMetrics.Register(reg)
 	registerRestAPIMetrics(reg, deps.WebAPIEcho)
+	deps.RateLimitMetrics.Register(reg)
 	deps.ChainMetrics.Register(reg)
 	return nil
 }
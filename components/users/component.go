@@ -2,6 +2,8 @@ package users
 
 import (
 	"encoding/hex"
+	"fmt"
+	"time"
 
 	"go.uber.org/dig"
 
@@ -40,11 +42,7 @@ func provide(c *dig.Container) error {
 			cfgUsers := make(map[string]*User)
 
 			for _, u := range users {
-				cfgUsers[u.Name] = &User{
-					PasswordHash: hex.EncodeToString(u.PasswordHash),
-					PasswordSalt: hex.EncodeToString(u.PasswordSalt),
-					Permissions:  u.PermissionsSlice(),
-				}
+				cfgUsers[u.Name] = userToConfig(u)
 			}
 
 			if err := deps.UsersConfig.Set(CfgUsers, cfgUsers); err != nil {
@@ -56,7 +54,7 @@ func provide(c *dig.Container) error {
 
 		// add users from config file to the user manager
 		for name, u := range ParamsUsers.Users {
-			user, err := users.NewUser(name, u.PasswordHash, u.PasswordSalt, u.PermissionsMap())
+			user, err := userFromConfig(name, u)
 			if err != nil {
 				Component.LogPanicf("unable to add user to user manager %s: %s", name, err)
 			}
@@ -77,3 +75,75 @@ func provide(c *dig.Container) error {
 
 	return nil
 }
+
+func userToConfig(u *users.User) *User {
+	apiKeys := make(map[string]*APIKey, len(u.APIKeys))
+	for _, key := range u.APIKeys {
+		apiKeys[key.ID] = &APIKey{
+			Name:        key.Name,
+			SecretHash:  hex.EncodeToString(key.SecretHash),
+			Permissions: key.PermissionsSlice(),
+			CreatedAt:   formatTime(key.CreatedAt),
+			ExpiresAt:   formatTime(key.ExpiresAt),
+		}
+	}
+
+	revokedTokens := make(map[string]string, len(u.RevokedTokens))
+	for id, expiresAt := range u.RevokedTokens {
+		revokedTokens[id] = formatTime(expiresAt)
+	}
+
+	return &User{
+		PasswordHash:    hex.EncodeToString(u.PasswordHash),
+		PasswordSalt:    hex.EncodeToString(u.PasswordSalt),
+		Permissions:     u.PermissionsSlice(),
+		APIKeys:         apiKeys,
+		TokensNotBefore: formatTime(u.TokensNotBefore),
+		RevokedTokens:   revokedTokens,
+	}
+}
+
+func userFromConfig(name string, u *User) (*users.User, error) {
+	user, err := users.NewUser(name, u.PasswordHash, u.PasswordSalt, u.PermissionsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	user.APIKeys = make(map[string]*users.APIKey, len(u.APIKeys))
+	for id, k := range u.APIKeys {
+		createdAt, err := parseTime(k.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid creation time of api key %s: %w", k.Name, err)
+		}
+		expiresAt, err := parseTime(k.ExpiresAt)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiry time of api key %s: %w", k.Name, err)
+		}
+
+		permissionsMap := make(map[string]struct{}, len(k.Permissions))
+		for _, permission := range k.Permissions {
+			permissionsMap[permission] = struct{}{}
+		}
+
+		key, err := users.NewAPIKey(k.Name, id, k.SecretHash, permissionsMap, createdAt, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		user.APIKeys[key.Name] = key
+	}
+
+	if user.TokensNotBefore, err = parseTime(u.TokensNotBefore); err != nil {
+		return nil, fmt.Errorf("invalid tokens not before time: %w", err)
+	}
+
+	user.RevokedTokens = make(map[string]time.Time, len(u.RevokedTokens))
+	for id, expiresAtStr := range u.RevokedTokens {
+		expiresAt, err := parseTime(expiresAtStr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid expiry time of revoked token %s: %w", id, err)
+		}
+		user.RevokedTokens[id] = expiresAt
+	}
+
+	return user, nil
+}
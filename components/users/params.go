@@ -1,6 +1,8 @@
 package users
 
 import (
+	"time"
+
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
 )
@@ -12,7 +14,34 @@ const (
 type User struct {
 	PasswordHash string   `default:"0000000000000000000000000000000000000000000000000000000000000000" usage:"the auth password+salt as a scrypt hash"`
 	PasswordSalt string   `default:"0000000000000000000000000000000000000000000000000000000000000000" usage:"the auth salt used for hashing the password"`
-	Permissions  []string `default:"" usage:"permissions of the user"`
+	Permissions  []string `default:"" usage:"permissions of the user (read, write, <capability>:<read|write> or chains/<chainID>:<read|write>)"`
+	// the following fields are managed by the node and omitted if empty
+	APIKeys         map[string]*APIKey `json:"APIKeys,omitempty" usage:"the API keys of the user by ID"`
+	TokensNotBefore string             `json:"TokensNotBefore,omitempty" usage:"all tokens of the user issued until then are revoked (RFC3339)"`
+	RevokedTokens   map[string]string  `json:"RevokedTokens,omitempty" usage:"the IDs of the revoked tokens of the user with their expiry time (RFC3339)"`
+}
+
+// APIKey is stored by its ID, since all keys of the config file are lowercased when loading it.
+type APIKey struct {
+	Name        string   `usage:"the name of the API key"`
+	SecretHash  string   `usage:"the sha256 hash of the secret of the API key"`
+	Permissions []string `usage:"permissions of the API key"`
+	CreatedAt   string   `usage:"the creation time of the API key (RFC3339)"`
+	ExpiresAt   string   `json:"ExpiresAt,omitempty" usage:"the expiry time of the API key (RFC3339), empty if it doesn't expire"`
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, s)
 }
 
 // PermissionsMap returns the permissions of the user as a map.
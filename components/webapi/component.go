@@ -34,6 +34,7 @@ import (
 	"github.com/iotaledger/wasp/packages/users"
 	"github.com/iotaledger/wasp/packages/webapi"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/auditlog"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
 	"github.com/iotaledger/wasp/packages/webapi/websocket"
 )
@@ -220,6 +221,7 @@ func provide(c *dig.Container) error {
 		APICacheTTL                 time.Duration `name:"apiCacheTTL"`
 		Chains                      *chains.Chains
 		ChainMetricsProvider        *metrics.ChainMetricsProvider
+		RateLimitMetricsProvider    *metrics.WebAPIRateLimitMetricsProvider
 		ChainRecordRegistryProvider registry.ChainRecordRegistryProvider
 		DKShareRegistryProvider     registry.DKShareRegistryProvider
 		NodeIdentityProvider        registry.NodeIdentityProvider
@@ -267,6 +269,11 @@ func provide(c *dig.Container) error {
 			}))
 		}
 
+		auditLog, err := auditlog.Open(logger.Named("AuditLog"), ParamsWebAPI.AuditLog)
+		if err != nil {
+			Component.LogPanicf("failed to open the audit log: %s", err)
+		}
+
 		webapi.Init(
 			logger,
 			echoSwagger,
@@ -291,6 +298,9 @@ func provide(c *dig.Container) error {
 			websocketService,
 			ParamsWebAPI.IndexDbPath,
 			deps.Publisher,
+			ParamsWebAPI.RateLimit,
+			deps.RateLimitMetricsProvider,
+			auditLog,
 		)
 
 		return webapiServerResult{
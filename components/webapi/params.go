@@ -5,6 +5,8 @@ import (
 
 	"github.com/iotaledger/hive.go/app"
 	"github.com/iotaledger/wasp/packages/authentication"
+	"github.com/iotaledger/wasp/packages/webapi/auditlog"
+	"github.com/iotaledger/wasp/packages/webapi/ratelimit"
 )
 
 type ParametersWebAPI struct {
@@ -21,6 +23,9 @@ type ParametersWebAPI struct {
 		ConfirmedStateLagThreshold     uint32        `default:"2" usage:"the threshold that define a chain is unsynchronized"`
 	}
 
+	RateLimit ratelimit.Config `usage:"configures the rate limiting of the requests per client"`
+	AuditLog  auditlog.Config  `usage:"configures the audit log of the administrative calls"`
+
 	DebugRequestLoggerEnabled bool `default:"false" usage:"whether the debug logging for requests should be enabled"`
 }
 
@@ -28,9 +33,12 @@ var ParamsWebAPI = &ParametersWebAPI{
 	Auth: authentication.AuthConfiguration{
 		Scheme: "jwt",
 		JWTConfig: authentication.JWTAuthConfiguration{
-			Duration: 24 * time.Hour,
+			Duration:        24 * time.Hour,
+			RefreshDuration: 30 * 24 * time.Hour,
 		},
 	},
+	RateLimit: ratelimit.DefaultConfig,
+	AuditLog:  auditlog.DefaultConfig,
 }
 
 var params = &app.ComponentParams{
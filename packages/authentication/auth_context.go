@@ -17,3 +17,21 @@ func (a *AuthContext) Name() string {
 func (a *AuthContext) Scheme() string {
 	return a.scheme
 }
+
+// IsGranted checks whether the user of the request has the given permission
+// (always true, if the authentication is disabled).
+func (a *AuthContext) IsGranted(permission string) bool {
+	if a.scheme == AuthNone {
+		return true
+	}
+	return a.claims != nil && a.claims.HasPermission(permission)
+}
+
+// Username returns the name of the user authenticated by the token of the
+// request, or "" if there is none (or the authentication is disabled).
+func Username(c echo.Context) string {
+	if authContext, ok := c.Get("auth").(*AuthContext); ok {
+		return authContext.name
+	}
+	return ""
+}
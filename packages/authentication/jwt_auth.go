@@ -1,8 +1,9 @@
 package authentication
 
 import (
+	"crypto/rand"
 	"crypto/subtle"
-	"fmt"
+	"encoding/hex"
 	"net/http"
 	"time"
 
@@ -21,37 +22,64 @@ var (
 
 const (
 	JWTContextKey = "jwt"
+
+	// TokenTypeRefresh marks the refresh tokens, which can only be used to get a new JWT.
+	TokenTypeRefresh = "refresh"
 )
 
+var DefaultJWTRefreshDuration = 30 * 24 * time.Hour
+
 type JWTAuth struct {
-	duration time.Duration
-	nodeID   string
-	secret   []byte
+	duration        time.Duration
+	refreshDuration time.Duration
+	nodeID          string
+	secret          []byte
 }
 
 func NewJWTAuth(duration time.Duration, nodeIDKeypair *cryptolib.KeyPair) *JWTAuth {
 	return &JWTAuth{
-		duration: duration,
-		nodeID:   nodeIDKeypair.Address().String(),
-		secret:   nodeIDKeypair.GetPrivateKey().AsBytes(),
+		duration:        duration,
+		refreshDuration: DefaultJWTRefreshDuration,
+		nodeID:          nodeIDKeypair.Address().String(),
+		secret:          nodeIDKeypair.GetPrivateKey().AsBytes(),
 	}
 }
 
 func (j *JWTAuth) IssueJWT(username string, claims *WaspClaims) (string, error) {
+	return j.issue(username, claims, j.duration, time.Now())
+}
+
+// IssueRefreshToken issues a token that can be exchanged for a new JWT (and refresh token).
+func (j *JWTAuth) IssueRefreshToken(username string) (string, error) {
+	return j.issueRefreshToken(username, time.Now())
+}
+
+func (j *JWTAuth) issueRefreshToken(username string, issuedAt time.Time) (string, error) {
+	return j.issue(username, &WaspClaims{TokenType: TokenTypeRefresh}, j.refreshDuration, issuedAt)
+}
+
+// issue issues a token. The issue time may be after now (see users.User.TokenIssueTime).
+func (j *JWTAuth) issue(username string, claims *WaspClaims, duration time.Duration, issuedAt time.Time) (string, error) {
 	now := time.Now()
 
+	// the ID is needed to revoke single tokens
+	id, err := newTokenID()
+	if err != nil {
+		return "", err
+	}
+
 	// Set claims
 	registeredClaims := jwt.RegisteredClaims{
 		Subject:   username,
 		Issuer:    j.nodeID,
 		Audience:  jwt.ClaimStrings{username},
-		ID:        fmt.Sprintf("%d", now.Unix()),
-		IssuedAt:  jwt.NewNumericDate(now),
+		ID:        id,
+		IssuedAt:  jwt.NewNumericDate(issuedAt),
 		NotBefore: jwt.NewNumericDate(now),
 	}
 
-	if j.duration > 0 {
-		registeredClaims.ExpiresAt = jwt.NewNumericDate(now.Add(j.duration))
+	if duration > 0 {
+		registeredClaims.ExpiresAt = jwt.NewNumericDate(now.Add(duration))
 	}
 
 	claims.RegisteredClaims = registeredClaims
@@ -63,26 +91,27 @@ func (j *JWTAuth) IssueJWT(username string, claims *WaspClaims) (string, error)
 	return token.SignedString(j.secret)
 }
 
+func newTokenID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id), nil
+}
+
 type WaspClaims struct {
 	jwt.RegisteredClaims
 	Permissions map[string]struct{} `json:"permissions"`
+	TokenType   string              `json:"tokenType,omitempty"`
 }
 
 func (c *WaspClaims) HasPermission(permission string) bool {
-	_, exists := c.Permissions[permission]
-
-	if exists {
-		return true
-	}
-
-	if permission == permissions.Read {
-		// If a user only has write permissions, it should still be able to read.
-		_, exists = c.Permissions[permissions.Write]
-
-		return exists
-	}
+	return c.HasChainPermission(permission, "")
+}
 
-	return false
+// HasChainPermission checks the permission required for the given chain (see permissions.IsGranted)
+func (c *WaspClaims) HasChainPermission(permission, chainID string) bool {
+	return permissions.IsGranted(c.Permissions, permission, chainID)
 }
 
 func (c *WaspClaims) compare(field, expected string) bool {
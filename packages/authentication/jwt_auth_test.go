@@ -1,9 +1,13 @@
 package authentication_test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -158,3 +162,227 @@ func TestJWTAuthIssueAndVerify(t *testing.T) {
 
 	require.Equal(t, http.StatusOK, res.Code)
 }
+
+func newTestAuthServer(t *testing.T, userManager *users.UserManager) (*echo.Echo, *authentication.JWTAuth) {
+	e := echo.New()
+	nodeIDKeypair := cryptolib.KeyPairFromSeed(cryptolib.SeedFromBytes([]byte("abc")))
+
+	jwtAuth, middleware := authentication.GetJWTAuthMiddleware(
+		authentication.JWTAuthConfiguration{Duration: time.Hour, RefreshDuration: 2 * time.Hour},
+		nodeIDKeypair,
+		userManager,
+	)
+	authHandler := &authentication.AuthHandler{Jwt: jwtAuth, UserManager: userManager}
+
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("auth", &authentication.AuthContext{})
+			return next(c)
+		}
+	})
+	e.Use(middleware)
+	e.POST(shared.AuthRoute(), authHandler.JWTLoginHandler)
+	e.POST(shared.AuthRefreshRoute(), authHandler.JWTRefreshHandler)
+	e.POST(shared.AuthRevokeRoute(), authHandler.JWTRevokeHandler)
+	e.GET("/test-route", func(c echo.Context) error {
+		token := c.Get(authentication.JWTContextKey).(*jwt.Token)
+		return c.JSON(http.StatusOK, token.Claims)
+	})
+
+	return e, jwtAuth
+}
+
+func callTestRoute(e *echo.Echo, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/test-route", http.NoBody)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	return res
+}
+
+func postJSON(e *echo.Echo, path string, body any) *httptest.ResponseRecorder {
+	data, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader(data))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	return res
+}
+
+func TestJWTRefreshAndRevoke(t *testing.T) {
+	userManager := users.NewUserManager(func(users []*users.User) error { return nil })
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "wasp",
+		Permissions: map[string]struct{}{"write": {}},
+	}))
+	e, jwtAuth := newTestAuthServer(t, userManager)
+
+	token, err := jwtAuth.IssueJWT("wasp", &authentication.WaspClaims{Permissions: map[string]struct{}{"write": {}}})
+	require.NoError(t, err)
+	refreshToken, err := jwtAuth.IssueRefreshToken("wasp")
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, callTestRoute(e, token).Code)
+	// a refresh token can't be used to access the API
+	require.Equal(t, http.StatusUnauthorized, callTestRoute(e, refreshToken).Code)
+	// and a JWT can't be used as refresh token
+	require.Equal(t, http.StatusUnauthorized, postJSON(e, shared.AuthRefreshRoute(), shared.RefreshTokenRequest{RefreshToken: token}).Code)
+
+	res := postJSON(e, shared.AuthRefreshRoute(), shared.RefreshTokenRequest{RefreshToken: refreshToken})
+	require.Equal(t, http.StatusOK, res.Code)
+	var refreshed shared.LoginResponse
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &refreshed))
+	require.NotEmpty(t, refreshed.JWT)
+	require.NotEmpty(t, refreshed.RefreshToken)
+	require.Equal(t, http.StatusOK, callTestRoute(e, refreshed.JWT).Code)
+
+	// the refresh token can only be used once
+	require.Equal(t, http.StatusUnauthorized, postJSON(e, shared.AuthRefreshRoute(), shared.RefreshTokenRequest{RefreshToken: refreshToken}).Code)
+
+	// revoking a single token doesn't affect the others
+	require.Equal(t, http.StatusOK, postJSON(e, shared.AuthRevokeRoute(), shared.RevokeTokenRequest{Token: token}).Code)
+	require.Equal(t, http.StatusUnauthorized, callTestRoute(e, token).Code)
+	require.Equal(t, http.StatusOK, callTestRoute(e, refreshed.JWT).Code)
+
+	// revoking all tokens of the user
+	require.NoError(t, userManager.RevokeAllTokens("wasp"))
+	require.Equal(t, http.StatusUnauthorized, callTestRoute(e, refreshed.JWT).Code)
+	require.Equal(t, http.StatusUnauthorized, postJSON(e, shared.AuthRefreshRoute(), shared.RefreshTokenRequest{RefreshToken: refreshed.RefreshToken}).Code)
+}
+
+func TestJWTRefreshConcurrently(t *testing.T) {
+	userManager := users.NewUserManager(func(users []*users.User) error { return nil })
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "wasp",
+		Permissions: map[string]struct{}{"write": {}},
+	}))
+	e, jwtAuth := newTestAuthServer(t, userManager)
+
+	refreshToken, err := jwtAuth.IssueRefreshToken("wasp")
+	require.NoError(t, err)
+
+	// the refresh token can only be used once, even by concurrent requests
+	const n = 10
+	var wg sync.WaitGroup
+	var refreshed atomic.Int32
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if postJSON(e, shared.AuthRefreshRoute(), shared.RefreshTokenRequest{RefreshToken: refreshToken}).Code == http.StatusOK {
+				refreshed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.EqualValues(t, 1, refreshed.Load())
+}
+
+func TestJWTRefreshDoesNotStoreUsers(t *testing.T) {
+	var stored atomic.Int32
+	userManager := users.NewUserManager(func(users []*users.User) error {
+		stored.Add(1)
+		return nil
+	})
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "wasp",
+		Permissions: map[string]struct{}{"write": {}},
+	}))
+	userManager.EnableStoreOnChange()
+	e, jwtAuth := newTestAuthServer(t, userManager)
+
+	refreshToken, err := jwtAuth.IssueRefreshToken("wasp")
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		res := postJSON(e, shared.AuthRefreshRoute(), shared.RefreshTokenRequest{RefreshToken: refreshToken})
+		require.Equal(t, http.StatusOK, res.Code)
+		var refreshed shared.LoginResponse
+		require.NoError(t, json.Unmarshal(res.Body.Bytes(), &refreshed))
+
+		// the used refresh token is still rejected
+		require.Equal(t, http.StatusUnauthorized, postJSON(e, shared.AuthRefreshRoute(), shared.RefreshTokenRequest{RefreshToken: refreshToken}).Code)
+		refreshToken = refreshed.RefreshToken
+	}
+
+	require.Zero(t, stored.Load())
+	user, err := userManager.User("wasp")
+	require.NoError(t, err)
+	require.Empty(t, user.RevokedTokens)
+}
+
+func TestJWTLoginAfterRevokeAll(t *testing.T) {
+	passwordHash, passwordSalt, err := users.DerivePasswordKey("secret")
+	require.NoError(t, err)
+	userManager := users.NewUserManager(func(users []*users.User) error { return nil })
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:         "wasp",
+		PasswordHash: passwordHash,
+		PasswordSalt: passwordSalt,
+		Permissions:  map[string]struct{}{"write": {}},
+	}))
+	e, jwtAuth := newTestAuthServer(t, userManager)
+
+	token, err := jwtAuth.IssueJWT("wasp", &authentication.WaspClaims{Permissions: map[string]struct{}{"write": {}}})
+	require.NoError(t, err)
+	require.NoError(t, userManager.RevokeAllTokens("wasp"))
+	require.Equal(t, http.StatusUnauthorized, callTestRoute(e, token).Code)
+
+	// the tokens issued in the same second as the revocation are valid
+	res := postJSON(e, shared.AuthRoute(), shared.LoginRequest{Username: "wasp", Password: "secret"})
+	require.Equal(t, http.StatusOK, res.Code)
+	var login shared.LoginResponse
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &login))
+	require.Equal(t, http.StatusOK, callTestRoute(e, login.JWT).Code)
+	require.Equal(t, http.StatusOK, postJSON(e, shared.AuthRefreshRoute(), shared.RefreshTokenRequest{RefreshToken: login.RefreshToken}).Code)
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	userManager := users.NewUserManager(func(users []*users.User) error { return nil })
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "wasp",
+		Permissions: map[string]struct{}{"chains:write": {}, "node:read": {}},
+	}))
+	e, _ := newTestAuthServer(t, userManager)
+
+	// the permissions of the key must be granted to the user
+	_, _, err := userManager.AddAPIKey("wasp", "ci", map[string]struct{}{"write": {}}, time.Time{})
+	require.Error(t, err)
+
+	key, _, err := userManager.AddAPIKey("wasp", "ci", map[string]struct{}{"chains:read": {}, "node:read": {}}, time.Time{})
+	require.NoError(t, err)
+	require.True(t, users.IsAPIKey(key))
+	_, _, err = userManager.AddAPIKey("wasp", "ci", map[string]struct{}{"chains:read": {}}, time.Time{})
+	require.Error(t, err, "duplicate name")
+
+	res := callTestRoute(e, key)
+	require.Equal(t, http.StatusOK, res.Code)
+	var claims authentication.WaspClaims
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &claims))
+	require.Equal(t, "wasp", claims.Subject)
+	require.Equal(t, map[string]struct{}{"chains:read": {}, "node:read": {}}, claims.Permissions)
+
+	// only the permissions still granted to the user apply
+	require.NoError(t, userManager.ChangeUserPermissions("wasp", map[string]struct{}{"chains:write": {}}))
+	res = callTestRoute(e, key)
+	require.Equal(t, http.StatusOK, res.Code)
+	claims = authentication.WaspClaims{}
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &claims))
+	require.Equal(t, map[string]struct{}{"chains:read": {}}, claims.Permissions)
+
+	// a wrong secret is rejected
+	wrongKey := key[:len(key)-1] + "0"
+	if wrongKey == key {
+		wrongKey = key[:len(key)-1] + "1"
+	}
+	require.Equal(t, http.StatusUnauthorized, callTestRoute(e, wrongKey).Code)
+
+	// expired keys are rejected
+	expiredKey, _, err := userManager.AddAPIKey("wasp", "expired", map[string]struct{}{"chains:read": {}}, time.Now().Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, http.StatusUnauthorized, callTestRoute(e, expiredKey).Code)
+
+	// removed keys are rejected
+	require.NoError(t, userManager.RemoveAPIKey("wasp", "ci"))
+	require.Equal(t, http.StatusUnauthorized, callTestRoute(e, key).Code)
+	require.Error(t, userManager.RemoveAPIKey("wasp", "ci"))
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 
@@ -22,20 +23,103 @@ func (a *AuthHandler) JWTLoginHandler(c echo.Context) error {
 		return errors.New("invalid login request")
 	}
 
-	req, user, err := a.parseAuthRequest(c)
+	_, user, err := a.parseAuthRequest(c)
 	if err != nil {
 		return c.JSON(http.StatusUnauthorized, shared.LoginResponse{Error: err})
 	}
 
-	claims := &WaspClaims{
+	response, err := a.issueTokens(user)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, shared.LoginResponse{Error: fmt.Errorf("unable to login")})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// JWTRefreshHandler exchanges a refresh token for a new JWT and refresh token.
+// The used refresh token is revoked, so that every refresh token can only be used once.
+func (a *AuthHandler) JWTRefreshHandler(c echo.Context) error {
+	request := &shared.RefreshTokenRequest{}
+	if err := c.Bind(request); err != nil {
+		return c.JSON(http.StatusUnauthorized, shared.LoginResponse{Error: fmt.Errorf("invalid form data")})
+	}
+
+	_, claims, err := a.Jwt.parseJWT(request.RefreshToken, a.UserManager)
+	if err != nil || claims.TokenType != TokenTypeRefresh {
+		return c.JSON(http.StatusUnauthorized, shared.LoginResponse{Error: fmt.Errorf("invalid refresh token")})
+	}
+
+	// checking and revoking the refresh token in one step makes sure it is used only once
+	if err := a.UserManager.UseToken(claims.Subject, claims.ID, issuedAt(claims), expiresAt(claims)); err != nil {
+		if errors.Is(err, users.ErrTokenRevoked) {
+			return c.JSON(http.StatusUnauthorized, shared.LoginResponse{Error: fmt.Errorf("invalid refresh token")})
+		}
+		return c.JSON(http.StatusUnauthorized, shared.LoginResponse{Error: fmt.Errorf("unable to refresh")})
+	}
+
+	// the new JWT gets the current permissions of the user
+	user, err := a.UserManager.User(claims.Subject)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, shared.LoginResponse{Error: fmt.Errorf("invalid refresh token")})
+	}
+
+	response, err := a.issueTokens(user)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, shared.LoginResponse{Error: fmt.Errorf("unable to refresh")})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// JWTRevokeHandler revokes a JWT or refresh token. Having the token is enough to revoke it.
+func (a *AuthHandler) JWTRevokeHandler(c echo.Context) error {
+	request := &shared.RevokeTokenRequest{}
+	if err := c.Bind(request); err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid form data")
+	}
+
+	_, claims, err := a.Jwt.parseJWT(request.Token, a.UserManager)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+	}
+
+	if err := a.UserManager.RevokeToken(claims.Subject, claims.ID, expiresAt(claims)); err != nil {
+		return echo.NewHTTPError(http.StatusUnauthorized, "unable to revoke token")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+func (a *AuthHandler) issueTokens(user *users.User) (*shared.LoginResponse, error) {
+	issueTime := user.TokenIssueTime()
+
+	token, err := a.Jwt.issue(user.Name, &WaspClaims{
 		Permissions: user.Permissions,
+	}, a.Jwt.duration, issueTime)
+	if err != nil {
+		return nil, err
 	}
-	token, err := a.Jwt.IssueJWT(req.Username, claims)
+
+	refreshToken, err := a.Jwt.issueRefreshToken(user.Name, issueTime)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, shared.LoginResponse{Error: fmt.Errorf("unable to login")})
+		return nil, err
+	}
+
+	return &shared.LoginResponse{JWT: token, RefreshToken: refreshToken}, nil
+}
+
+func issuedAt(claims *WaspClaims) time.Time {
+	if claims.IssuedAt == nil {
+		return time.Time{}
 	}
+	return claims.IssuedAt.Time
+}
 
-	return c.JSON(http.StatusOK, shared.LoginResponse{JWT: token})
+func expiresAt(claims *WaspClaims) time.Time {
+	if claims.ExpiresAt == nil {
+		return time.Time{}
+	}
+	return claims.ExpiresAt.Time
 }
 
 func (a *AuthHandler) parseAuthRequest(c echo.Context) (*shared.LoginRequest, *users.User, error) {
@@ -20,7 +20,8 @@ const (
 )
 
 type JWTAuthConfiguration struct {
-	Duration time.Duration `default:"24h" usage:"jwt token lifetime"`
+	Duration        time.Duration `default:"24h" usage:"jwt token lifetime"`
+	RefreshDuration time.Duration `default:"720h" usage:"refresh token lifetime"`
 }
 
 type AuthConfiguration struct {
@@ -45,17 +46,6 @@ func AddAuthentication(
 	echoRoot := apiRoot.Echo()
 	authGroup := apiRoot.Group("auth", "")
 
-	// initialize AuthContext obj as var in echo.Context
-	echoRoot.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
-		return func(c echo.Context) error {
-			c.Set("auth", &AuthContext{
-				scheme: authConfig.Scheme,
-			})
-
-			return next(c)
-		}
-	})
-
 	// set AuthInfo route
 	authGroup.GET(shared.AuthInfoRoute(), authInfoHandler(authConfig)).
 		AddResponse(http.StatusOK, "Login was successful", mocker.Get(shared.AuthInfoModel{}), nil).
@@ -64,25 +54,45 @@ func AddAuthentication(
 
 	// set Auth route
 	var middleware echo.MiddlewareFunc
-	var handler echo.HandlerFunc
+	var handler, refreshHandler, revokeHandler echo.HandlerFunc
+	var jwtAuth *JWTAuth
 	switch authConfig.Scheme {
 	case AuthJWT:
-		var jwtAuth *JWTAuth
 		nodeIDKeypair := nodeIdentityProvider.NodeIdentity()
 
 		// The primary claim is the one mandatory claim that gives access to api/webapi/alike
 		jwtAuth, middleware = GetJWTAuthMiddleware(authConfig.JWTConfig, nodeIDKeypair, userManager)
 		authHandler := &AuthHandler{Jwt: jwtAuth, UserManager: userManager}
 		handler = authHandler.JWTLoginHandler
+		refreshHandler = authHandler.JWTRefreshHandler
+		revokeHandler = authHandler.JWTRevokeHandler
 
 	case AuthNone:
 		middleware = GetNoneAuthMiddleware()
 		handler = nil
+		refreshHandler = nil
+		revokeHandler = nil
 
 	default:
 		panic(fmt.Sprintf("Unknown auth scheme %s", authConfig.Scheme))
 	}
 
+	// initialize AuthContext obj as var in echo.Context
+	echoRoot.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			authContext := &AuthContext{
+				scheme: authConfig.Scheme,
+			}
+			if jwtAuth != nil {
+				// the user is known on the public routes too, if a valid token is supplied (e.g. for the rate limiter)
+				authContext.name = jwtAuth.usernameFromRequest(c, userManager)
+			}
+			c.Set("auth", authContext)
+
+			return next(c)
+		}
+	})
+
 	authGroup.POST(shared.AuthRoute(), handler).
 		AddParamBody(mocker.Get(shared.LoginRequest{}), "", "The login request", true).
 		AddResponse(http.StatusUnauthorized, "Unauthorized (Wrong permissions, missing token)", nil, nil).
@@ -90,6 +100,22 @@ func AddAuthentication(
 		AddResponse(http.StatusOK, "Login was successful", mocker.Get(shared.LoginResponse{}), nil).
 		SetOperationId("authenticate").
 		SetSummary("Authenticate towards the node")
+
+	authGroup.POST(shared.AuthRefreshRoute(), refreshHandler).
+		AddParamBody(mocker.Get(shared.RefreshTokenRequest{}), "", "The refresh request", true).
+		AddResponse(http.StatusUnauthorized, "Unauthorized (Invalid, expired or revoked refresh token)", nil, nil).
+		AddResponse(http.StatusMethodNotAllowed, "auth type: none", nil, nil).
+		AddResponse(http.StatusOK, "Refresh was successful", mocker.Get(shared.LoginResponse{}), nil).
+		SetOperationId("refreshToken").
+		SetSummary("Get a new JWT and refresh token in exchange for a refresh token")
+
+	authGroup.POST(shared.AuthRevokeRoute(), revokeHandler).
+		AddParamBody(mocker.Get(shared.RevokeTokenRequest{}), "", "The revoke request", true).
+		AddResponse(http.StatusUnauthorized, "Unauthorized (Invalid token)", nil, nil).
+		AddResponse(http.StatusMethodNotAllowed, "auth type: none", nil, nil).
+		AddResponse(http.StatusOK, "The token was revoked", nil, nil).
+		SetOperationId("revokeToken").
+		SetSummary("Revoke a JWT or refresh token")
 	return middleware
 }
 
@@ -1,7 +1,111 @@
 package permissions
 
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// The permission levels. Without a capability (see below), they apply to the whole node.
+// The write permission implies the read permission.
 const (
 	API   = "api"
 	Read  = "read"
 	Write = "write"
 )
+
+// The capabilities the permissions can be restricted to, as "<capability>:<level>" (e.g. "peering:write").
+// The Chains capability can be further restricted to a single chain, as "chains/<chainID>:<level>".
+const (
+	Chains  = "chains"
+	Peering = "peering"
+	Users   = "users"
+	DKG     = "dkg"
+	Node    = "node"
+	Audit   = "audit"
+)
+
+var Capabilities = []string{Chains, Peering, Users, DKG, Node, Audit}
+
+// The permissions required by the routes of the web API
+var (
+	ChainsRead   = Scoped(Chains, Read)
+	ChainsWrite  = Scoped(Chains, Write)
+	PeeringRead  = Scoped(Peering, Read)
+	PeeringWrite = Scoped(Peering, Write)
+	UsersRead    = Scoped(Users, Read)
+	UsersWrite   = Scoped(Users, Write)
+	DKGRead      = Scoped(DKG, Read)
+	DKGWrite     = Scoped(DKG, Write)
+	NodeRead     = Scoped(Node, Read)
+	NodeWrite    = Scoped(Node, Write)
+	AuditRead    = Scoped(Audit, Read)
+)
+
+// Scoped returns the permission restricted to the given capability
+func Scoped(capability, level string) string {
+	return capability + ":" + level
+}
+
+// ChainScoped returns the permission restricted to the given chain
+func ChainScoped(chainID, level string) string {
+	return Chains + "/" + chainID + ":" + level
+}
+
+// Parse splits a permission into its capability and chain ID (empty, if the
+// permission is not restricted to them) and level.
+func Parse(permission string) (capability, chainID, level string, err error) {
+	scope, level, scoped := strings.Cut(permission, ":")
+	if !scoped {
+		scope, level = "", permission
+	}
+	if level != Read && level != Write {
+		return "", "", "", fmt.Errorf("invalid permission %q: unknown level %q", permission, level)
+	}
+	if !scoped {
+		return "", "", level, nil
+	}
+
+	capability, chainID, chainScoped := strings.Cut(scope, "/")
+	if !slices.Contains(Capabilities, capability) {
+		return "", "", "", fmt.Errorf("invalid permission %q: unknown capability %q", permission, capability)
+	}
+	if chainScoped && (capability != Chains || chainID == "") {
+		return "", "", "", fmt.Errorf("invalid permission %q: only the %s capability can be restricted to a chain", permission, Chains)
+	}
+	return capability, chainID, level, nil
+}
+
+// IsGranted checks whether the required permission is granted by the given
+// permissions. The chain ID (if not empty) is the chain the permission is
+// required for, so that the permissions restricted to it are considered too.
+func IsGranted(granted map[string]struct{}, required, chainID string) bool {
+	capability, requiredChainID, level, err := Parse(required)
+	if err != nil {
+		return false
+	}
+	if requiredChainID != "" {
+		chainID = requiredChainID
+	}
+
+	levels := []string{level}
+	if level == Read {
+		levels = append(levels, Write)
+	}
+	for _, l := range levels {
+		candidates := []string{l}
+		if capability != "" {
+			candidates = append(candidates, Scoped(capability, l))
+		}
+		if capability == Chains && chainID != "" {
+			candidates = append(candidates, ChainScoped(chainID, l))
+		}
+		for _, candidate := range candidates {
+			if _, ok := granted[candidate]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
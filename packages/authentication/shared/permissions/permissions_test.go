@@ -0,0 +1,57 @@
+package permissions_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
+)
+
+func TestParse(t *testing.T) {
+	for _, valid := range []string{"read", "write", "chains:read", "peering:write", "users:write", "dkg:read", "node:write", "chains/tgl1abc:write"} {
+		_, _, _, err := permissions.Parse(valid)
+		require.NoError(t, err, valid)
+	}
+	for _, invalid := range []string{"", "api", "admin", "chains", "chains:admin", "foo:read", "peering/tgl1abc:read", "chains/:read", ":read"} {
+		_, _, _, err := permissions.Parse(invalid)
+		require.Error(t, err, invalid)
+	}
+
+	capability, chainID, level, err := permissions.Parse(permissions.ChainScoped("tgl1abc", permissions.Read))
+	require.NoError(t, err)
+	require.Equal(t, permissions.Chains, capability)
+	require.Equal(t, "tgl1abc", chainID)
+	require.Equal(t, permissions.Read, level)
+}
+
+func TestIsGranted(t *testing.T) {
+	granted := func(perms ...string) map[string]struct{} {
+		ret := map[string]struct{}{}
+		for _, p := range perms {
+			ret[p] = struct{}{}
+		}
+		return ret
+	}
+
+	// global permissions apply to every capability
+	require.True(t, permissions.IsGranted(granted(permissions.Write), permissions.NodeWrite, ""))
+	require.True(t, permissions.IsGranted(granted(permissions.Write), permissions.ChainsRead, "tgl1abc"))
+	require.True(t, permissions.IsGranted(granted(permissions.Read), permissions.UsersRead, ""))
+	require.False(t, permissions.IsGranted(granted(permissions.Read), permissions.UsersWrite, ""))
+
+	// per capability
+	require.True(t, permissions.IsGranted(granted(permissions.PeeringWrite), permissions.PeeringRead, ""))
+	require.False(t, permissions.IsGranted(granted(permissions.PeeringWrite), permissions.NodeWrite, ""))
+	require.False(t, permissions.IsGranted(granted(permissions.PeeringWrite), permissions.Write, ""))
+	require.True(t, permissions.IsGranted(granted(permissions.ChainsRead), permissions.ChainsRead, "tgl1abc"))
+
+	// per chain
+	chainWrite := granted(permissions.ChainScoped("tgl1abc", permissions.Write))
+	require.True(t, permissions.IsGranted(chainWrite, permissions.ChainsWrite, "tgl1abc"))
+	require.True(t, permissions.IsGranted(chainWrite, permissions.ChainsRead, "tgl1abc"))
+	require.True(t, permissions.IsGranted(chainWrite, permissions.ChainScoped("tgl1abc", permissions.Read), ""))
+	require.False(t, permissions.IsGranted(chainWrite, permissions.ChainsWrite, "tgl1xyz"))
+	require.False(t, permissions.IsGranted(chainWrite, permissions.ChainsRead, ""))
+	require.False(t, permissions.IsGranted(chainWrite, permissions.NodeRead, ""))
+}
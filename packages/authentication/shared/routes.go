@@ -8,6 +8,14 @@ func AuthInfoRoute() string {
 	return "/auth/info"
 }
 
+func AuthRefreshRoute() string {
+	return "/auth/refresh"
+}
+
+func AuthRevokeRoute() string {
+	return "/auth/revoke"
+}
+
 type AuthInfoModel struct {
 	Scheme  string `json:"scheme" swagger:"desc(Authentication scheme (jwt, basic, ip)),required"`
 	AuthURL string `json:"authURL" swagger:"desc(JWT only),required"`
@@ -19,6 +27,15 @@ type LoginRequest struct {
 }
 
 type LoginResponse struct {
-	JWT   string `json:"jwt,omitempty" swagger:"required"`
-	Error error  `json:"error,omitempty" swagger:"required"`
+	JWT          string `json:"jwt,omitempty" swagger:"required"`
+	RefreshToken string `json:"refreshToken,omitempty" swagger:"desc(The token to get a new JWT with, before the refresh token expires)"`
+	Error        error  `json:"error,omitempty" swagger:"required"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken" swagger:"desc(The refresh token, it is revoked after use),required"`
+}
+
+type RevokeTokenRequest struct {
+	Token string `json:"token" swagger:"desc(The JWT or refresh token to revoke),required"`
 }
@@ -2,6 +2,7 @@ package authentication
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -9,12 +10,15 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/iotaledger/wasp/packages/authentication/shared"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/users"
 )
 
 var DefaultJWTDuration time.Duration
 
+const bearerPrefix = "Bearer "
+
 func GetJWTAuthMiddleware(
 	config JWTAuthConfiguration,
 	nodeIDKeypair *cryptolib.KeyPair,
@@ -27,6 +31,9 @@ func GetJWTAuthMiddleware(
 	}
 
 	jwtAuth := NewJWTAuth(duration, nodeIDKeypair)
+	if config.RefreshDuration != 0 {
+		jwtAuth.refreshDuration = config.RefreshDuration
+	}
 
 	authMiddleware := echojwt.WithConfig(echojwt.Config{
 		ContextKey: JWTContextKey,
@@ -38,6 +45,8 @@ func GetJWTAuthMiddleware(
 			if path == "/" ||
 				path == shared.AuthRoute() ||
 				path == shared.AuthInfoRoute() ||
+				path == shared.AuthRefreshRoute() ||
+				path == shared.AuthRevokeRoute() ||
 				path == "/doc" {
 				return true
 			}
@@ -47,44 +56,10 @@ func GetJWTAuthMiddleware(
 		SigningKey:  jwtAuth.secret,
 		TokenLookup: "header:Authorization:Bearer ,cookie:jwt",
 		ParseTokenFunc: func(c echo.Context, auth string) (interface{}, error) {
-			keyFunc := func(t *jwt.Token) (interface{}, error) {
-				if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-					return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-				}
-
-				return jwtAuth.secret, nil
-			}
-
-			token, err := jwt.ParseWithClaims(
-				auth,
-				&WaspClaims{},
-				keyFunc,
-				jwt.WithValidMethods([]string{"HS256"}),
-			)
+			token, claims, err := jwtAuth.parseToken(auth, userManager)
 			if err != nil {
 				return nil, err
 			}
-			if !token.Valid {
-				return nil, fmt.Errorf("invalid token")
-			}
-
-			claims, ok := token.Claims.(*WaspClaims)
-			if !ok {
-				return nil, fmt.Errorf("wrong JWT claim type")
-			}
-
-			userMap := userManager.Users()
-			audience, err := claims.GetAudience()
-			if err != nil {
-				return nil, err
-			}
-			if _, ok := userMap[audience[0]]; !ok {
-				return nil, fmt.Errorf("not in audience")
-			}
-
-			if _, ok := userMap[claims.Subject]; !ok {
-				return nil, fmt.Errorf("invalid subject")
-			}
 
 			authContext := c.Get("auth").(*AuthContext)
 			authContext.claims = claims
@@ -96,6 +71,128 @@ func GetJWTAuthMiddleware(
 	return jwtAuth, authMiddleware
 }
 
+// parseToken parses the JWT or API key used to access the API.
+func (j *JWTAuth) parseToken(auth string, userManager *users.UserManager) (*jwt.Token, *WaspClaims, error) {
+	if users.IsAPIKey(auth) {
+		return j.parseAPIKey(auth, userManager)
+	}
+
+	token, claims, err := j.parseJWT(auth, userManager)
+	if err != nil {
+		return nil, nil, err
+	}
+	if claims.TokenType != "" {
+		return nil, nil, fmt.Errorf("a %s token can't be used to access the API", claims.TokenType)
+	}
+
+	return token, claims, nil
+}
+
+// parseJWT parses and verifies a JWT (of any type) issued by this node,
+// which must belong to an existing user and must not be revoked.
+func (j *JWTAuth) parseJWT(auth string, userManager *users.UserManager) (*jwt.Token, *WaspClaims, error) {
+	keyFunc := func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+		}
+
+		return j.secret, nil
+	}
+
+	token, err := jwt.ParseWithClaims(
+		auth,
+		&WaspClaims{},
+		keyFunc,
+		jwt.WithValidMethods([]string{"HS256"}),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !token.Valid {
+		return nil, nil, fmt.Errorf("invalid token")
+	}
+
+	claims, ok := token.Claims.(*WaspClaims)
+	if !ok {
+		return nil, nil, fmt.Errorf("wrong JWT claim type")
+	}
+
+	userMap := userManager.Users()
+	audience, err := claims.GetAudience()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(audience) == 0 {
+		return nil, nil, fmt.Errorf("missing audience")
+	}
+	if _, ok := userMap[audience[0]]; !ok {
+		return nil, nil, fmt.Errorf("not in audience")
+	}
+
+	if _, ok := userMap[claims.Subject]; !ok {
+		return nil, nil, fmt.Errorf("invalid subject")
+	}
+
+	if userManager.IsTokenRevoked(claims.Subject, claims.ID, issuedAt(claims)) {
+		return nil, nil, fmt.Errorf("token was revoked")
+	}
+
+	return token, claims, nil
+}
+
+// parseAPIKey verifies an API key and returns a token with the claims of the
+// key. Only those permissions of the key that are still granted to its user apply.
+func (j *JWTAuth) parseAPIKey(auth string, userManager *users.UserManager) (*jwt.Token, *WaspClaims, error) {
+	user, apiKey, err := userManager.AuthenticateAPIKey(auth)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	keyPermissions := make(map[string]struct{}, len(apiKey.Permissions))
+	for permission := range apiKey.Permissions {
+		if permissions.IsGranted(user.Permissions, permission, "") {
+			keyPermissions[permission] = struct{}{}
+		}
+	}
+
+	claims := &WaspClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:  user.Name,
+			Issuer:   j.nodeID,
+			Audience: jwt.ClaimStrings{user.Name},
+			ID:       apiKey.ID,
+			IssuedAt: jwt.NewNumericDate(apiKey.CreatedAt),
+		},
+		Permissions: keyPermissions,
+	}
+	if !apiKey.ExpiresAt.IsZero() {
+		claims.ExpiresAt = jwt.NewNumericDate(apiKey.ExpiresAt)
+	}
+
+	return &jwt.Token{Claims: claims, Valid: true}, claims, nil
+}
+
+// usernameFromRequest returns the name of the user of the token (or API key) supplied in the
+// request (the same way as the auth middleware looks it up), or "" if there is
+// no valid token.
+func (j *JWTAuth) usernameFromRequest(c echo.Context, userManager *users.UserManager) string {
+	auth := ""
+	if header := c.Request().Header.Get(echo.HeaderAuthorization); strings.HasPrefix(header, bearerPrefix) {
+		auth = header[len(bearerPrefix):]
+	} else if cookie, err := c.Cookie(JWTContextKey); err == nil {
+		auth = cookie.Value
+	}
+	if auth == "" {
+		return ""
+	}
+
+	_, claims, err := j.parseToken(auth, userManager)
+	if err != nil {
+		return ""
+	}
+	return claims.Subject
+}
+
 func GetNoneAuthMiddleware() echo.MiddlewareFunc {
 	// Adds a middleware to set the authContext to authenticated.
 	// All routes will be open to everyone, so use it in private environments only.
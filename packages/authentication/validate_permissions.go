@@ -4,6 +4,8 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/wasp/packages/webapi/params"
 )
 
 type ValidationError struct {
@@ -28,8 +30,10 @@ func ValidatePermissions(permissions []string) func(next echo.HandlerFunc) echo.
 				return next(e)
 			}
 
+			// the permissions restricted to a chain apply to the routes of that chain
+			chainID := e.Param(params.ParamChainID)
 			for _, permission := range permissions {
-				if !authContext.claims.HasPermission(permission) {
+				if authContext.claims == nil || !authContext.claims.HasChainPermission(permission, chainID) {
 					return e.JSON(http.StatusUnauthorized, ValidationError{MissingPermission: permission, Error: "Missing permission"})
 				}
 			}
@@ -0,0 +1,96 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/chaindb"
+	"github.com/iotaledger/wasp/packages/isc"
+)
+
+// OffLedgerJournal persists the requests in the off-ledger pool, so that
+// they are not lost when the node is restarted.
+type OffLedgerJournal interface {
+	Add(request isc.OffLedgerRequest)
+	Remove(request isc.OffLedgerRequest)
+	// Load returns all the requests in the journal. The requests that were
+	// processed while the node was down are still included; it is up to the
+	// mempool to drop them.
+	Load() []isc.OffLedgerRequest
+}
+
+type offLedgerJournal struct {
+	db  kvstore.KVStore
+	log *logger.Logger
+}
+
+var _ OffLedgerJournal = &offLedgerJournal{}
+
+// NewOffLedgerJournal creates a journal stored in the chain database,
+// under the chaindb.PrefixMempoolOffLedgerJournal partition.
+func NewOffLedgerJournal(db kvstore.KVStore, log *logger.Logger) OffLedgerJournal {
+	return &offLedgerJournal{db: db, log: log}
+}
+
+func keyOffLedgerJournalNoRequestID() []byte {
+	return []byte{chaindb.PrefixMempoolOffLedgerJournal}
+}
+
+func keyOffLedgerJournal(requestID isc.RequestID) []byte {
+	return append(keyOffLedgerJournalNoRequestID(), requestID.Bytes()...)
+}
+
+func (j *offLedgerJournal) Add(request isc.OffLedgerRequest) {
+	if err := j.db.Set(keyOffLedgerJournal(request.ID()), request.Bytes()); err != nil {
+		j.log.Warnf("cannot journal off-ledger request %v: %v", request.ID(), err)
+	}
+}
+
+func (j *offLedgerJournal) Remove(request isc.OffLedgerRequest) {
+	if err := j.db.Delete(keyOffLedgerJournal(request.ID())); err != nil {
+		j.log.Warnf("cannot remove off-ledger request %v from the journal: %v", request.ID(), err)
+	}
+}
+
+func (j *offLedgerJournal) Load() []isc.OffLedgerRequest {
+	requests := []isc.OffLedgerRequest{}
+	invalidKeys := []kvstore.Key{}
+	err := j.db.Iterate(keyOffLedgerJournalNoRequestID(), func(key kvstore.Key, value kvstore.Value) bool {
+		request, err := isc.RequestFromBytes(value)
+		if err != nil {
+			j.log.Warnf("dropping undecodable request from the off-ledger journal: %v", err)
+			invalidKeys = append(invalidKeys, key)
+			return true
+		}
+		offLedgerRequest, ok := request.(isc.OffLedgerRequest)
+		if !ok {
+			j.log.Warnf("dropping unexpected request %v of type %T from the off-ledger journal", request.ID(), request)
+			invalidKeys = append(invalidKeys, key)
+			return true
+		}
+		requests = append(requests, offLedgerRequest)
+		return true
+	})
+	if err != nil {
+		j.log.Warnf("cannot read the off-ledger journal: %v", err)
+	}
+	for _, key := range invalidKeys {
+		if err := j.db.Delete(key); err != nil {
+			j.log.Warnf("cannot delete key %x from the off-ledger journal: %v", key, err)
+		}
+	}
+	return requests
+}
+
+// May be used in tests or in production, when the off-ledger requests should
+// not be persisted.
+type emptyOffLedgerJournal struct{}
+
+var _ OffLedgerJournal = &emptyOffLedgerJournal{}
+
+func NewEmptyOffLedgerJournal() OffLedgerJournal            { return &emptyOffLedgerJournal{} }
+func (*emptyOffLedgerJournal) Add(isc.OffLedgerRequest)     {}
+func (*emptyOffLedgerJournal) Remove(isc.OffLedgerRequest)  {}
+func (*emptyOffLedgerJournal) Load() []isc.OffLedgerRequest { return nil }
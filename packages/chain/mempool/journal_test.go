@@ -0,0 +1,85 @@
+package mempool
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/chaindb"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/testutil"
+	"github.com/iotaledger/wasp/packages/testutil/testkey"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+func TestOffLedgerJournal(t *testing.T) {
+	log := testlogger.NewSilentLogger("", true)
+	db := mapdb.NewMapDB()
+	waitReq := NewWaitReq(waitRequestCleanupEvery)
+	pool := NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, NewOffLedgerJournal(db, log), func(int) {}, func(time.Duration) {}, log)
+
+	kp, _ := testkey.GenKeyAddr()
+	chainID := isc.RandomChainID()
+	req0 := testutil.DummyOffledgerRequestForAccount(chainID, 0, kp)
+	req1 := testutil.DummyOffledgerRequestForAccount(chainID, 1, kp)
+	req2 := testutil.DummyOffledgerRequestForAccount(chainID, 2, kp)
+	pool.Add(req0)
+	pool.Add(req1)
+	pool.Add(req2)
+	pool.Remove(req0)
+	pool.Filter(func(request isc.OffLedgerRequest, _ time.Time) bool {
+		return request.Nonce() != 2
+	})
+
+	// garbage in the journal partition is dropped on load
+	garbageKey := append([]byte{chaindb.PrefixMempoolOffLedgerJournal}, 1, 2, 3)
+	require.NoError(t, db.Set(garbageKey, []byte{0xff}))
+
+	// simulate a restart
+	journal := NewOffLedgerJournal(db, log)
+	loaded := journal.Load()
+	require.Len(t, loaded, 1)
+	require.Equal(t, req1.ID(), loaded[0].ID())
+	require.Equal(t, req1.Bytes(), loaded[0].Bytes())
+	has, err := db.Has(garbageKey)
+	require.NoError(t, err)
+	require.False(t, has)
+
+	pool = NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, journal, func(int) {}, func(time.Duration) {}, log)
+	for _, req := range loaded {
+		pool.Add(req)
+	}
+	require.True(t, pool.Has(isc.RequestRefFromRequest(req1)))
+	pool.Remove(req1)
+	require.Empty(t, NewOffLedgerJournal(db, log).Load())
+}
+
+func TestOffLedgerJournalReplacedNonce(t *testing.T) {
+	log := testlogger.NewSilentLogger("", true)
+	db := mapdb.NewMapDB()
+	waitReq := NewWaitReq(waitRequestCleanupEvery)
+	pool := NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, NewOffLedgerJournal(db, log), func(int) {}, func(time.Duration) {}, log)
+
+	kp, _ := testkey.GenKeyAddr()
+	chainID := isc.RandomChainID()
+	req := testutil.DummyOffledgerRequestForAccount(chainID, 1, kp)
+	replacement := isc.NewOffLedgerRequest(chainID, isc.Hn("somecontract"), isc.Hn("someentrypoint"), dict.Dict{}, 1, gas.LimitsDefault.MaxGasPerRequest-1).Sign(kp)
+	require.NotEqual(t, req.ID(), replacement.ID())
+	pool.Add(req)
+	pool.Add(replacement)
+
+	// simulate a restart: only the replacement is loaded
+	loaded := NewOffLedgerJournal(db, log).Load()
+	require.Len(t, loaded, 1)
+	require.Equal(t, replacement.ID(), loaded[0].ID())
+
+	// the replaced request is removed from the pool later, e.g. when it is processed
+	pool.Remove(req)
+	loaded = NewOffLedgerJournal(db, log).Load()
+	require.Len(t, loaded, 1)
+	require.Equal(t, replacement.ID(), loaded[0].ID())
+}
@@ -36,10 +36,15 @@
 // to the proposal based on a tangle time. The tangle time is received from the
 // L1 with the milestones.
 //
-// NOTE: A node looses its off-ledger requests on restart. The on-ledger requests
-// will be added back to the mempool by reading them from the L1 node.
+// The off-ledger requests are journaled to the chain database (OffLedgerJournal),
+// and reloaded on restart. The ones processed while the node was down are dropped
+// when the first chain head is received, based on the blocklog. The on-ledger
+// requests will be added back to the mempool by reading them from the L1 node.
 //
-// TODO: Propose subset of the requests. That's for the next release.
+// The proposal is bounded by the block gas limit. The requests are selected by
+// the gas price they offer, keeping the nonce order of the off-ledger requests
+// and limiting the share of the block a single sender can take while others
+// are waiting. The gas of a request is estimated by its gas budget.
 package mempool
 
 import (
@@ -105,6 +110,10 @@ type Mempool interface {
 	// These nodes should be used to disseminate the off-ledger requests.
 	ServerNodesUpdated(committeePubKeys []*cryptolib.PublicKey, serverNodePubKeys []*cryptolib.PublicKey)
 	AccessNodesUpdated(committeePubKeys []*cryptolib.PublicKey, accessNodePubKeys []*cryptolib.PublicKey)
+	// Returns the off-ledger requests currently waiting in the pool, including
+	// the ones that cannot be proposed yet because of a nonce gap. Requests
+	// replaced by a newer request with the same nonce are not included.
+	OffLedgerRequestsAsync(ctx context.Context) <-chan []isc.OffLedgerRequest
 }
 
 type RequestPool[V isc.Request] interface {
@@ -145,6 +154,7 @@ type mempoolImpl struct {
 	reqReceiveOffLedgerRequestPipe pipe.Pipe[isc.OffLedgerRequest]
 	reqTangleTimeUpdatedPipe       pipe.Pipe[time.Time]
 	reqTrackNewChainHeadPipe       pipe.Pipe[*reqTrackNewChainHead]
+	reqOffLedgerRequestsPipe       pipe.Pipe[*reqOffLedgerRequests]
 	netRecvPipe                    pipe.Pipe[*peering.PeerMessageIn]
 	netPeeringID                   peering.PeeringID
 	netPeerPubs                    map[gpa.NodeID]*cryptolib.PublicKey
@@ -187,6 +197,11 @@ type reqConsensusRequests struct {
 	responseCh  chan<- []isc.Request
 }
 
+type reqOffLedgerRequests struct {
+	ctx        context.Context
+	responseCh chan<- []isc.OffLedgerRequest
+}
+
 type reqTrackNewChainHead struct {
 	st         state.State
 	from       *isc.AliasOutputWithID
@@ -205,6 +220,7 @@ func New(
 	metrics *metrics.ChainMempoolMetrics,
 	pipeMetrics *metrics.ChainPipeMetrics,
 	listener ChainListener,
+	offLedgerJournal OffLedgerJournal,
 ) Mempool {
 	netPeeringID := peering.HashPeeringIDFromBytes(chainID.Bytes(), []byte("Mempool")) // ChainID × Mempool
 	waitReq := NewWaitReq(waitRequestCleanupEvery)
@@ -213,7 +229,7 @@ func New(
 		tangleTime:                     time.Time{},
 		timePool:                       NewTimePool(metrics.SetTimePoolSize, log.Named("TIM")),
 		onLedgerPool:                   NewTypedPool[isc.OnLedgerRequest](waitReq, metrics.SetOnLedgerPoolSize, metrics.SetOnLedgerReqTime, log.Named("ONL")),
-		offLedgerPool:                  NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, metrics.SetOffLedgerPoolSize, metrics.SetOffLedgerReqTime, log.Named("OFF")),
+		offLedgerPool:                  NewTypedPoolByNonce[isc.OffLedgerRequest](waitReq, offLedgerJournal, metrics.SetOffLedgerPoolSize, metrics.SetOffLedgerReqTime, log.Named("OFF")),
 		chainHeadAO:                    nil,
 		serverNodesUpdatedPipe:         pipe.NewInfinitePipe[*reqServerNodesUpdated](),
 		serverNodes:                    []*cryptolib.PublicKey{},
@@ -228,6 +244,7 @@ func New(
 		reqReceiveOffLedgerRequestPipe: pipe.NewInfinitePipe[isc.OffLedgerRequest](),
 		reqTangleTimeUpdatedPipe:       pipe.NewInfinitePipe[time.Time](),
 		reqTrackNewChainHeadPipe:       pipe.NewInfinitePipe[*reqTrackNewChainHead](),
+		reqOffLedgerRequestsPipe:       pipe.NewInfinitePipe[*reqOffLedgerRequests](),
 		netRecvPipe:                    pipe.NewInfinitePipe[*peering.PeerMessageIn](),
 		netPeeringID:                   netPeeringID,
 		netPeerPubs:                    map[gpa.NodeID]*cryptolib.PublicKey{},
@@ -237,6 +254,14 @@ func New(
 		listener:                       listener,
 	}
 
+	journaled := offLedgerJournal.Load()
+	for _, request := range journaled {
+		mpi.offLedgerPool.Add(request)
+	}
+	if len(journaled) > 0 {
+		log.Infof("Loaded %v off-ledger requests from the journal", len(journaled))
+	}
+
 	pipeMetrics.TrackPipeLen("mp-serverNodesUpdatedPipe", mpi.serverNodesUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-accessNodesUpdatedPipe", mpi.accessNodesUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqConsensusProposalPipe", mpi.reqConsensusProposalPipe.Len)
@@ -245,6 +270,7 @@ func New(
 	pipeMetrics.TrackPipeLen("mp-reqReceiveOffLedgerRequestPipe", mpi.reqReceiveOffLedgerRequestPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqTangleTimeUpdatedPipe", mpi.reqTangleTimeUpdatedPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-reqTrackNewChainHeadPipe", mpi.reqTrackNewChainHeadPipe.Len)
+	pipeMetrics.TrackPipeLen("mp-reqOffLedgerRequestsPipe", mpi.reqOffLedgerRequestsPipe.Len)
 	pipeMetrics.TrackPipeLen("mp-netRecvPipe", mpi.netRecvPipe.Len)
 
 	mpi.distSync = distsync.New(
@@ -325,6 +351,16 @@ func (mpi *mempoolImpl) ConsensusRequestsAsync(ctx context.Context, requestRefs
 	return res
 }
 
+func (mpi *mempoolImpl) OffLedgerRequestsAsync(ctx context.Context) <-chan []isc.OffLedgerRequest {
+	res := make(chan []isc.OffLedgerRequest, 1)
+	req := &reqOffLedgerRequests{
+		ctx:        ctx,
+		responseCh: res,
+	}
+	mpi.reqOffLedgerRequestsPipe.In() <- req
+	return res
+}
+
 func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc) { //nolint:gocyclo
 	serverNodesUpdatedPipeOutCh := mpi.serverNodesUpdatedPipe.Out()
 	accessNodesUpdatedPipeOutCh := mpi.accessNodesUpdatedPipe.Out()
@@ -334,6 +370,7 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 	reqReceiveOffLedgerRequestPipeOutCh := mpi.reqReceiveOffLedgerRequestPipe.Out()
 	reqTangleTimeUpdatedPipeOutCh := mpi.reqTangleTimeUpdatedPipe.Out()
 	reqTrackNewChainHeadPipeOutCh := mpi.reqTrackNewChainHeadPipe.Out()
+	reqOffLedgerRequestsPipeOutCh := mpi.reqOffLedgerRequestsPipe.Out()
 	netRecvPipeOutCh := mpi.netRecvPipe.Out()
 	debugTicker := time.NewTicker(distShareDebugTick)
 	timeTicker := time.NewTicker(distShareTimeTick)
@@ -388,6 +425,12 @@ func (mpi *mempoolImpl) run(ctx context.Context, cleanupFunc context.CancelFunc)
 				break
 			}
 			mpi.handleTrackNewChainHead(recv)
+		case recv, ok := <-reqOffLedgerRequestsPipeOutCh:
+			if !ok {
+				reqOffLedgerRequestsPipeOutCh = nil
+				break
+			}
+			mpi.handleOffLedgerRequests(recv)
 		case recv, ok := <-netRecvPipeOutCh:
 			if !ok {
 				netRecvPipeOutCh = nil
@@ -530,16 +573,20 @@ func (mpi *mempoolImpl) handleConsensusProposal(recv *reqConsensusProposal) {
 }
 
 func (mpi *mempoolImpl) refsToPropose() []*isc.RequestRef {
+	pricing := newProposalPricing(governance.NewStateAccess(mpi.chainHeadState))
+	queues := []*proposalQueue{}
 	//
 	// The case for matching ChainHeadAO and request BaseAO
-	reqRefs := []*isc.RequestRef{}
 	if !mpi.tangleTime.IsZero() { // Wait for tangle-time to process the on ledger requests.
-		mpi.onLedgerPool.Filter(func(request isc.OnLedgerRequest, _ time.Time) bool {
+		mpi.onLedgerPool.Filter(func(request isc.OnLedgerRequest, ts time.Time) bool {
 			if isc.RequestIsExpired(request, mpi.tangleTime) {
 				return false // Drop it from the mempool
 			}
 			if isc.RequestIsUnlockable(request, mpi.chainID.AsAddress(), mpi.tangleTime) {
-				reqRefs = append(reqRefs, isc.RequestRefFromRequest(request))
+				queues = append(queues, &proposalQueue{
+					sender:     request.SenderAccount().String(),
+					candidates: []*proposalCandidate{pricing.candidate(request, ts)},
+				})
 			}
 			return true // Keep them for now
 		})
@@ -550,6 +597,12 @@ func (mpi *mempoolImpl) refsToPropose() []*isc.RequestRef {
 		if err != nil {
 			panic(fmt.Errorf("invalid agentID string: %s", err.Error()))
 		}
+		queue := &proposalQueue{sender: account, candidates: []*proposalCandidate{}}
+		defer func() {
+			if len(queue.candidates) > 0 {
+				queues = append(queues, queue)
+			}
+		}()
 		accountNonce := mpi.nonce(agentID)
 		for _, e := range entries {
 			reqNonce := e.req.Nonce()
@@ -565,9 +618,8 @@ func (mpi *mempoolImpl) refsToPropose() []*isc.RequestRef {
 				continue
 			}
 			if reqNonce == accountNonce {
-				// expected nonce, add it to the list to propose
-				mpi.log.Debugf("refsToPropose, account: %s, proposing reqID %s with nonce: %d", account, e.req.ID().String(), e.req.Nonce())
-				reqRefs = append(reqRefs, isc.RequestRefFromRequest(e.req))
+				// expected nonce, add it to the list of candidates
+				queue.candidates = append(queue.candidates, pricing.candidate(e.req, e.ts))
 				accountNonce++ // increment the account nonce to match the next valid request
 			}
 			if reqNonce > accountNonce {
@@ -577,7 +629,17 @@ func (mpi *mempoolImpl) refsToPropose() []*isc.RequestRef {
 		}
 	})
 
-	return reqRefs
+	selection := selectProposal(queues, pricing.limits.MaxGasPerBlock)
+	mpi.metrics.SetProposalGas(selection.gas)
+	for _, reason := range proposalDeferredReasons {
+		count := selection.deferred[reason]
+		if count > 0 {
+			mpi.log.Debugf("refsToPropose, deferred %d requests, reason: %s", count, reason)
+		}
+		// the same requests are deferred again by the next proposals, so only the last one is reported
+		mpi.metrics.SetProposalDeferred(reason, count)
+	}
+	return selection.refs
 }
 
 func (mpi *mempoolImpl) handleConsensusProposalForChainHead(recv *reqConsensusProposal) {
@@ -798,6 +860,23 @@ func (mpi *mempoolImpl) handleTrackNewChainHead(req *reqTrackNewChainHead) {
 	}
 }
 
+func (mpi *mempoolImpl) handleOffLedgerRequests(recv *reqOffLedgerRequests) {
+	if recv.ctx.Err() != nil {
+		close(recv.responseCh)
+		return
+	}
+	reqs := []isc.OffLedgerRequest{}
+	mpi.offLedgerPool.Iterate(func(account string, entries []*OrderedPoolEntry[isc.OffLedgerRequest]) {
+		for _, e := range entries {
+			if !e.old {
+				reqs = append(reqs, e.req)
+			}
+		}
+	})
+	recv.responseCh <- reqs
+	close(recv.responseCh)
+}
+
 func (mpi *mempoolImpl) handleNetMessage(recv *peering.PeerMessageIn) {
 	msg, err := mpi.distSync.UnmarshalMessage(recv.MsgData)
 	if err != nil {
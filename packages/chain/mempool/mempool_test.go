@@ -656,6 +656,9 @@ func TestMempoolOverrideNonce(t *testing.T) {
 
 	require.NoError(t, te.mempools[0].ReceiveOffLedgerRequest(overwritingReq))
 	time.Sleep(200 * time.Millisecond) // give some time for the requests to reach the pool
+	pendingReqs := <-te.mempools[0].OffLedgerRequestsAsync(te.ctx)
+	require.Len(t, pendingReqs, 1)
+	require.Equal(t, overwritingReq, pendingReqs[0])
 	reqRefs := <-te.mempools[0].ConsensusProposalAsync(te.ctx, currentAO)
 	proposedReqs := <-te.mempools[0].ConsensusRequestsAsync(te.ctx, reqRefs)
 	require.Len(t, proposedReqs, 1)
@@ -738,6 +741,7 @@ func newEnv(t *testing.T, n, f int, reliable bool) *testEnv {
 			chainMetrics.Mempool,
 			chainMetrics.Pipe,
 			chain.NewEmptyChainListener(),
+			mempool.NewEmptyOffLedgerJournal(),
 		)
 	}
 	return te
@@ -0,0 +1,211 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package mempool
+
+import (
+	"bytes"
+	"container/heap"
+	"math/big"
+	"time"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+// The block gas is shared among the senders in proposalSenderShare steps: a
+// sender can take up to 1/proposalSenderShare of the block gas at first, then
+// 2/proposalSenderShare and so on. So a sender gets more than the others only
+// if they have no more requests that fit into the block.
+const proposalSenderShare = 4
+
+// Reasons for a ready request not to be included in a proposal.
+const (
+	proposalDeferredBlockGas   = "block_gas"   // the request does not fit into the block gas limit.
+	proposalDeferredNonceOrder = "nonce_order" // a request with a lower nonce from the same sender was deferred.
+)
+
+var proposalDeferredReasons = []string{proposalDeferredBlockGas, proposalDeferredNonceOrder}
+
+// proposalCandidate is a request ready to be proposed.
+type proposalCandidate struct {
+	ref   *isc.RequestRef
+	gas   uint64   // estimated ISC gas, the gas budget bounded by the gas limits.
+	price *big.Int // the price the sender is willing to pay for a unit of EVM gas.
+	ts    time.Time
+}
+
+// proposalQueue is a sequence of candidates from a single sender, that
+// have to be proposed in order (e.g. off-ledger requests by nonce).
+type proposalQueue struct {
+	sender     string
+	candidates []*proposalCandidate
+	next       int
+}
+
+func (q *proposalQueue) head() *proposalCandidate {
+	return q.candidates[q.next]
+}
+
+type proposalSelection struct {
+	refs     []*isc.RequestRef
+	gas      uint64
+	deferred map[string]int // reason -> number of requests
+}
+
+// selectProposal picks the requests to propose among the queues, so that the
+// total gas does not exceed maxGas. The queue heads are taken in the order of
+// the price (then by age) up to the share of their sender (see
+// proposalSenderShare), and the order within each queue is preserved. If the
+// head of a queue does not fit, the rest of the queue is deferred as well.
+// The first request is always taken, to make progress.
+func selectProposal(queues []*proposalQueue, maxGas uint64) *proposalSelection {
+	sel := &proposalSelection{
+		refs:     []*isc.RequestRef{},
+		deferred: map[string]int{},
+	}
+	senderGas := map[string]uint64{}
+
+	take := func(q *proposalQueue) {
+		c := q.head()
+		sel.refs = append(sel.refs, c.ref)
+		sel.gas += c.gas
+		senderGas[q.sender] += c.gas
+		q.next++
+	}
+	fits := func(c *proposalCandidate) bool {
+		return len(sel.refs) == 0 || sel.gas+c.gas <= maxGas
+	}
+	deferQueue := func(q *proposalQueue) {
+		sel.deferred[proposalDeferredBlockGas]++
+		if rest := len(q.candidates) - q.next - 1; rest > 0 {
+			sel.deferred[proposalDeferredNonceOrder] += rest
+		}
+	}
+
+	pending := []*proposalQueue{}
+	for _, q := range queues {
+		if len(q.candidates) > 0 {
+			pending = append(pending, q)
+		}
+	}
+	//
+	// Raise the share of the senders by one step in each round. The queues of
+	// the senders over their share wait for the next round. In the last round
+	// the share is the whole block, so all queues are done.
+	for share := uint64(1); share <= proposalSenderShare && len(pending) > 0; share++ {
+		senderMaxGas := maxGas / proposalSenderShare * share
+		if share == proposalSenderShare {
+			senderMaxGas = maxGas
+		}
+		round := proposalHeap(pending)
+		heap.Init(&round)
+		pending = []*proposalQueue{}
+		for round.Len() > 0 {
+			q := heap.Pop(&round).(*proposalQueue)
+			c := q.head()
+			if !fits(c) {
+				deferQueue(q)
+				continue
+			}
+			if senderGas[q.sender] > 0 && senderGas[q.sender]+c.gas > senderMaxGas {
+				pending = append(pending, q)
+				continue
+			}
+			take(q)
+			if q.next < len(q.candidates) {
+				heap.Push(&round, q)
+			}
+		}
+	}
+	return sel
+}
+
+// proposalHeap orders the queues by their heads, the best one first.
+type proposalHeap []*proposalQueue
+
+var _ heap.Interface = &proposalHeap{}
+
+func (h proposalHeap) Len() int { return len(h) }
+
+func (h proposalHeap) Less(i, j int) bool {
+	a, b := h[i].head(), h[j].head()
+	if cmp := a.price.Cmp(b.price); cmp != 0 {
+		return cmp > 0
+	}
+	if !a.ts.Equal(b.ts) {
+		return a.ts.Before(b.ts)
+	}
+	return bytes.Compare(a.ref.ID.Bytes(), b.ref.ID.Bytes()) < 0
+}
+
+func (h proposalHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *proposalHeap) Push(x any) { *h = append(*h, x.(*proposalQueue)) }
+
+func (h *proposalHeap) Pop() any {
+	old := *h
+	n := len(old)
+	q := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return q
+}
+
+// proposalPricing estimates the gas and the price of the requests based on
+// the chain parameters in the current chain head.
+type proposalPricing struct {
+	limits    *gas.Limits
+	feePolicy *gas.FeePolicy
+	basePrice *big.Int
+}
+
+func newProposalPricing(governanceState *governance.StateAccess) *proposalPricing {
+	feePolicy := governanceState.GasFeePolicy()
+	return &proposalPricing{
+		limits:    governanceState.GasLimits(),
+		feePolicy: feePolicy,
+		basePrice: feePolicy.EVMGasPrice(parameters.L1().BaseToken.Decimals),
+	}
+}
+
+func (pp *proposalPricing) candidate(req isc.Request, ts time.Time) *proposalCandidate {
+	return &proposalCandidate{
+		ref:   isc.RequestRefFromRequest(req),
+		gas:   pp.gas(req),
+		price: pp.price(req),
+		ts:    ts,
+	}
+}
+
+func (pp *proposalPricing) gas(req isc.Request) uint64 {
+	gasBudget, isEVM := req.GasBudget()
+	if isEVM {
+		gasBudget = gas.EVMGasToISC(gasBudget, &pp.feePolicy.EVMGasRatio)
+	}
+	if gasBudget < pp.limits.MinGasPerRequest {
+		return pp.limits.MinGasPerRequest
+	}
+	if gasBudget > pp.limits.MaxGasPerRequest {
+		return pp.limits.MaxGasPerRequest
+	}
+	return gasBudget
+}
+
+// price returns the gas price set in the EVM requests, or the price defined by
+// the fee policy for the rest of the requests.
+func (pp *proposalPricing) price(req isc.Request) *big.Int {
+	callMsg := req.EVMCallMsg()
+	if callMsg == nil {
+		return pp.basePrice
+	}
+	if callMsg.GasPrice != nil {
+		return callMsg.GasPrice
+	}
+	if callMsg.GasFeeCap != nil {
+		return callMsg.GasFeeCap
+	}
+	return pp.basePrice
+}
@@ -0,0 +1,95 @@
+package mempool
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/testutil/testiotago"
+)
+
+func TestSelectProposal(t *testing.T) {
+	now := time.Now()
+	newCandidate := func(gas uint64, price int64, age time.Duration) *proposalCandidate {
+		return &proposalCandidate{
+			ref:   &isc.RequestRef{ID: isc.RequestID(testiotago.RandOutputID())},
+			gas:   gas,
+			price: big.NewInt(price),
+			ts:    now.Add(-age),
+		}
+	}
+	queue := func(sender string, candidates ...*proposalCandidate) *proposalQueue {
+		return &proposalQueue{sender: sender, candidates: candidates}
+	}
+
+	t.Run("by price, then by age", func(t *testing.T) {
+		a := newCandidate(10, 1, time.Second)
+		b := newCandidate(10, 2, 0)
+		c := newCandidate(10, 1, time.Minute)
+		sel := selectProposal([]*proposalQueue{queue("a", a), queue("b", b), queue("c", c)}, 100)
+		require.Equal(t, []*isc.RequestRef{b.ref, c.ref, a.ref}, sel.refs)
+		require.EqualValues(t, 30, sel.gas)
+		require.Empty(t, sel.deferred)
+	})
+
+	t.Run("nonce order and block gas", func(t *testing.T) {
+		a0 := newCandidate(40, 1, 0)
+		a1 := newCandidate(40, 5, 0) // higher price, but has to wait for a0
+		a2 := newCandidate(40, 5, 0)
+		b0 := newCandidate(40, 2, 0)
+		sel := selectProposal([]*proposalQueue{queue("a", a0, a1, a2), queue("b", b0)}, 100)
+		require.Equal(t, []*isc.RequestRef{b0.ref, a0.ref}, sel.refs)
+		require.EqualValues(t, 80, sel.gas)
+		require.Equal(t, map[string]int{
+			proposalDeferredBlockGas:   1,
+			proposalDeferredNonceOrder: 1,
+		}, sel.deferred)
+	})
+
+	t.Run("sender share", func(t *testing.T) {
+		// "a" pays more, but "b" still gets its share of the block.
+		a := queue("a", newCandidate(20, 9, 0), newCandidate(20, 9, 0), newCandidate(20, 9, 0), newCandidate(20, 9, 0))
+		b := queue("b", newCandidate(20, 1, 0), newCandidate(20, 1, 0))
+		sel := selectProposal([]*proposalQueue{a, b}, 100)
+		require.Equal(t, []*isc.RequestRef{
+			a.candidates[0].ref,
+			b.candidates[0].ref,
+			a.candidates[1].ref,
+			b.candidates[1].ref,
+			a.candidates[2].ref,
+		}, sel.refs)
+		require.EqualValues(t, 100, sel.gas)
+		require.Equal(t, map[string]int{proposalDeferredBlockGas: 1}, sel.deferred)
+	})
+
+	t.Run("sender share is exceeded if the others can't use it", func(t *testing.T) {
+		a := queue("a", newCandidate(20, 9, 0), newCandidate(20, 9, 0), newCandidate(20, 9, 0), newCandidate(20, 9, 0), newCandidate(20, 9, 0))
+		b := queue("b", newCandidate(10, 1, 0), newCandidate(90, 1, 0))
+		sel := selectProposal([]*proposalQueue{a, b}, 100)
+		require.Equal(t, []*isc.RequestRef{
+			a.candidates[0].ref,
+			b.candidates[0].ref,
+			a.candidates[1].ref,
+			a.candidates[2].ref,
+			a.candidates[3].ref,
+		}, sel.refs)
+		require.EqualValues(t, 90, sel.gas)
+		require.Equal(t, map[string]int{proposalDeferredBlockGas: 2}, sel.deferred)
+	})
+
+	t.Run("single sender fills the block", func(t *testing.T) {
+		a := queue("a", newCandidate(30, 1, 0), newCandidate(30, 1, 0), newCandidate(30, 1, 0))
+		sel := selectProposal([]*proposalQueue{a}, 100)
+		require.Len(t, sel.refs, 3)
+		require.Empty(t, sel.deferred)
+	})
+
+	t.Run("first request is always taken", func(t *testing.T) {
+		a := newCandidate(1000, 1, 0)
+		sel := selectProposal([]*proposalQueue{queue("a", a)}, 100)
+		require.Equal(t, []*isc.RequestRef{a.ref}, sel.refs)
+	})
+}
@@ -17,6 +17,7 @@ import (
 // keeps a map of requests ordered by nonce for each account
 type TypedPoolByNonce[V isc.OffLedgerRequest] struct {
 	waitReq WaitReq
+	journal OffLedgerJournal
 	refLUT  *shrinkingmap.ShrinkingMap[isc.RequestRefKey, *OrderedPoolEntry[V]]
 	// reqsByAcountOrdered keeps an ordered map of reqsByAcountOrdered for each account by nonce
 	reqsByAcountOrdered *shrinkingmap.ShrinkingMap[string, []*OrderedPoolEntry[V]] // string is isc.AgentID.String()
@@ -27,9 +28,10 @@ type TypedPoolByNonce[V isc.OffLedgerRequest] struct {
 
 var _ RequestPool[isc.OffLedgerRequest] = &TypedPoolByNonce[isc.OffLedgerRequest]{}
 
-func NewTypedPoolByNonce[V isc.OffLedgerRequest](waitReq WaitReq, sizeMetric func(int), timeMetric func(time.Duration), log *logger.Logger) *TypedPoolByNonce[V] {
+func NewTypedPoolByNonce[V isc.OffLedgerRequest](waitReq WaitReq, journal OffLedgerJournal, sizeMetric func(int), timeMetric func(time.Duration), log *logger.Logger) *TypedPoolByNonce[V] {
 	return &TypedPoolByNonce[V]{
 		waitReq:             waitReq,
+		journal:             journal,
 		reqsByAcountOrdered: shrinkingmap.New[string, []*OrderedPoolEntry[V]](),
 		refLUT:              shrinkingmap.New[isc.RequestRefKey, *OrderedPoolEntry[V]](),
 		sizeMetric:          sizeMetric,
@@ -65,6 +67,7 @@ func (p *TypedPoolByNonce[V]) Add(request V) {
 		p.log.Debugf("NOT ADDED, already exists. reqID: %v as key=%v, senderAccount: ", request.ID(), ref, account)
 		return // not added already exists
 	}
+	p.journal.Add(request)
 
 	defer func() {
 		p.log.Debugf("ADD %v as key=%v, senderAccount: %s", request.ID(), ref, account)
@@ -99,6 +102,8 @@ func (p *TypedPoolByNonce[V]) Add(request V) {
 		// same nonce, mark the existing request with overlapping nonce as "old", place the new one
 		// NOTE: do not delete the request here, as it might already be part of an on-going consensus round
 		reqsForAcount[index].old = true
+		// it must not come back in place of the new one after a restart
+		p.journal.Remove(reqsForAcount[index].req)
 	}
 
 	reqsForAcount = append(reqsForAcount, entry) // add to the end of the list (thus extending the array)
@@ -124,6 +129,7 @@ func (p *TypedPoolByNonce[V]) Remove(request V) {
 	if p.refLUT.Delete(refKey) {
 		p.log.Debugf("DEL %v as key=%v", request.ID(), refKey)
 	}
+	p.journal.Remove(entry.req)
 	account := entry.req.SenderAccount().String()
 	reqsByAccount, exists := p.reqsByAcountOrdered.Get(account)
 	if !exists {
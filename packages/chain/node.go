@@ -67,6 +67,8 @@ const (
 type ChainRequests interface {
 	ReceiveOffLedgerRequest(request isc.OffLedgerRequest, sender *cryptolib.PublicKey) error
 	AwaitRequestProcessed(ctx context.Context, requestID isc.RequestID, confirmed bool) <-chan *blocklog.RequestReceipt
+	// Returns the off-ledger requests currently waiting in the mempool of this node.
+	OffLedgerRequestsInMempool(ctx context.Context) <-chan []isc.OffLedgerRequest
 }
 
 type Chain interface {
@@ -265,6 +267,7 @@ func New(
 	consensusStateRegistry cmt_log.ConsensusStateRegistry,
 	recoverFromWAL bool,
 	blockWAL sm_gpa_utils.BlockWAL,
+	offLedgerJournal mempool.OffLedgerJournal,
 	snapshotManager sm_snapshots.SnapshotManager,
 	listener ChainListener,
 	accessNodesFromNode []*cryptolib.PublicKey,
@@ -345,7 +348,7 @@ func New(
 	cni.chainMetrics.Pipe.TrackPipeLen("node-netRecvPipe", cni.netRecvPipe.Len)
 
 	if recoverFromWAL {
-		cni.recoverStoreFromWAL(chainStore, blockWAL)
+		cni.recoverStoreFromWAL(ctx, chainStore, blockWAL)
 	}
 	cni.me = cni.pubKeyAsNodeID(nodeIdentity.GetPublicKey())
 	//
@@ -426,6 +429,7 @@ func New(
 		chainMetrics.Mempool,
 		chainMetrics.Pipe,
 		cni.listener,
+		offLedgerJournal,
 	)
 	cni.chainMgr = gpa.NewAckHandler(cni.me, chainMgr.AsGPA(), redeliveryPeriod)
 	cni.stateMgr = stateMgr
@@ -503,6 +507,10 @@ func (cni *chainNodeImpl) AwaitRequestProcessed(ctx context.Context, requestID i
 	return responseCh
 }
 
+func (cni *chainNodeImpl) OffLedgerRequestsInMempool(ctx context.Context) <-chan []isc.OffLedgerRequest {
+	return cni.mempool.OffLedgerRequestsAsync(ctx)
+}
+
 func (cni *chainNodeImpl) ConfigUpdated(accessNodesPerNode []*cryptolib.PublicKey) {
 	cni.configUpdatedCh <- &configUpdate{accessNodes: accessNodesPerNode}
 }
@@ -1231,31 +1239,22 @@ func (cni *chainNodeImpl) GetConsensusWorkflowStatus() ConsensusWorkflowStatus {
 	return &consensusWorkflowStatusImpl{}
 }
 
-func (cni *chainNodeImpl) recoverStoreFromWAL(chainStore indexedstore.IndexedStore, chainWAL sm_gpa_utils.BlockWAL) {
+func (cni *chainNodeImpl) recoverStoreFromWAL(ctx context.Context, chainStore indexedstore.IndexedStore, chainWAL sm_gpa_utils.BlockWAL) {
 	//
 	// Load all the existing blocks from the WAL.
-	blocksAdded := 0
-	err := chainWAL.ReadAllByStateIndex(func(stateIndex uint32, block state.Block) bool {
-		cni.log.Debugf("TryRecoverStoreFromWAL: Adding a block to the store, stateIndex=%v, l1Commitment=%v, previousL1Commitment=%v", block.StateIndex(), block.L1Commitment(), block.PreviousL1Commitment())
-		var stateDraft state.StateDraft
-		if block.StateIndex() == 0 {
-			stateDraft = chainStore.NewOriginStateDraft()
-		} else {
-			var stateErr error
-			stateDraft, stateErr = chainStore.NewEmptyStateDraft(block.PreviousL1Commitment())
-			if stateErr != nil {
-				panic(fmt.Errorf("cannot create new state draft for previousL1Commitment=%v: %w", block.PreviousL1Commitment(), stateErr))
-			}
-		}
-		block.Mutations().ApplyTo(stateDraft)
-		chainStore.Commit(stateDraft)
-		blocksAdded++
-		return true
-	})
+	result, err := sm_gpa_utils.ReplayBlockWAL(ctx, cni.log, chainStore, chainWAL, sm_gpa_utils.NewBlockWALReplayOptions())
 	if err != nil {
 		panic(fmt.Errorf("failed to iterate over WAL blocks: %w", err))
 	}
-	cni.log.Infof("TryRecoverStoreFromWAL: Done, added %v blocks.", blocksAdded)
+	if result.FirstInconsistency != nil {
+		panic(fmt.Errorf("WAL block index %v is inconsistent: expected %v, got %v",
+			result.FirstInconsistency.StateIndex, result.FirstInconsistency.Expected, result.FirstInconsistency.Actual))
+	}
+	for _, gap := range result.Gaps {
+		cni.log.Warnf("TryRecoverStoreFromWAL: blocks %v..%v skipped, their previous states are not available", gap.FromIndex, gap.ToIndex)
+	}
+	cni.log.Infof("TryRecoverStoreFromWAL: Done, added %v blocks, %v blocks already present, %v blocks skipped.",
+		result.BlocksCommitted, result.BlocksVerified, result.BlocksSkipped)
 }
 
 type consensusPipeMetricsImpl struct{}                                        // TODO: Fake data, for now. Review metrics in general.
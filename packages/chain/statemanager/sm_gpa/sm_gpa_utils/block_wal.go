@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/samber/lo"
 
@@ -22,8 +23,15 @@ import (
 type blockWAL struct {
 	*logger.WrappedLogger
 
-	dir     string
-	metrics *metrics.ChainBlockWALMetrics
+	dir      string
+	metrics  *metrics.ChainBlockWALMetrics
+	readOnly bool
+
+	// Pruning; the fields below are only used if `blocksToKeep > 0`
+	blocksToKeep      uint32
+	blockPaths        map[uint32][]string // Paths of the blocks in WAL by state index
+	firstIndexToPrune uint32
+	blockPathsMutex   sync.Mutex
 }
 
 const (
@@ -32,20 +40,65 @@ const (
 )
 
 func NewBlockWAL(log *logger.Logger, baseDir string, chainID isc.ChainID, metrics *metrics.ChainBlockWALMetrics) (BlockWAL, error) {
+	return NewPrunedBlockWAL(log, baseDir, chainID, metrics, 0)
+}
+
+// NewPrunedBlockWAL creates WAL, which keeps only `blocksToKeep` latest blocks:
+// when block is written, the blocks with state index lower by `blocksToKeep`
+// or more are deleted. If `blocksToKeep` is 0, no blocks are deleted.
+func NewPrunedBlockWAL(log *logger.Logger, baseDir string, chainID isc.ChainID, metrics *metrics.ChainBlockWALMetrics, blocksToKeep uint32) (BlockWAL, error) {
 	dir := filepath.Join(baseDir, chainID.String())
 	if err := ioutils.CreateDirectory(dir, 0o777); err != nil {
 		return nil, fmt.Errorf("BlockWAL cannot create folder %v: %w", dir, err)
 	}
+	return newBlockWAL(log, dir, metrics, blocksToKeep, false)
+}
+
+// NewReadOnlyBlockWAL opens the existing WAL without modifying it, e.g. to
+// inspect the WAL of a node, which is not running. Writing to it fails.
+func NewReadOnlyBlockWAL(log *logger.Logger, baseDir string, chainID isc.ChainID, metrics *metrics.ChainBlockWALMetrics) (BlockWAL, error) {
+	dir := filepath.Join(baseDir, chainID.String())
+	if err := checkBlockWALDir(dir); err != nil {
+		return nil, err
+	}
+	return newBlockWAL(log, dir, metrics, 0, true)
+}
 
+func newBlockWAL(log *logger.Logger, dir string, metrics *metrics.ChainBlockWALMetrics, blocksToKeep uint32, readOnly bool) (BlockWAL, error) {
 	result := &blockWAL{
 		WrappedLogger: logger.NewWrappedLogger(log.Named("WAL")),
 		dir:           dir,
 		metrics:       metrics,
+		readOnly:      readOnly,
+		blocksToKeep:  blocksToKeep,
 	}
-	result.LogDebugf("BlockWAL created in folder %v", dir)
+	if blocksToKeep > 0 {
+		blockPaths, err := result.blockPathsByStateIndex()
+		if err != nil {
+			return nil, fmt.Errorf("BlockWAL cannot read folder %v: %w", dir, err)
+		}
+		result.blockPaths = blockPaths
+		if len(blockPaths) > 0 {
+			allStateIndexes := lo.Keys(blockPaths)
+			result.firstIndexToPrune = lo.Min(allStateIndexes)
+			result.prune(lo.Max(allStateIndexes))
+		}
+	}
+	result.LogDebugf("BlockWAL created in folder %v, blocks to keep: %v, read only: %v", dir, blocksToKeep, readOnly)
 	return result, nil
 }
 
+func checkBlockWALDir(dir string) error {
+	fileInfo, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("BlockWAL cannot open folder %v: %w", dir, err)
+	}
+	if !fileInfo.IsDir() {
+		return fmt.Errorf("BlockWAL cannot open folder %v: not a folder", dir)
+	}
+	return nil
+}
+
 // Overwrites, if block is already in WAL
 // Block format (version 1):
 //   - Version (4 bytes, unsigned int); value 1
@@ -55,6 +108,9 @@ func NewBlockWAL(log *logger.Logger, baseDir string, chainID isc.ChainID, metric
 // Block format (legacy = version 0):
 //   - Block bytes
 func (bwT *blockWAL) Write(block state.Block) error {
+	if bwT.readOnly {
+		return fmt.Errorf("cannot write block %s to read only WAL", block.L1Commitment())
+	}
 	blockIndex := block.StateIndex()
 	commitment := block.L1Commitment()
 	subfolderName := blockWALSubFolderName(commitment.BlockHash())
@@ -98,9 +154,40 @@ func (bwT *blockWAL) Write(block state.Block) error {
 
 	bwT.metrics.BlockWritten(block.StateIndex())
 	bwT.LogDebugf("Block index %v %s written to wal; file name - %s", blockIndex, commitment, finalFilePath)
+	if bwT.blocksToKeep > 0 {
+		bwT.blockPathsMutex.Lock()
+		defer bwT.blockPathsMutex.Unlock()
+		if !lo.Contains(bwT.blockPaths[blockIndex], finalFilePath) {
+			bwT.blockPaths[blockIndex] = append(bwT.blockPaths[blockIndex], finalFilePath)
+		}
+		bwT.prune(blockIndex)
+	}
 	return nil
 }
 
+// Deletes the blocks, which are `blocksToKeep` or more states older than
+// `lastIndex`. `blockPathsMutex` must be held by the caller (or WAL must not be
+// accessible to other goroutines yet).
+func (bwT *blockWAL) prune(lastIndex uint32) {
+	if lastIndex < bwT.blocksToKeep {
+		return
+	}
+	firstIndexToKeep := lastIndex - bwT.blocksToKeep + 1
+	for i := bwT.firstIndexToPrune; i < firstIndexToKeep; i++ {
+		for _, filePath := range bwT.blockPaths[i] {
+			if err := os.Remove(filePath); err != nil && !os.IsNotExist(err) {
+				bwT.LogWarnf("Unable to delete pruned block index %v file %s: %v", i, filePath, err)
+				continue
+			}
+			bwT.LogDebugf("Block index %v deleted from wal; file name - %s", i, filePath)
+		}
+		delete(bwT.blockPaths, i)
+	}
+	if firstIndexToKeep > bwT.firstIndexToPrune {
+		bwT.firstIndexToPrune = firstIndexToKeep
+	}
+}
+
 func (bwT *blockWAL) blockFilepath(blockHash state.BlockHash) (string, bool) {
 	subfolderName := blockWALSubFolderName(blockHash)
 	fileName := blockWALFileName(blockHash)
@@ -142,6 +229,32 @@ func (bwT *blockWAL) Read(blockHash state.BlockHash) (state.Block, error) {
 // The blocks are provided ordered by the state index, so that they can be applied to the store.
 // This function reads blocks twice, but tries to minimize the amount of memory required to load the WAL.
 func (bwT *blockWAL) ReadAllByStateIndex(cb func(stateIndex uint32, block state.Block) bool) error {
+	blocksByStateIndex, err := bwT.blockPathsByStateIndex()
+	if err != nil {
+		return err
+	}
+
+	allStateIndexes := lo.Keys(blocksByStateIndex)
+	sort.Slice(allStateIndexes, func(i, j int) bool { return allStateIndexes[i] < allStateIndexes[j] })
+	for _, stateIndex := range allStateIndexes {
+		stateIndexPaths := blocksByStateIndex[stateIndex]
+		for _, stateIndexPath := range stateIndexPaths {
+			fileBlock, fileErr := BlockFromFilePath(stateIndexPath)
+			if fileErr != nil {
+				bwT.metrics.IncFailedReads()
+				bwT.LogWarn("Unable to read %v: %v", stateIndexPath, err)
+				continue
+			}
+			if !cb(stateIndex, fileBlock) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// Reads the state indexes of all the blocks in the WAL dir (but not the blocks themselves).
+func (bwT *blockWAL) blockPathsByStateIndex() (map[uint32][]string, error) {
 	blocksByStateIndex := map[uint32][]string{}
 	checkFile := func(filePath string) {
 		if !strings.HasSuffix(filePath, constBlockWALFileSuffix) {
@@ -153,13 +266,7 @@ func (bwT *blockWAL) ReadAllByStateIndex(cb func(stateIndex uint32, block state.
 			bwT.LogWarn("Unable to read %v: %v", filePath, err)
 			return
 		}
-		stateIndexPaths, found := blocksByStateIndex[stateIndex]
-		if found {
-			stateIndexPaths = append(stateIndexPaths, filePath)
-		} else {
-			stateIndexPaths = []string{filePath}
-		}
-		blocksByStateIndex[stateIndex] = stateIndexPaths
+		blocksByStateIndex[stateIndex] = append(blocksByStateIndex[stateIndex], filePath)
 	}
 
 	var checkDir func(dirPath string, dirEntries []os.DirEntry)
@@ -179,27 +286,10 @@ func (bwT *blockWAL) ReadAllByStateIndex(cb func(stateIndex uint32, block state.
 
 	dirEntries, err := os.ReadDir(bwT.dir)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	checkDir(bwT.dir, dirEntries)
-
-	allStateIndexes := lo.Keys(blocksByStateIndex)
-	sort.Slice(allStateIndexes, func(i, j int) bool { return allStateIndexes[i] < allStateIndexes[j] })
-	for _, stateIndex := range allStateIndexes {
-		stateIndexPaths := blocksByStateIndex[stateIndex]
-		for _, stateIndexPath := range stateIndexPaths {
-			fileBlock, fileErr := BlockFromFilePath(stateIndexPath)
-			if fileErr != nil {
-				bwT.metrics.IncFailedReads()
-				bwT.LogWarn("Unable to read %v: %v", stateIndexPath, err)
-				continue
-			}
-			if !cb(stateIndex, fileBlock) {
-				return nil
-			}
-		}
-	}
-	return nil
+	return blocksByStateIndex, nil
 }
 
 func blockInfoFromFilePath[I any](filePath string, getInfoFun func(uint32, io.Reader) (I, error)) (I, error) {
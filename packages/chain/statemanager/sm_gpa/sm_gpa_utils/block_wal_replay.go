@@ -0,0 +1,181 @@
+package sm_gpa_utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/state"
+)
+
+type BlockWALReplayOptions struct {
+	// VerifyOnly makes the replay check the blocks against the states, which
+	// are already in the store, without modifying it: the blocks missing in
+	// the store are committed to an in-memory overlay of it, so that the
+	// following blocks can still be verified. Otherwise, the blocks, which are
+	// missing in the store, are committed and the ones already present are
+	// verified.
+	VerifyOnly bool
+	// TargetIndex is the state index of the last blocks to replay; -1 - all
+	// the blocks in the WAL are replayed.
+	TargetIndex int64
+	// SetLatest makes the last replayed block the latest one in the store, if
+	// its index is larger than the index of the current latest block. Ignored,
+	// if VerifyOnly is set.
+	SetLatest bool
+}
+
+func NewBlockWALReplayOptions() BlockWALReplayOptions {
+	return BlockWALReplayOptions{TargetIndex: -1}
+}
+
+// BlockWALGap is a range of state indexes, the blocks of which could not be
+// replayed, because their previous states are neither in the store nor in the
+// WAL.
+type BlockWALGap struct {
+	FromIndex uint32
+	ToIndex   uint32
+}
+
+// BlockWALInconsistency describes the block, which produced other
+// L1 commitment, than the one written in it, when its mutations were applied
+// to the previous state.
+type BlockWALInconsistency struct {
+	StateIndex uint32
+	Expected   *state.L1Commitment
+	Actual     *state.L1Commitment
+}
+
+type BlockWALReplayResult struct {
+	// BlocksCommitted is the number of the blocks committed to the store.
+	BlocksCommitted int
+	// BlocksVerified is the number of the blocks, which were already in the
+	// store and were verified.
+	BlocksVerified int
+	// BlocksMissing is the number of the consistent blocks, which are not in
+	// the store; only counted if VerifyOnly is set. They are replayed in
+	// memory, so the blocks following them are verified as well.
+	BlocksMissing int
+	// BlocksSkipped is the number of the blocks in the gaps.
+	BlocksSkipped int
+	Gaps          []BlockWALGap
+	// Forks are the state indexes, for which the WAL contains several blocks.
+	Forks []uint32
+	// FirstInconsistency is the first block, which does not match its
+	// L1 commitment; the replay stops on it. nil, if no such block was found.
+	FirstInconsistency *BlockWALInconsistency
+	// LastBlock is the last block, which was successfully replayed.
+	LastBlock state.Block
+}
+
+func (r *BlockWALReplayResult) addGap(stateIndex uint32) {
+	r.BlocksSkipped++
+	if len(r.Gaps) > 0 {
+		last := &r.Gaps[len(r.Gaps)-1]
+		if stateIndex == last.ToIndex || stateIndex == last.ToIndex+1 {
+			last.ToIndex = stateIndex
+			return
+		}
+	}
+	r.Gaps = append(r.Gaps, BlockWALGap{FromIndex: stateIndex, ToIndex: stateIndex})
+}
+
+func (r *BlockWALReplayResult) addFork(stateIndex uint32) {
+	if len(r.Forks) == 0 || r.Forks[len(r.Forks)-1] != stateIndex {
+		r.Forks = append(r.Forks, stateIndex)
+	}
+}
+
+// ReplayBlockWAL applies the blocks of the WAL, ordered by their state index,
+// to the store and checks that each of them produces the trie root of its
+// L1 commitment. The blocks, which cannot be applied because of the missing
+// previous state, are reported as gaps; several blocks with the same state
+// index are reported as forks. The replay stops on the first inconsistent
+// block. An error is returned only if the WAL cannot be read or the context
+// is cancelled.
+func ReplayBlockWAL(
+	ctx context.Context,
+	log *logger.Logger,
+	store state.Store,
+	wal BlockWAL,
+	options BlockWALReplayOptions,
+) (*BlockWALReplayResult, error) {
+	result := &BlockWALReplayResult{}
+	replayStore := store
+	if options.VerifyOnly {
+		var err error
+		if replayStore, err = state.NewOverlayStore(store); err != nil {
+			return result, err
+		}
+	}
+	var lastIndex uint32
+	blocksSeen := 0
+	err := wal.ReadAllByStateIndex(func(stateIndex uint32, block state.Block) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		if options.TargetIndex >= 0 && int64(stateIndex) > options.TargetIndex {
+			return false
+		}
+		if blocksSeen > 0 && stateIndex == lastIndex {
+			log.Warnf("Replaying WAL: fork at block index %v, block %s", stateIndex, block.L1Commitment())
+			result.addFork(stateIndex)
+		}
+		lastIndex = stateIndex
+		blocksSeen++
+		if blocksSeen%10000 == 0 {
+			log.Infof("Replaying WAL: %v blocks read, block index %v", blocksSeen, stateIndex)
+		}
+
+		var stateDraft state.StateDraft
+		if stateIndex == 0 {
+			stateDraft = replayStore.NewOriginStateDraft()
+		} else {
+			var err error
+			stateDraft, err = replayStore.NewEmptyStateDraft(block.PreviousL1Commitment())
+			if err != nil {
+				log.Debugf("Replaying WAL: previous state of block index %v %s is not available: %v",
+					stateIndex, block.L1Commitment(), err)
+				result.addGap(stateIndex)
+				return true
+			}
+		}
+		block.Mutations().ApplyTo(stateDraft)
+		actual := replayStore.ExtractBlock(stateDraft).L1Commitment()
+		if !actual.Equals(block.L1Commitment()) {
+			result.FirstInconsistency = &BlockWALInconsistency{
+				StateIndex: stateIndex,
+				Expected:   block.L1Commitment(),
+				Actual:     actual,
+			}
+			return false
+		}
+		switch {
+		case store.HasTrieRoot(block.TrieRoot()):
+			result.BlocksVerified++
+		case options.VerifyOnly:
+			replayStore.Commit(stateDraft)
+			result.BlocksMissing++
+		default:
+			store.Commit(stateDraft)
+			result.BlocksCommitted++
+		}
+		result.LastBlock = block
+		return true
+	})
+	if err != nil {
+		return result, fmt.Errorf("failed to read WAL: %w", err)
+	}
+	if ctx.Err() != nil {
+		return result, ctx.Err()
+	}
+	if options.SetLatest && !options.VerifyOnly && result.LastBlock != nil {
+		latestIndex, err := store.LatestBlockIndex()
+		if err != nil || latestIndex < result.LastBlock.StateIndex() {
+			if err := store.SetLatest(result.LastBlock.TrieRoot()); err != nil {
+				return result, fmt.Errorf("failed to set latest block: %w", err)
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,109 @@
+package sm_gpa_utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/origin"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+)
+
+func TestReplayBlockWAL(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(8, 1)                                  // indexes 1..8
+	branch := factory.GetBlocksFrom(2, 1, blocks[3].L1Commitment(), 2) // indexes 5..6
+	newStore := func() state.Store {
+		store := state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())
+		origin.InitChain(store, nil, 0)
+		return store
+	}
+	newWAL := func(blockSlices ...[]state.Block) BlockWAL {
+		cleanupAfterTest(t)
+		wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+		require.NoError(t, err)
+		for _, blockSlice := range blockSlices {
+			for _, block := range blockSlice {
+				require.NoError(t, wal.Write(block))
+			}
+		}
+		return wal
+	}
+	ctx := context.Background()
+
+	// Rebuild and then verify the store
+	wal := newWAL(blocks, branch)
+	store := newStore()
+	options := NewBlockWALReplayOptions()
+	options.SetLatest = true
+	result, err := ReplayBlockWAL(ctx, log, store, wal, options)
+	require.NoError(t, err)
+	require.Nil(t, result.FirstInconsistency)
+	require.Equal(t, 10, result.BlocksCommitted)
+	require.Empty(t, result.Gaps)
+	require.Equal(t, []uint32{5, 6}, result.Forks)
+	CheckBlocksEqual(t, blocks[7], result.LastBlock)
+	latest, err := store.LatestBlock()
+	require.NoError(t, err)
+	CheckBlocksEqual(t, blocks[7], latest)
+	for _, block := range blocks {
+		CheckStateInStores(t, factory.GetStore(), store, block.L1Commitment())
+	}
+	for _, block := range branch {
+		CheckStateInStores(t, factory.GetStore(), store, block.L1Commitment())
+	}
+
+	result, err = ReplayBlockWAL(ctx, log, store, wal, BlockWALReplayOptions{VerifyOnly: true, TargetIndex: 6})
+	require.NoError(t, err)
+	require.Nil(t, result.FirstInconsistency)
+	require.Zero(t, result.BlocksCommitted)
+	require.Equal(t, 8, result.BlocksVerified)
+
+	// Verify a store, which misses some of the blocks
+	store = newStore()
+	options.TargetIndex = 3
+	result, err = ReplayBlockWAL(ctx, log, store, wal, options)
+	require.NoError(t, err)
+	require.Equal(t, 3, result.BlocksCommitted)
+	result, err = ReplayBlockWAL(ctx, log, store, wal, BlockWALReplayOptions{VerifyOnly: true, TargetIndex: -1})
+	require.NoError(t, err)
+	require.Nil(t, result.FirstInconsistency)
+	require.Equal(t, 3, result.BlocksVerified)
+	require.Equal(t, 7, result.BlocksMissing)
+	require.Empty(t, result.Gaps)
+	require.Zero(t, result.BlocksSkipped)
+	CheckBlocksEqual(t, blocks[7], result.LastBlock)
+	require.False(t, store.HasTrieRoot(blocks[3].TrieRoot()))
+
+	// Rebuild with a block missing in WAL
+	wal = newWAL(blocks[:2], blocks[3:])
+	store = newStore()
+	result, err = ReplayBlockWAL(ctx, log, store, wal, NewBlockWALReplayOptions())
+	require.NoError(t, err)
+	require.Nil(t, result.FirstInconsistency)
+	require.Equal(t, 2, result.BlocksCommitted)
+	require.Equal(t, []BlockWALGap{{FromIndex: 4, ToIndex: 8}}, result.Gaps)
+	require.False(t, store.HasTrieRoot(blocks[3].TrieRoot()))
+
+	// Rebuild with an inconsistent block: its trie root is corrupted
+	blockBytes := blocks[2].Bytes()
+	blockBytes[0] ^= 0xff
+	corruptedBlock, err := state.BlockFromBytes(blockBytes)
+	require.NoError(t, err)
+	wal = newWAL(blocks[:2], []state.Block{corruptedBlock}, blocks[3:])
+	store = newStore()
+	result, err = ReplayBlockWAL(ctx, log, store, wal, NewBlockWALReplayOptions())
+	require.NoError(t, err)
+	require.NotNil(t, result.FirstInconsistency)
+	require.EqualValues(t, 3, result.FirstInconsistency.StateIndex)
+	require.True(t, result.FirstInconsistency.Actual.Equals(blocks[2].L1Commitment()))
+	require.Equal(t, 2, result.BlocksCommitted)
+	require.False(t, store.HasTrieRoot(corruptedBlock.TrieRoot()))
+}
@@ -0,0 +1,717 @@
+package sm_gpa_utils
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/samber/lo"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/runtime/ioutils"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/metrics"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+type BlockWALCompression string
+
+const (
+	BlockWALCompressionNone   BlockWALCompression = "none"
+	BlockWALCompressionZstd   BlockWALCompression = "zstd"
+	BlockWALCompressionSnappy BlockWALCompression = "snappy"
+)
+
+func ParseBlockWALCompression(s string) (BlockWALCompression, error) {
+	switch c := BlockWALCompression(s); c {
+	case BlockWALCompressionNone, BlockWALCompressionZstd, BlockWALCompressionSnappy:
+		return c, nil
+	case "":
+		return BlockWALCompressionNone, nil
+	default:
+		return "", fmt.Errorf("unknown WAL compression %q", s)
+	}
+}
+
+// BlockWALRetention defines which blocks may be deleted from the segmented WAL.
+// The blocks are deleted together with the whole segment, so slightly more
+// blocks than required by the policy may be kept. The segment, to which the
+// blocks are being appended, is never deleted. Zero values disable the
+// respective rule.
+type BlockWALRetention struct {
+	// Blocks is the number of the latest state indexes to keep.
+	Blocks uint32
+	// MaxAge is how long the segment is kept after it was last written to.
+	MaxAge time.Duration
+	// MaxSize is the maximal total size of all the segments in bytes.
+	MaxSize int64
+}
+
+type SegmentedBlockWALOptions struct {
+	// SegmentMaxSize is the size in bytes, after which the new segment is started.
+	SegmentMaxSize int64
+	Compression    BlockWALCompression
+	Retention      BlockWALRetention
+	// ReadOnly opens the existing WAL without modifying it: the corrupted end
+	// of the active segment is ignored instead of truncated, the missing
+	// indexes are not written, the retention is not applied and writing
+	// fails.
+	ReadOnly bool
+}
+
+func NewSegmentedBlockWALOptions() *SegmentedBlockWALOptions {
+	return &SegmentedBlockWALOptions{
+		SegmentMaxSize: 64 * 1024 * 1024,
+		Compression:    BlockWALCompressionNone,
+	}
+}
+
+// segmentedBlockWAL appends the blocks to the segment files. Each segment, once
+// it is full, is sealed by writing the index file next to it, so that the
+// segment need not be read on startup. Only the last (active) segment is
+// scanned on startup; a partially written record at its end is truncated.
+//
+// Segment format:
+//   - Version (4 bytes, unsigned int); value 1
+//   - Records, each of them:
+//   - Compression (1 byte)
+//   - State index (4 bytes, unsigned int)
+//   - Block hash
+//   - Length of the data (4 bytes, unsigned int)
+//   - CRC32 of the data (4 bytes, unsigned int)
+//   - Data: block bytes, compressed as indicated
+//
+// Index format:
+//   - Number of entries (4 bytes, unsigned int)
+//   - Entries, each of them: block hash, state index, offset of the record
+//     in the segment (8 bytes) and length of the record (4 bytes)
+type segmentedBlockWAL struct {
+	*logger.WrappedLogger
+
+	dir      string
+	options  *SegmentedBlockWALOptions
+	metrics  *metrics.ChainBlockWALMetrics
+	encoder  *zstd.Encoder
+	decoder  *zstd.Decoder
+	segments []*blockWALSegment // ordered by segment number; the last one is active
+	blocks   map[state.BlockHash]*blockWALLocation
+	mutex    sync.RWMutex
+}
+
+type blockWALSegment struct {
+	number   uint64
+	size     int64
+	modTime  time.Time
+	maxIndex uint32
+	entries  []*blockWALLocation
+}
+
+type blockWALLocation struct {
+	segment    *blockWALSegment
+	blockHash  state.BlockHash
+	stateIndex uint32
+	offset     int64
+	length     uint32
+}
+
+const (
+	constBlockWALSegmentsDir        = "segments"
+	constBlockWALSegmentSuffix      = ".seg"
+	constBlockWALSegmentIndexSuffix = ".idx"
+	constBlockWALSegmentVersion     = uint32(1)
+	constBlockWALSegmentHeaderSize  = 4
+	constBlockWALRecordHeaderSize   = 1 + 4 + state.BlockHashSize + 4 + 4
+)
+
+const (
+	blockWALRecordCompressionNone byte = iota
+	blockWALRecordCompressionZstd
+	blockWALRecordCompressionSnappy
+)
+
+var _ BlockWAL = &segmentedBlockWAL{}
+
+// NewSegmentedBlockWAL creates WAL, which appends the blocks to the segment
+// files in `<baseDir>/<chainID>/segments` folder. The blocks of the per-file
+// layout (see `NewBlockWAL`) are not visible to it; use `MigrateBlockWAL` to
+// move them to the segments.
+func NewSegmentedBlockWAL(log *logger.Logger, baseDir string, chainID isc.ChainID, metrics *metrics.ChainBlockWALMetrics, options *SegmentedBlockWALOptions) (BlockWAL, error) {
+	return newSegmentedBlockWAL(log, baseDir, chainID, metrics, options)
+}
+
+func newSegmentedBlockWAL(log *logger.Logger, baseDir string, chainID isc.ChainID, metrics *metrics.ChainBlockWALMetrics, options *SegmentedBlockWALOptions) (*segmentedBlockWAL, error) {
+	if options.SegmentMaxSize <= 0 {
+		return nil, fmt.Errorf("invalid WAL segment size %v", options.SegmentMaxSize)
+	}
+	if _, err := ParseBlockWALCompression(string(options.Compression)); err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(baseDir, chainID.String(), constBlockWALSegmentsDir)
+	if options.ReadOnly {
+		if err := checkBlockWALDir(dir); err != nil {
+			return nil, err
+		}
+	} else if err := ioutils.CreateDirectory(dir, 0o777); err != nil {
+		return nil, fmt.Errorf("BlockWAL cannot create folder %v: %w", dir, err)
+	}
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, err
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, err
+	}
+	result := &segmentedBlockWAL{
+		WrappedLogger: logger.NewWrappedLogger(log.Named("WAL")),
+		dir:           dir,
+		options:       options,
+		metrics:       metrics,
+		encoder:       encoder,
+		decoder:       decoder,
+		blocks:        map[state.BlockHash]*blockWALLocation{},
+	}
+	if err := result.load(); err != nil {
+		return nil, fmt.Errorf("BlockWAL cannot load segments from folder %v: %w", dir, err)
+	}
+	if !options.ReadOnly {
+		result.applyRetention()
+	}
+	result.LogDebugf("Segmented BlockWAL created in folder %v, segments: %v, blocks: %v, options: %+v",
+		dir, len(result.segments), len(result.blocks), *options)
+	return result, nil
+}
+
+func (swT *segmentedBlockWAL) load() error {
+	dirEntries, err := os.ReadDir(swT.dir)
+	if err != nil {
+		return err
+	}
+	segmentNumbers := []uint64{}
+	for _, dirEntry := range dirEntries {
+		name := dirEntry.Name()
+		if dirEntry.IsDir() || !strings.HasSuffix(name, constBlockWALSegmentSuffix) {
+			continue
+		}
+		number, err := strconv.ParseUint(strings.TrimSuffix(name, constBlockWALSegmentSuffix), 10, 64)
+		if err != nil {
+			swT.LogWarnf("Unexpected file %s in WAL segments folder", name)
+			continue
+		}
+		segmentNumbers = append(segmentNumbers, number)
+	}
+	sort.Slice(segmentNumbers, func(i, j int) bool { return segmentNumbers[i] < segmentNumbers[j] })
+	for i, number := range segmentNumbers {
+		segment := &blockWALSegment{number: number}
+		fileInfo, err := os.Stat(swT.segmentPath(number))
+		if err != nil {
+			return err
+		}
+		segment.size = fileInfo.Size()
+		segment.modTime = fileInfo.ModTime()
+		active := i == len(segmentNumbers)-1
+		if active || !swT.readSegmentIndex(segment) {
+			if err := swT.scanSegment(segment, active); err != nil {
+				return fmt.Errorf("failed to scan segment %s: %w", swT.segmentPath(number), err)
+			}
+			if !active && !swT.options.ReadOnly {
+				if err := swT.writeSegmentIndex(segment); err != nil {
+					return err
+				}
+			}
+		}
+		swT.addSegment(segment)
+	}
+	return nil
+}
+
+func (swT *segmentedBlockWAL) addSegment(segment *blockWALSegment) {
+	swT.segments = append(swT.segments, segment)
+	for _, entry := range segment.entries {
+		swT.addEntry(entry)
+	}
+}
+
+func (swT *segmentedBlockWAL) addEntry(entry *blockWALLocation) {
+	swT.blocks[entry.blockHash] = entry
+	if entry.stateIndex > entry.segment.maxIndex {
+		entry.segment.maxIndex = entry.stateIndex
+	}
+}
+
+// Reads the records of the segment without decoding the blocks. If the last
+// record is incomplete or corrupted and `truncate` is set, it is cut off; in
+// read only mode it is only left out.
+func (swT *segmentedBlockWAL) scanSegment(segment *blockWALSegment, truncate bool) error {
+	segmentPath := swT.segmentPath(segment.number)
+	f, err := os.Open(segmentPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	segment.entries = nil
+	rr := rwutil.NewReader(bufio.NewReader(f))
+	version := rr.ReadUint32()
+	if rr.Err != nil {
+		if !truncate {
+			return fmt.Errorf("failed to read segment version: %w", rr.Err)
+		}
+		// The segment was created, but the first record was not written
+		segment.size = 0
+		if swT.options.ReadOnly {
+			return nil
+		}
+		return os.Truncate(segmentPath, 0)
+	}
+	if version != constBlockWALSegmentVersion {
+		return fmt.Errorf("unknown segment version %v", version)
+	}
+	offset := int64(constBlockWALSegmentHeaderSize)
+	for {
+		header, data, err := readBlockWALRecord(rr)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			if !truncate {
+				return err
+			}
+			if swT.options.ReadOnly {
+				swT.LogWarnf("Segment %s is corrupted at offset %v: %v; ignoring the rest of it", segmentPath, offset, err)
+				break
+			}
+			swT.LogWarnf("Segment %s is corrupted at offset %v: %v; truncating it", segmentPath, offset, err)
+			if err := os.Truncate(segmentPath, offset); err != nil {
+				return err
+			}
+			break
+		}
+		entry := header.blockWALLocation
+		entry.segment = segment
+		entry.offset = offset
+		entry.length = constBlockWALRecordHeaderSize + uint32(len(data))
+		segment.entries = append(segment.entries, entry)
+		offset += int64(entry.length)
+	}
+	segment.size = offset
+	return nil
+}
+
+func (swT *segmentedBlockWAL) readSegmentIndex(segment *blockWALSegment) bool {
+	indexPath := swT.segmentIndexPath(segment.number)
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			swT.LogWarnf("Unable to read segment index %s: %v", indexPath, err)
+		}
+		return false
+	}
+	rr := rwutil.NewBytesReader(data)
+	count := rr.ReadUint32()
+	entries := []*blockWALLocation{}
+	for i := uint32(0); i < count && rr.Err == nil; i++ {
+		entry := &blockWALLocation{segment: segment}
+		rr.ReadN(entry.blockHash[:])
+		entry.stateIndex = rr.ReadUint32()
+		entry.offset = rr.ReadInt64()
+		entry.length = rr.ReadUint32()
+		entries = append(entries, entry)
+	}
+	rr.Close()
+	if rr.Err != nil {
+		swT.LogWarnf("Unable to read segment index %s: %v", indexPath, rr.Err)
+		return false
+	}
+	segment.entries = entries
+	return true
+}
+
+func (swT *segmentedBlockWAL) writeSegmentIndex(segment *blockWALSegment) error {
+	ww := rwutil.NewBytesWriter()
+	ww.WriteUint32(uint32(len(segment.entries)))
+	for _, entry := range segment.entries {
+		ww.WriteN(entry.blockHash[:])
+		ww.WriteUint32(entry.stateIndex)
+		ww.WriteInt64(entry.offset)
+		ww.WriteUint32(entry.length)
+	}
+	indexPath := swT.segmentIndexPath(segment.number)
+	tmpIndexPath := indexPath + constBlockWALTmpFileSuffix
+	if err := os.WriteFile(tmpIndexPath, ww.Bytes(), 0o666); err != nil {
+		return fmt.Errorf("failed to write segment index %s: %w", tmpIndexPath, err)
+	}
+	if err := os.Rename(tmpIndexPath, indexPath); err != nil {
+		return fmt.Errorf("failed to move segment index %s to %s: %w", tmpIndexPath, indexPath, err)
+	}
+	return nil
+}
+
+// Does nothing, if block is already in WAL: the same block hash means the same
+// block contents.
+func (swT *segmentedBlockWAL) Write(block state.Block) error {
+	if swT.options.ReadOnly {
+		return fmt.Errorf("cannot write block %s to read only WAL", block.L1Commitment())
+	}
+	blockHash := block.Hash()
+	blockIndex := block.StateIndex()
+	data, compression, err := swT.compress(block.Bytes())
+	if err != nil {
+		swT.metrics.IncFailedWrites()
+		return fmt.Errorf("failed to compress block %s: %w", blockHash, err)
+	}
+
+	swT.mutex.Lock()
+	defer swT.mutex.Unlock()
+	if _, ok := swT.blocks[blockHash]; ok {
+		return nil
+	}
+	segment, err := swT.activeSegment(int64(constBlockWALRecordHeaderSize + len(data)))
+	if err != nil {
+		swT.metrics.IncFailedWrites()
+		return err
+	}
+	segmentPath := swT.segmentPath(segment.number)
+	entry := &blockWALLocation{
+		segment:    segment,
+		blockHash:  blockHash,
+		stateIndex: blockIndex,
+		offset:     segment.size,
+		length:     constBlockWALRecordHeaderSize + uint32(len(data)),
+	}
+	err = func() error {
+		f, err := os.OpenFile(segmentPath, os.O_CREATE|os.O_WRONLY, 0o666)
+		if err != nil {
+			return fmt.Errorf("failed to open segment %s for writing block: %w", segmentPath, err)
+		}
+		defer f.Close()
+		ww := rwutil.NewWriter(f)
+		if segment.size == 0 {
+			ww.WriteUint32(constBlockWALSegmentVersion)
+			entry.offset = constBlockWALSegmentHeaderSize
+		}
+		if _, err = f.Seek(entry.offset, io.SeekStart); err != nil {
+			return fmt.Errorf("failed to seek segment %s: %w", segmentPath, err)
+		}
+		ww.WriteByte(compression)
+		ww.WriteUint32(blockIndex)
+		ww.WriteN(blockHash[:])
+		ww.WriteUint32(uint32(len(data)))
+		ww.WriteUint32(crc32.ChecksumIEEE(data))
+		ww.WriteN(data)
+		if ww.Err != nil {
+			return fmt.Errorf("failed to write block to segment %s: %w", segmentPath, ww.Err)
+		}
+		return f.Sync()
+	}()
+	if err != nil {
+		swT.metrics.IncFailedWrites()
+		return err
+	}
+	segment.size = entry.offset + int64(entry.length)
+	segment.modTime = time.Now()
+	segment.entries = append(segment.entries, entry)
+	swT.addEntry(entry)
+
+	swT.metrics.BlockWritten(blockIndex)
+	swT.LogDebugf("Block index %v %s written to wal segment %s", blockIndex, blockHash, segmentPath)
+	swT.applyRetention()
+	return nil
+}
+
+// Returns the segment, to which the record of the given size should be
+// appended. If the active segment would exceed the maximal size, it is sealed
+// and a new one is started. `mutex` must be held by the caller.
+func (swT *segmentedBlockWAL) activeSegment(recordSize int64) (*blockWALSegment, error) {
+	if len(swT.segments) > 0 {
+		active := swT.segments[len(swT.segments)-1]
+		if len(active.entries) == 0 || active.size+recordSize <= swT.options.SegmentMaxSize {
+			return active, nil
+		}
+		if err := swT.writeSegmentIndex(active); err != nil {
+			return nil, err
+		}
+		swT.LogDebugf("WAL segment %s sealed: %v blocks, %v bytes",
+			swT.segmentPath(active.number), len(active.entries), active.size)
+	}
+	segment := &blockWALSegment{number: 1, modTime: time.Now()}
+	if len(swT.segments) > 0 {
+		segment.number = swT.segments[len(swT.segments)-1].number + 1
+	}
+	swT.segments = append(swT.segments, segment)
+	return segment, nil
+}
+
+// Deletes the oldest sealed segments, which are not needed according to the
+// retention policy. `mutex` must be held by the caller (or WAL must not be
+// accessible to other goroutines yet).
+func (swT *segmentedBlockWAL) applyRetention() {
+	retention := swT.options.Retention
+	if len(swT.segments) <= 1 {
+		return
+	}
+	var lastIndex uint32
+	var totalSize int64
+	for _, segment := range swT.segments {
+		if segment.maxIndex > lastIndex {
+			lastIndex = segment.maxIndex
+		}
+		totalSize += segment.size
+	}
+	now := time.Now()
+	deleteCount := 0
+	for _, segment := range swT.segments[:len(swT.segments)-1] {
+		expired := (retention.Blocks > 0 && segment.maxIndex+retention.Blocks <= lastIndex) ||
+			(retention.MaxAge > 0 && now.Sub(segment.modTime) > retention.MaxAge) ||
+			(retention.MaxSize > 0 && totalSize > retention.MaxSize)
+		if !expired {
+			break
+		}
+		if err := swT.deleteSegment(segment); err != nil {
+			swT.LogWarnf("Unable to delete WAL segment %s: %v", swT.segmentPath(segment.number), err)
+			break
+		}
+		totalSize -= segment.size
+		deleteCount++
+	}
+	swT.segments = swT.segments[deleteCount:]
+}
+
+func (swT *segmentedBlockWAL) deleteSegment(segment *blockWALSegment) error {
+	for _, path := range []string{swT.segmentIndexPath(segment.number), swT.segmentPath(segment.number)} {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	for _, entry := range segment.entries {
+		if swT.blocks[entry.blockHash] == entry {
+			delete(swT.blocks, entry.blockHash)
+		}
+	}
+	swT.LogDebugf("WAL segment %s with %v blocks deleted, max block index %v",
+		swT.segmentPath(segment.number), len(segment.entries), segment.maxIndex)
+	return nil
+}
+
+func (swT *segmentedBlockWAL) Contains(blockHash state.BlockHash) bool {
+	swT.mutex.RLock()
+	defer swT.mutex.RUnlock()
+	_, ok := swT.blocks[blockHash]
+	return ok
+}
+
+func (swT *segmentedBlockWAL) Read(blockHash state.BlockHash) (state.Block, error) {
+	swT.mutex.RLock()
+	defer swT.mutex.RUnlock()
+	entry, ok := swT.blocks[blockHash]
+	if !ok {
+		return nil, fmt.Errorf("block hash %s is not present in WAL", blockHash)
+	}
+	block, err := swT.readBlock(entry)
+	if err != nil {
+		swT.metrics.IncFailedReads()
+		return nil, err
+	}
+	return block, nil
+}
+
+// This reads all the blocks in the WAL and passes them to the supplied callback.
+// The blocks are provided ordered by the state index, so that they can be applied to the store.
+func (swT *segmentedBlockWAL) ReadAllByStateIndex(cb func(stateIndex uint32, block state.Block) bool) error {
+	swT.mutex.RLock()
+	entries := lo.Values(swT.blocks)
+	swT.mutex.RUnlock()
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].stateIndex < entries[j].stateIndex })
+	for _, entry := range entries {
+		block, ok, err := swT.readBlockIfPresent(entry)
+		if !ok {
+			continue // deleted by the retention in the meantime
+		}
+		if err != nil {
+			swT.metrics.IncFailedReads()
+			swT.LogWarnf("Unable to read block %s from segment %s: %v", entry.blockHash, swT.segmentPath(entry.segment.number), err)
+			continue
+		}
+		if !cb(entry.stateIndex, block) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Reads the block, unless its entry was removed from the WAL after it was
+// looked up. The read lock is held only while the block is read, so that the
+// callback of `ReadAllByStateIndex` does not block the writes.
+func (swT *segmentedBlockWAL) readBlockIfPresent(entry *blockWALLocation) (state.Block, bool, error) {
+	swT.mutex.RLock()
+	defer swT.mutex.RUnlock()
+	if swT.blocks[entry.blockHash] != entry {
+		return nil, false, nil
+	}
+	block, err := swT.readBlock(entry)
+	return block, true, err
+}
+
+// `mutex` must be held by the caller at least for reading, so that the segment
+// is not deleted by the retention while it is read.
+func (swT *segmentedBlockWAL) readBlock(entry *blockWALLocation) (state.Block, error) {
+	segmentPath := swT.segmentPath(entry.segment.number)
+	f, err := os.Open(segmentPath)
+	if err != nil {
+		return nil, fmt.Errorf("opening segment %s for reading failed: %w", segmentPath, err)
+	}
+	defer f.Close()
+	record := make([]byte, entry.length)
+	if _, err = f.ReadAt(record, entry.offset); err != nil {
+		return nil, fmt.Errorf("reading block %s from segment %s failed: %w", entry.blockHash, segmentPath, err)
+	}
+	rr := rwutil.NewBytesReader(record)
+	recordEntry, data, err := readBlockWALRecord(rr)
+	if err != nil {
+		return nil, err
+	}
+	if recordEntry.blockHash != entry.blockHash {
+		return nil, fmt.Errorf("block hash %s in segment %s does not match expected %s", recordEntry.blockHash, segmentPath, entry.blockHash)
+	}
+	blockBytes, err := swT.decompress(recordEntry.compression, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress block %s: %w", entry.blockHash, err)
+	}
+	block, err := state.BlockFromBytes(blockBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read block %s: %w", entry.blockHash, err)
+	}
+	if !block.Hash().Equals(entry.blockHash) {
+		return nil, fmt.Errorf("block %s in segment %s has unexpected hash %s", entry.blockHash, segmentPath, block.Hash())
+	}
+	return block, nil
+}
+
+type blockWALRecordHeader struct {
+	*blockWALLocation
+	compression byte
+}
+
+// Reads the record and checks its checksum. Returns io.EOF if there are no more records.
+func readBlockWALRecord(rr *rwutil.Reader) (*blockWALRecordHeader, []byte, error) {
+	header := &blockWALRecordHeader{blockWALLocation: &blockWALLocation{}}
+	header.compression = rr.ReadByte()
+	if errors.Is(rr.Err, io.EOF) {
+		return nil, nil, io.EOF
+	}
+	header.stateIndex = rr.ReadUint32()
+	rr.ReadN(header.blockHash[:])
+	length := rr.ReadUint32()
+	checksum := rr.ReadUint32()
+	if rr.Err != nil {
+		return nil, nil, fmt.Errorf("failed to read record header: %w", rr.Err)
+	}
+	data := make([]byte, length)
+	rr.ReadN(data)
+	if rr.Err != nil {
+		return nil, nil, fmt.Errorf("failed to read record data: %w", rr.Err)
+	}
+	if crc32.ChecksumIEEE(data) != checksum {
+		return nil, nil, fmt.Errorf("checksum mismatch of block %s", header.blockHash)
+	}
+	return header, data, nil
+}
+
+func (swT *segmentedBlockWAL) compress(data []byte) ([]byte, byte, error) {
+	switch swT.options.Compression {
+	case BlockWALCompressionZstd:
+		return swT.encoder.EncodeAll(data, nil), blockWALRecordCompressionZstd, nil
+	case BlockWALCompressionSnappy:
+		return snappy.Encode(nil, data), blockWALRecordCompressionSnappy, nil
+	case BlockWALCompressionNone, "":
+		return data, blockWALRecordCompressionNone, nil
+	default:
+		return nil, 0, fmt.Errorf("unknown WAL compression %q", swT.options.Compression)
+	}
+}
+
+// The compression of the record is used, so that the records written with
+// a different compression setting can still be read.
+func (swT *segmentedBlockWAL) decompress(compression byte, data []byte) ([]byte, error) {
+	switch compression {
+	case blockWALRecordCompressionZstd:
+		return swT.decoder.DecodeAll(data, nil)
+	case blockWALRecordCompressionSnappy:
+		return snappy.Decode(nil, data)
+	case blockWALRecordCompressionNone:
+		return data, nil
+	default:
+		return nil, fmt.Errorf("unknown record compression %v", compression)
+	}
+}
+
+func (swT *segmentedBlockWAL) segmentPath(number uint64) string {
+	return filepath.Join(swT.dir, fmt.Sprintf("%020d%s", number, constBlockWALSegmentSuffix))
+}
+
+func (swT *segmentedBlockWAL) segmentIndexPath(number uint64) string {
+	return filepath.Join(swT.dir, fmt.Sprintf("%020d%s", number, constBlockWALSegmentIndexSuffix))
+}
+
+// MigrateBlockWAL moves the blocks of the per-file WAL layout (see
+// `NewBlockWAL`) of the chain to the segments (see `NewSegmentedBlockWAL`).
+// The block files are deleted after all of them are written to the segments.
+// It must be run while the WAL is not used by the chain. Returns the number of
+// blocks migrated; 0 if there was nothing to migrate.
+func MigrateBlockWAL(log *logger.Logger, baseDir string, chainID isc.ChainID, metrics *metrics.ChainBlockWALMetrics, options *SegmentedBlockWALOptions) (int, error) {
+	legacyWAL, err := NewBlockWAL(log, baseDir, chainID, metrics)
+	if err != nil {
+		return 0, err
+	}
+	legacy := legacyWAL.(*blockWAL)
+	blockPaths, err := legacy.blockPathsByStateIndex()
+	if err != nil {
+		return 0, fmt.Errorf("cannot read WAL folder %v: %w", legacy.dir, err)
+	}
+	if len(blockPaths) == 0 {
+		return 0, nil
+	}
+	segmented, err := newSegmentedBlockWAL(log, baseDir, chainID, metrics, options)
+	if err != nil {
+		return 0, err
+	}
+	legacy.LogInfof("Migrating WAL blocks from folder %v to segments in %v...", legacy.dir, segmented.dir)
+	count := 0
+	var writeErr error
+	err = legacy.ReadAllByStateIndex(func(stateIndex uint32, block state.Block) bool {
+		if writeErr = segmented.Write(block); writeErr != nil {
+			return false
+		}
+		count++
+		if count%10000 == 0 {
+			legacy.LogInfof("Migrating WAL blocks: %v blocks migrated, last block index %v", count, stateIndex)
+		}
+		return true
+	})
+	if err != nil {
+		return count, err
+	}
+	if writeErr != nil {
+		return count, fmt.Errorf("failed to migrate WAL block: %w", writeErr)
+	}
+	for _, paths := range blockPaths {
+		for _, path := range paths {
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return count, fmt.Errorf("failed to delete migrated WAL file %s: %w", path, err)
+			}
+		}
+	}
+	legacy.LogInfof("Migrating WAL blocks: done, %v blocks migrated", count)
+	return count, nil
+}
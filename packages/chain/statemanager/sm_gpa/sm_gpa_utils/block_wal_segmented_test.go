@@ -0,0 +1,275 @@
+package sm_gpa_utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+)
+
+func TestSegmentedBlockWALBasic(t *testing.T) {
+	for _, compression := range []BlockWALCompression{BlockWALCompressionNone, BlockWALCompressionZstd, BlockWALCompressionSnappy} {
+		t.Run(string(compression), func(t *testing.T) {
+			log := testlogger.NewLogger(t)
+			defer log.Sync()
+			defer cleanupAfterTest(t)
+
+			factory := NewBlockFactory(t)
+			blocks := factory.GetBlocks(10, 1)
+			options := NewSegmentedBlockWALOptions()
+			options.Compression = compression
+			wal, err := NewSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+			require.NoError(t, err)
+			for _, block := range blocks[:8] {
+				require.NoError(t, wal.Write(block))
+			}
+			// Writing the same block again does not duplicate it
+			require.NoError(t, wal.Write(blocks[0]))
+
+			// Restart with a different compression: the old records are still readable
+			options.Compression = BlockWALCompressionNone
+			wal, err = NewSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+			require.NoError(t, err)
+			require.NoError(t, wal.Write(blocks[8]))
+			for i, block := range blocks {
+				require.Equal(t, i < 9, wal.Contains(block.Hash()))
+				blockRead, err := wal.Read(block.Hash())
+				if i < 9 {
+					require.NoError(t, err)
+					CheckBlocksEqual(t, block, blockRead)
+				} else {
+					require.Error(t, err)
+				}
+			}
+
+			var blocksRead []state.Block
+			err = wal.ReadAllByStateIndex(func(stateIndex uint32, block state.Block) bool {
+				require.Equal(t, stateIndex, block.StateIndex())
+				blocksRead = append(blocksRead, block)
+				return true
+			})
+			require.NoError(t, err)
+			require.Len(t, blocksRead, 9)
+			for i := range blocksRead {
+				CheckBlocksEqual(t, blocks[i], blocksRead[i])
+			}
+		})
+	}
+}
+
+// Check that full segments are sealed with an index and that the partially
+// written record at the end of the active segment is discarded on restart
+func TestSegmentedBlockWALSegments(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(6, 1)
+	options := NewSegmentedBlockWALOptions()
+	options.SegmentMaxSize = 1 // Every block in its own segment
+	wal, err := newSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	for _, block := range blocks[:5] {
+		require.NoError(t, wal.Write(block))
+	}
+	require.Len(t, wal.segments, 5)
+	for i, segment := range wal.segments {
+		_, err = os.Stat(wal.segmentIndexPath(segment.number))
+		require.Equal(t, i < 4, err == nil) // the active segment is not sealed
+	}
+
+	// Simulate a crash while writing the last block
+	activePath := wal.segmentPath(wal.segments[4].number)
+	info, err := os.Stat(activePath)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(activePath, info.Size()-3))
+
+	wal, err = newSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	for i, block := range blocks[:5] {
+		require.Equal(t, i < 4, wal.Contains(block.Hash()))
+	}
+	require.NoError(t, wal.Write(blocks[4]))
+	require.NoError(t, wal.Write(blocks[5]))
+	for _, block := range blocks {
+		blockRead, err := wal.Read(block.Hash())
+		require.NoError(t, err)
+		CheckBlocksEqual(t, block, blockRead)
+	}
+}
+
+func TestSegmentedBlockWALRetention(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(10, 1)
+	options := NewSegmentedBlockWALOptions()
+	options.SegmentMaxSize = 1
+	options.Retention.Blocks = 3
+	wal, err := newSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	for i, block := range blocks {
+		require.NoError(t, wal.Write(block))
+		for j := 0; j <= i; j++ {
+			require.Equal(t, j+3 > i, wal.Contains(blocks[j].Hash()))
+		}
+	}
+
+	// By size: only the active segment and one more fit
+	options.Retention = BlockWALRetention{MaxSize: 2*wal.segments[0].size + 1}
+	wal, err = newSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	for i, block := range blocks {
+		require.Equal(t, i >= 8, wal.Contains(block.Hash()))
+	}
+
+	// By age: the active segment is kept even if it is too old
+	options.Retention = BlockWALRetention{MaxAge: time.Hour}
+	for _, segment := range wal.segments {
+		past := time.Now().Add(-2 * time.Hour)
+		require.NoError(t, os.Chtimes(wal.segmentPath(segment.number), past, past))
+	}
+	wal, err = newSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	for i, block := range blocks {
+		require.Equal(t, i == 9, wal.Contains(block.Hash()))
+	}
+	segmentFiles, err := filepath.Glob(filepath.Join(wal.dir, "*"))
+	require.NoError(t, err)
+	require.Equal(t, []string{wal.segmentPath(wal.segments[0].number)}, segmentFiles)
+}
+
+// Check that the blocks are either read completely or reported as not present,
+// while the retention deletes their segments concurrently
+func TestSegmentedBlockWALConcurrentRetention(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(50, 1)
+	options := NewSegmentedBlockWALOptions()
+	options.SegmentMaxSize = 1
+	options.Retention.Blocks = 2
+	wal, err := NewSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for _, block := range blocks {
+			require.NoError(t, wal.Write(block))
+		}
+	}()
+	for finished := false; !finished; {
+		select {
+		case <-done:
+			finished = true
+		default:
+		}
+		for _, block := range blocks {
+			blockRead, err := wal.Read(block.Hash())
+			if err != nil {
+				require.ErrorContains(t, err, "is not present in WAL")
+				continue
+			}
+			CheckBlocksEqual(t, block, blockRead)
+		}
+		err = wal.ReadAllByStateIndex(func(stateIndex uint32, block state.Block) bool {
+			CheckBlocksEqual(t, blocks[stateIndex-1], block)
+			return true
+		})
+		require.NoError(t, err)
+	}
+}
+
+// Check that the read only WAL neither truncates the active segment, nor
+// writes the missing indexes, nor applies the retention
+func TestSegmentedBlockWALReadOnly(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(4, 1)
+	options := NewSegmentedBlockWALOptions()
+	options.ReadOnly = true
+	_, err := newSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.Error(t, err) // the folder is not created
+
+	options.ReadOnly = false
+	options.SegmentMaxSize = 1
+	wal, err := newSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		require.NoError(t, wal.Write(block))
+	}
+	require.NoError(t, os.Remove(wal.segmentIndexPath(wal.segments[0].number)))
+	activePath := wal.segmentPath(wal.segments[3].number)
+	info, err := os.Stat(activePath)
+	require.NoError(t, err)
+	truncatedSize := info.Size() - 3
+	require.NoError(t, os.Truncate(activePath, truncatedSize))
+	filesBefore, err := filepath.Glob(filepath.Join(wal.dir, "*"))
+	require.NoError(t, err)
+
+	options.ReadOnly = true
+	options.Retention.Blocks = 1
+	wal, err = newSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	for i, block := range blocks {
+		require.Equal(t, i < 3, wal.Contains(block.Hash()))
+	}
+	require.Error(t, wal.Write(blocks[3]))
+	filesAfter, err := filepath.Glob(filepath.Join(wal.dir, "*"))
+	require.NoError(t, err)
+	require.Equal(t, filesBefore, filesAfter)
+	info, err = os.Stat(activePath)
+	require.NoError(t, err)
+	require.Equal(t, truncatedSize, info.Size())
+}
+
+func TestMigrateBlockWAL(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(8, 1)
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	require.NoError(t, err)
+	for _, block := range blocks[:4] {
+		require.NoError(t, wal.Write(block))
+	}
+	writeBlocksLegacy(t, factory.GetChainID(), blocks[4:])
+
+	options := NewSegmentedBlockWALOptions()
+	options.Compression = BlockWALCompressionZstd
+	count, err := MigrateBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	require.Equal(t, len(blocks), count)
+	for _, block := range blocks {
+		require.False(t, wal.Contains(block.Hash()))
+	}
+
+	// Nothing to migrate anymore
+	count, err = MigrateBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	segmentedWAL, err := NewSegmentedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), options)
+	require.NoError(t, err)
+	for _, block := range blocks {
+		blockRead, err := segmentedWAL.Read(block.Hash())
+		require.NoError(t, err)
+		CheckBlocksEqual(t, block, blockRead)
+	}
+}
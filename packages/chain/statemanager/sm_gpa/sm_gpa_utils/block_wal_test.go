@@ -159,6 +159,63 @@ func TestBlockWALRestart(t *testing.T) {
 	}
 }
 
+func TestBlockWALReadOnly(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(4, 1)
+	_, err := NewReadOnlyBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	require.Error(t, err) // the folder is not created
+	wal, err := NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	require.NoError(t, err)
+	for i := range blocks[:3] {
+		require.NoError(t, wal.Write(blocks[i]))
+	}
+
+	wal, err = NewReadOnlyBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	require.NoError(t, err)
+	for i := range blocks {
+		require.Equal(t, i < 3, wal.Contains(blocks[i].Hash()))
+	}
+	require.Error(t, wal.Write(blocks[3]))
+	require.False(t, wal.Contains(blocks[3].Hash()))
+}
+
+// Check if old blocks are deleted from WAL, which keeps limited number of blocks
+func TestBlockWALPruning(t *testing.T) {
+	log := testlogger.NewLogger(t)
+	defer log.Sync()
+	defer cleanupAfterTest(t)
+
+	factory := NewBlockFactory(t)
+	blocks := factory.GetBlocks(10, 1)
+	wal, err := NewPrunedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), 3)
+	require.NoError(t, err)
+	for i := range blocks {
+		err = wal.Write(blocks[i])
+		require.NoError(t, err)
+		for j := 0; j <= i; j++ {
+			require.Equal(t, j+3 > i, wal.Contains(blocks[j].Hash()))
+		}
+	}
+
+	// Restart with a lower number of blocks to keep: the excess blocks are deleted on start
+	wal, err = NewPrunedBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics(), 2)
+	require.NoError(t, err)
+	for i := range blocks {
+		require.Equal(t, i >= 8, wal.Contains(blocks[i].Hash()))
+	}
+
+	// Restart without pruning: nothing is deleted
+	wal, err = NewBlockWAL(log, constTestFolder, factory.GetChainID(), mockBlockWALMetrics())
+	require.NoError(t, err)
+	for i := range blocks {
+		require.Equal(t, i >= 8, wal.Contains(blocks[i].Hash()))
+	}
+}
+
 func testReadAllByStateIndex(t *testing.T, addToWALFun func(isc.ChainID, BlockWAL, []state.Block)) {
 	log := testlogger.NewLogger(t)
 	defer log.Sync()
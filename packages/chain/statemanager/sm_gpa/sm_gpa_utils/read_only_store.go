@@ -5,6 +5,7 @@ import (
 	"io"
 	"time"
 
+	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/trie"
 )
@@ -79,10 +80,26 @@ func (ros *readOnlyStore) Prune(trie.Hash) (trie.PruneStats, error) {
 	panic("Cannot prune read-only store")
 }
 
+func (ros *readOnlyStore) SchedulePrune(trie.Hash) error {
+	return fmt.Errorf("cannot prune read-only store")
+}
+
+func (ros *readOnlyStore) PruneBatch(int) (trie.PruneStats, error) {
+	return trie.PruneStats{}, fmt.Errorf("cannot prune read-only store")
+}
+
+func (ros *readOnlyStore) TriePruningBacklog() uint64 {
+	return ros.store.TriePruningBacklog()
+}
+
 func (ros *readOnlyStore) LargestPrunedBlockIndex() (uint32, error) {
 	return ros.store.LargestPrunedBlockIndex()
 }
 
+func (ros *readOnlyStore) DiffStates(oldRoot, newRoot trie.Hash, prefix, fromKey kv.Key, limit int) ([]*trie.KeyDiff, kv.Key, error) {
+	return ros.store.DiffStates(oldRoot, newRoot, prefix, fromKey, limit)
+}
+
 func (ros *readOnlyStore) TakeSnapshot(trieRoot trie.Hash, w io.Writer) error {
 	return ros.store.TakeSnapshot(trieRoot, w)
 }
@@ -628,6 +628,15 @@ func (smT *stateManagerGPA) pruneStore(commitment *state.L1Commitment) {
 	} else {
 		statesToKeep = smT.parameters.PruningMinStatesToKeep
 	}
+	// State `i` contains the blocklog receipts of blocks `i-blockKeepAmount+1`
+	// to `i`, so the oldest kept state must still contain the receipts of
+	// the oldest of the latest `PruningMinReceiptsToKeep` blocks.
+	if statesToKeepFromChain > 0 {
+		statesToKeepForReceipts := smT.parameters.PruningMinReceiptsToKeep - statesToKeepFromChain
+		if statesToKeepForReceipts > statesToKeep {
+			statesToKeep = statesToKeepForReceipts
+		}
+	}
 
 	// Skip last `statesToKeep` trie roots
 	bi := &blockInfo{
@@ -662,13 +671,13 @@ func (smT *stateManagerGPA) pruneStore(commitment *state.L1Commitment) {
 	for i := -1; i >= -bis.Length(); i-- {
 		singleStart := time.Now()
 		bi = bis.Get(i)
-		stats, err := smT.store.Prune(bi.trieRoot)
+		err := smT.store.SchedulePrune(bi.trieRoot)
 		if err != nil {
 			smT.log.Errorf("Failed to prune trie root %s: %v", bi.trieRoot, err)
 			return // Returning in order not to leave gaps of pruned trie roots in between not pruned ones
 		}
 		smT.metrics.StatePruned(time.Since(singleStart), bi.blockIndex)
-		smT.log.Debugf("Trie root %s pruned: %v nodes and %v values deleted", bi.trieRoot, stats.DeletedNodes, stats.DeletedValues)
+		smT.log.Debugf("Trie root %s pruned, its nodes will be deleted in the background", bi.trieRoot)
 	}
 	smT.metrics.PruningCompleted(time.Since(start), bis.Length())
 	smT.log.Debugf("Pruning completed, %v trie roots pruned", bis.Length())
@@ -383,6 +383,42 @@ func TestPruningSequentially(t *testing.T) {
 	}
 }
 
+// Single node setting, the chain keeps receipts of 5 blocks, pruning leaves
+// 5 historic blocks, but receipts of 12 blocks must be available.
+//   - 20 blocks are added into the store one by one; each time it is checked if
+//     only the newest block and 7 others are still in store, as the oldest of
+//     them contains the receipts of the 12th newest block.
+func TestPruningKeepsReceipts(t *testing.T) {
+	blocksToKeep := 5
+	receiptsToKeep := 12
+	blockCount := 20
+
+	nodeIDs := gpa.MakeTestNodeIDs(1)
+	nodeID := nodeIDs[0]
+	smParameters := NewStateManagerParameters()
+	smParameters.PruningMinStatesToKeep = blocksToKeep // Also initializes chain with this value in governance contract
+	smParameters.PruningMinReceiptsToKeep = receiptsToKeep
+	env := newTestEnv(t, nodeIDs, sm_gpa_utils.NewEmptyTestBlockWAL, newEmptySnapshotManagerFun, smParameters)
+	defer env.finalize()
+
+	statesToKeep := receiptsToKeep - blocksToKeep
+	blocks := env.bf.GetBlocks(blockCount, 1)
+	for i := 0; i < blockCount; i++ {
+		lastExistingBlockIndex := i - statesToKeep
+		if lastExistingBlockIndex < 0 {
+			lastExistingBlockIndex = 0
+		}
+		env.sendBlocksToNode(nodeID, 0*time.Second, blocks[i])
+		require.True(env.t, env.ensureStoreContainsBlocksNoWait(nodeID, blocks[lastExistingBlockIndex:i+1]))
+		for j := 0; j < lastExistingBlockIndex; j++ {
+			env.doesNotContainBlock(nodeID, blocks[j])
+		}
+		for j := lastExistingBlockIndex; j <= i; j++ {
+			env.checkBlock(nodeID, blocks[j])
+		}
+	}
+}
+
 // Single node setting
 //   - pruning leaves 10000 historic blocks.
 //   - 20 blocks are committed, none of them are pruned.
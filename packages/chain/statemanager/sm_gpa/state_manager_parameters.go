@@ -24,8 +24,19 @@ type StateManagerParameters struct {
 	StateManagerTimerTickPeriod time.Duration
 	// This number of states will always be available in the database
 	PruningMinStatesToKeep int
+	// The blocklog receipts and events of this number of latest blocks must be
+	// available: the states needed to serve the ones, which are already pruned
+	// from the latest state by the chain's block keep amount, are kept as well;
+	// 0 means that no states are kept for that
+	PruningMinReceiptsToKeep int
 	// On single store pruning attempt at most this number of states will be deleted
 	PruningMaxStatesToDelete int
+	// The tries of the deleted states are pruned in the background in batches:
+	// at most this number of trie node references is released in one batch
+	PruningBatchSize int
+	// At most this number of trie node references is released per second by
+	// the background pruning; 0 means no limit
+	PruningMaxNodesPerSecond int
 
 	TimeProvider sm_gpa_utils.TimeProvider
 }
@@ -45,7 +56,10 @@ func NewStateManagerParameters(tpOpt ...sm_gpa_utils.TimeProvider) StateManagerP
 		StateManagerRequestCleaningPeriod: 1 * time.Second,
 		StateManagerTimerTickPeriod:       1 * time.Second,
 		PruningMinStatesToKeep:            10000,
+		PruningMinReceiptsToKeep:          0,
 		PruningMaxStatesToDelete:          1000,
+		PruningBatchSize:                  1000,
+		PruningMaxNodesPerSecond:          10000,
 		TimeProvider:                      tp,
 	}
 }
@@ -0,0 +1,434 @@
+package sm_snapshots
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+type mirrorDownloaderImpl struct {
+	ctx         context.Context
+	log         *logger.Logger
+	mirrors     []string
+	fileSize    uint64
+	chunkSize   uint64
+	manifest    *snapshotManifest
+	parallelism int
+}
+
+const (
+	constMirrorChunkSize        = uint64(8 * 1024 * 1024)  // 8Mb; used if mirrors do not provide a manifest
+	constMirrorMaxChunkSize     = uint64(64 * 1024 * 1024) // 64Mb; manifests with larger chunks are ignored, as each worker holds a chunk in memory
+	constMirrorParallelism      = 4
+	constMirrorRangeAttempts    = 3 // Number of attempts to resume a chunk from the same mirror
+	constMirrorDownloadRounds   = 3 // Number of attempts to download the failed chunks, while it makes progress
+	constProgressFileSuffix     = ".progress"
+	constManifestDownloadLength = 1024 * 1024 // Manifest of 1Tb snapshot in chunks of 16Mb is ~2Mb; limit the download anyway
+)
+
+// Downloads a file, which is available on several mirrors, to the local file.
+// If the mirrors support `Range` requests, the file is downloaded in chunks,
+// several of which are fetched in parallel, each from a different mirror. If
+// the connection breaks in the middle of the chunk, the rest of it is requested
+// again. If the chunk cannot be downloaded from one mirror, other mirrors are
+// tried. If the mirrors provide a manifest (see `snapshotManifest`), each chunk
+// is verified before it is written. The downloaded chunks are recorded in a
+// progress file, so that the download can be resumed after it was interrupted
+// (e.g. by restarting the node). If the mirrors do not support `Range` requests,
+// the file is downloaded from the mirrors one by one using `DownloadToFile`.
+func DownloadToFileFromMirrors(
+	ctx context.Context,
+	mirrors []string,
+	filePathLocal string,
+	fileType string,
+	timeout time.Duration,
+	log *logger.Logger,
+) error {
+	if len(mirrors) == 0 {
+		return errors.New("no mirrors to download from")
+	}
+	ctxWithTimeout, ctxWithTimeoutCancel := context.WithTimeout(ctx, timeout)
+	defer ctxWithTimeoutCancel()
+
+	md := &mirrorDownloaderImpl{
+		ctx:         ctxWithTimeout,
+		log:         log,
+		parallelism: constMirrorParallelism,
+	}
+	md.probeMirrors(mirrors)
+	if len(md.mirrors) == 0 {
+		log.Debugf("Downloading %s: no mirror supports downloading in chunks, downloading sequentially", filePathLocal)
+		addProgressReporter := func(r io.Reader, url string, length uint64) io.Reader {
+			return io.TeeReader(r, NewProgressReporter(log, fmt.Sprintf("Downloading %s from url %s", fileType, url), length))
+		}
+		var errs error
+		for _, mirror := range mirrors {
+			err := DownloadToFile(ctx, mirror, filePathLocal, timeout, addProgressReporter)
+			if err == nil {
+				return nil
+			}
+			errs = errors.Join(errs, err)
+		}
+		return errs
+	}
+	md.fetchManifest()
+	progressReporter := NewProgressReporter(log, fmt.Sprintf("Downloading %s from %v mirrors", fileType, len(md.mirrors)), md.fileSize)
+	return md.downloadToFile(filePathLocal, progressReporter)
+}
+
+// Only the mirrors, which support `Range` requests and have the same file
+// as the first such mirror, are used.
+func (md *mirrorDownloaderImpl) probeMirrors(mirrors []string) {
+	md.mirrors = make([]string, 0, len(mirrors))
+	for _, mirror := range mirrors {
+		fileSize, acceptRanges, err := md.head(mirror)
+		if err != nil {
+			md.log.Warnf("Mirror %s is not available: %v", mirror, err)
+			continue
+		}
+		if !acceptRanges {
+			md.log.Debugf("Mirror %s does not support downloading in chunks", mirror)
+			continue
+		}
+		if len(md.mirrors) > 0 && fileSize != md.fileSize {
+			md.log.Warnf("Mirror %s is ignored: file size %v differs from %v on %s", mirror, fileSize, md.fileSize, md.mirrors[0])
+			continue
+		}
+		md.fileSize = fileSize
+		md.mirrors = append(md.mirrors, mirror)
+	}
+}
+
+func (md *mirrorDownloaderImpl) head(url string) (uint64, bool, error) {
+	request, err := http.NewRequestWithContext(md.ctx, http.MethodHead, url, http.NoBody)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to make head request to %s: %w", url, err)
+	}
+	head, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to receive header for url %s: %w", url, err)
+	}
+	defer head.Body.Close()
+	if head.StatusCode != http.StatusOK {
+		return 0, false, fmt.Errorf("head request to %s got status code %v", url, head.StatusCode)
+	}
+	fileSizeStr := head.Header.Get("Content-Length")
+	fileSize, err := strconv.ParseUint(fileSizeStr, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to convert file length %v to integer: %w", fileSizeStr, err)
+	}
+	acceptRanges := strings.ToLower(head.Header.Get("Accept-Ranges"))
+	return fileSize, acceptRanges != "" && acceptRanges != "none", nil
+}
+
+func (md *mirrorDownloaderImpl) fetchManifest() {
+	md.chunkSize = constMirrorChunkSize
+	for _, mirror := range md.mirrors {
+		manifest, err := md.getManifest(mirror + constManifestFileSuffix)
+		if err != nil {
+			md.log.Debugf("Manifest is not available on mirror %s: %v", mirror, err)
+			continue
+		}
+		if manifest.fileSize != md.fileSize {
+			md.log.Warnf("Manifest on mirror %s is ignored: it describes file of %v bytes instead of %v", mirror, manifest.fileSize, md.fileSize)
+			continue
+		}
+		if manifest.chunkSize == 0 || manifest.chunkSize > constMirrorMaxChunkSize {
+			md.log.Warnf("Manifest on mirror %s is ignored: chunk size %v is not in range 1-%v", mirror, manifest.chunkSize, constMirrorMaxChunkSize)
+			continue
+		}
+		md.manifest = manifest
+		md.chunkSize = manifest.chunkSize
+		return
+	}
+}
+
+func (md *mirrorDownloaderImpl) getManifest(url string) (*snapshotManifest, error) {
+	request, err := http.NewRequestWithContext(md.ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make get request to %s: %w", url, err)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("get request to %s got status code %v", url, response.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, constManifestDownloadLength))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	return snapshotManifestFromBytes(data)
+}
+
+func (md *mirrorDownloaderImpl) downloadToFile(
+	filePathLocal string,
+	progressReporter io.Writer,
+) error {
+	filePathTemp := filePathLocal + tempFileSuffixConst
+	filePathProgress := filePathTemp + constProgressFileSuffix
+	progress := md.readProgress(filePathTemp, filePathProgress)
+	f, err := os.OpenFile(filePathTemp, os.O_CREATE|os.O_WRONLY, 0o666)
+	if err != nil {
+		return fmt.Errorf("failed to open temporary file %s: %w", filePathTemp, err)
+	}
+	err = func() error { // Function is used to make deferred close occur before the file is renamed
+		defer f.Close()
+		downloaded := 0
+		var errs error
+		for round := 0; round < constMirrorDownloadRounds && !progress.isComplete(); round++ {
+			var roundDownloaded int
+			roundDownloaded, errs = md.downloadChunks(f, progress, filePathProgress, progressReporter)
+			downloaded += roundDownloaded
+			if roundDownloaded == 0 || md.ctx.Err() != nil {
+				break // No progress, do not try again
+			}
+		}
+		if !progress.isComplete() {
+			return fmt.Errorf("failed to download %s, it can be resumed: %w", filePathLocal, errors.Join(errs, md.ctx.Err()))
+		}
+		md.log.Debugf("Downloading %s: %v of %v chunks downloaded, the rest was downloaded before", filePathLocal, downloaded, progress.chunkCount())
+		return nil
+	}()
+	if err != nil {
+		return err
+	}
+	err = os.Rename(filePathTemp, filePathLocal)
+	if err != nil {
+		return fmt.Errorf("failed to move temporary file %s to permanent location %s: %v",
+			filePathTemp, filePathLocal, err)
+	}
+	if err = os.Remove(filePathProgress); err != nil && !os.IsNotExist(err) {
+		md.log.Warnf("Failed to remove download progress file %s: %v", filePathProgress, err)
+	}
+	return nil
+}
+
+// Downloads the chunks, which are not yet downloaded, in parallel. The chunk
+// is marked as done in the progress file only after it is synced to disk.
+func (md *mirrorDownloaderImpl) downloadChunks(
+	f *os.File,
+	progress *downloadProgress,
+	filePathProgress string,
+	progressReporter io.Writer,
+) (int, error) {
+	chunks := make(chan int)
+	results := make(chan *chunkResult)
+	var wg sync.WaitGroup
+	for i := 0; i < md.parallelism; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for index := range chunks {
+				data, err := md.downloadChunk(f, worker, index)
+				results <- &chunkResult{index: index, data: data, err: err}
+			}
+		}(i)
+	}
+	go func() {
+		defer close(chunks)
+		for index := 0; index < progress.chunkCount(); index++ {
+			if progress.done[index] {
+				continue
+			}
+			select {
+			case chunks <- index:
+			case <-md.ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var errs error
+	downloaded := 0
+	for result := range results {
+		if result.err != nil {
+			errs = errors.Join(errs, result.err)
+			continue
+		}
+		if err := f.Sync(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to sync chunk %v: %w", result.index, err))
+			continue
+		}
+		progress.done[result.index] = true
+		if err := os.WriteFile(filePathProgress, progress.Bytes(), 0o666); err != nil {
+			md.log.Warnf("Failed to save download progress to %s: %v", filePathProgress, err)
+		}
+		_, _ = progressReporter.Write(result.data)
+		downloaded++
+	}
+	return downloaded, errs
+}
+
+type chunkResult struct {
+	index int
+	data  []byte
+	err   error
+}
+
+// Each worker starts with a different mirror to spread the load; on failure
+// the next mirror is tried.
+func (md *mirrorDownloaderImpl) downloadChunk(f *os.File, worker int, index int) ([]byte, error) {
+	start := uint64(index) * md.chunkSize
+	end := start + md.chunkSize
+	if end > md.fileSize {
+		end = md.fileSize
+	}
+	var errs error
+	for i := range md.mirrors {
+		mirror := md.mirrors[(worker+index+i)%len(md.mirrors)]
+		data, err := md.fetchRange(mirror, start, end)
+		if err == nil && md.manifest != nil {
+			err = md.manifest.verifyChunk(index, data)
+		}
+		if err != nil {
+			md.log.Debugf("Failed to download chunk %v from mirror %s: %v", index, mirror, err)
+			errs = errors.Join(errs, fmt.Errorf("mirror %s: %w", mirror, err))
+			if md.ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		if _, err = f.WriteAt(data, int64(start)); err != nil {
+			return nil, fmt.Errorf("failed to write chunk %v: %w", index, err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("failed to download chunk %v (bytes %v to %v): %w", index, start, end, errs)
+}
+
+// Fetches bytes [start, end) from the mirror. If the response breaks before
+// all the bytes are received, the rest of the range is requested again.
+func (md *mirrorDownloaderImpl) fetchRange(url string, start, end uint64) ([]byte, error) {
+	buf := bytes.NewBuffer(make([]byte, 0, end-start))
+	var err error
+	for attempt := 0; attempt < constMirrorRangeAttempts && uint64(buf.Len()) < end-start; attempt++ {
+		from := start + uint64(buf.Len())
+		err = md.fetchRangeTo(buf, url, from, end)
+		if md.ctx.Err() != nil {
+			return nil, md.ctx.Err()
+		}
+	}
+	if uint64(buf.Len()) != end-start {
+		return nil, fmt.Errorf("received %v of %v bytes: %w", buf.Len(), end-start, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func (md *mirrorDownloaderImpl) fetchRangeTo(w io.Writer, url string, start, end uint64) error {
+	request, err := http.NewRequestWithContext(md.ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("failed to make get request to %s: %w", url, err)
+	}
+	request.Header.Add("Range", "bytes="+strconv.FormatUint(start, 10)+"-"+strconv.FormatUint(end-1, 10))
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to get bytes %v to %v from %s: %w", start, end, url, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusPartialContent {
+		return fmt.Errorf("get bytes %v to %v request to %s got status code %v", start, end, url, response.StatusCode)
+	}
+	_, err = io.Copy(w, io.LimitReader(response.Body, int64(end-start)))
+	return err
+}
+
+// Progress is only reused, if it was made for the same file and chunks and
+// the temporary file still exists.
+func (md *mirrorDownloaderImpl) readProgress(filePathTemp, filePathProgress string) *downloadProgress {
+	newProgress := newDownloadProgress(md.fileSize, md.chunkSize)
+	data, err := os.ReadFile(filePathProgress)
+	if err != nil {
+		return newProgress
+	}
+	if _, err = os.Stat(filePathTemp); err != nil {
+		return newProgress
+	}
+	progress, err := rwutil.ReadFromBytes(data, new(downloadProgress))
+	if err != nil {
+		md.log.Warnf("Failed to parse download progress file %s, download is restarted: %v", filePathProgress, err)
+		return newProgress
+	}
+	if progress.fileSize != md.fileSize || progress.chunkSize != md.chunkSize {
+		md.log.Debugf("Download progress file %s is for different file, download is restarted", filePathProgress)
+		return newProgress
+	}
+	return progress
+}
+
+type downloadProgress struct {
+	fileSize  uint64
+	chunkSize uint64
+	done      []bool
+}
+
+func newDownloadProgress(fileSize, chunkSize uint64) *downloadProgress {
+	return &downloadProgress{
+		fileSize:  fileSize,
+		chunkSize: chunkSize,
+		done:      make([]bool, chunkCount(fileSize, chunkSize)),
+	}
+}
+
+func (dp *downloadProgress) chunkCount() int {
+	return len(dp.done)
+}
+
+func (dp *downloadProgress) isComplete() bool {
+	for _, done := range dp.done {
+		if !done {
+			return false
+		}
+	}
+	return true
+}
+
+func (dp *downloadProgress) Bytes() []byte {
+	return rwutil.WriteToBytes(dp)
+}
+
+func (dp *downloadProgress) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	dp.fileSize = rr.ReadUint64()
+	dp.chunkSize = rr.ReadUint64()
+	if rr.Err == nil && dp.chunkSize == 0 {
+		return fmt.Errorf("invalid chunk size 0")
+	}
+	size := rr.ReadSize32()
+	if rr.Err == nil && size != chunkCount(dp.fileSize, dp.chunkSize) {
+		return fmt.Errorf("progress of %v bytes in chunks of %v bytes contains %v chunks", dp.fileSize, dp.chunkSize, size)
+	}
+	dp.done = make([]bool, size)
+	for i := range dp.done {
+		dp.done[i] = rr.ReadBool()
+	}
+	return rr.Err
+}
+
+func (dp *downloadProgress) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteUint64(dp.fileSize)
+	ww.WriteUint64(dp.chunkSize)
+	ww.WriteSize32(len(dp.done))
+	for _, done := range dp.done {
+		ww.WriteBool(done)
+	}
+	return ww.Err
+}
@@ -0,0 +1,227 @@
+package sm_snapshots
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+)
+
+const mirrorTestChunkSize = 10
+
+type testMirror struct {
+	*httptest.Server
+	mutex         sync.Mutex
+	data          []byte
+	manifest      []byte
+	rangeRequests []string
+	breakAfter    int  // If positive, the connection is broken after that many bytes of the first response to each range
+	noRanges      bool // If true, the mirror pretends to not support `Range` header
+	broken        map[string]bool
+}
+
+func newTestMirror(t *testing.T, data []byte, manifest []byte) *testMirror {
+	m := &testMirror{data: data, manifest: manifest, broken: map[string]bool{}}
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serve))
+	t.Cleanup(m.Close)
+	return m
+}
+
+func (m *testMirror) url() string {
+	return m.URL + "/file.bin"
+}
+
+func (m *testMirror) serve(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/file.bin.chunks":
+		if m.manifest == nil {
+			http.NotFound(w, r)
+			return
+		}
+		http.ServeContent(w, r, "file.bin.chunks", time.Time{}, bytes.NewReader(m.manifest))
+	case "/file.bin":
+		if m.noRanges {
+			w.Header().Set("Content-Length", strconv.Itoa(len(m.data)))
+			w.WriteHeader(http.StatusOK)
+			if r.Method != http.MethodHead {
+				_, _ = w.Write(m.data)
+			}
+			return
+		}
+		rangeHeader := r.Header.Get("Range")
+		if rangeHeader == "" {
+			http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(m.data))
+			return
+		}
+		m.mutex.Lock()
+		m.rangeRequests = append(m.rangeRequests, rangeHeader)
+		breakNow := m.breakAfter > 0 && !m.broken[rangeHeader]
+		m.broken[rangeHeader] = true
+		m.mutex.Unlock()
+		if breakNow {
+			w = &breakingResponseWriter{ResponseWriter: w, left: m.breakAfter}
+		}
+		http.ServeContent(w, r, "file.bin", time.Time{}, bytes.NewReader(m.data))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (m *testMirror) getRangeRequests() []string {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return append([]string{}, m.rangeRequests...)
+}
+
+// Aborts the response after the given number of bytes of the body.
+type breakingResponseWriter struct {
+	http.ResponseWriter
+	left int
+}
+
+func (w *breakingResponseWriter) Write(b []byte) (int, error) {
+	if len(b) > w.left {
+		_, _ = w.ResponseWriter.Write(b[:w.left])
+		w.ResponseWriter.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	w.left -= len(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func testMirrorData(size int) ([]byte, []byte) {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i*7 + 3)
+	}
+	mw := newManifestWriter(mirrorTestChunkSize)
+	_, _ = mw.Write(data)
+	return data, mw.manifest().Bytes()
+}
+
+func downloadFromTestMirrors(t *testing.T, dir string, mirrors ...*testMirror) ([]byte, error) {
+	log := testlogger.NewLogger(t)
+	urls := make([]string, len(mirrors))
+	for i := range mirrors {
+		urls[i] = mirrors[i].url()
+	}
+	filePath := filepath.Join(dir, "file.bin")
+	err := DownloadToFileFromMirrors(context.Background(), urls, filePath, "test file", time.Minute, log)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filePath)
+}
+
+func TestSnapshotManifest(t *testing.T) {
+	data, manifestBytes := testMirrorData(95)
+	manifest, err := snapshotManifestFromBytes(manifestBytes)
+	require.NoError(t, err)
+	require.EqualValues(t, 95, manifest.fileSize)
+	require.EqualValues(t, mirrorTestChunkSize, manifest.chunkSize)
+	require.Equal(t, 10, manifest.chunkCount())
+	require.NoError(t, manifest.verifyChunk(9, data[90:]))
+	require.Error(t, manifest.verifyChunk(8, data[90:]))
+	require.Error(t, manifest.verifyChunk(10, data[90:]))
+}
+
+func TestDownloadFromMirrors(t *testing.T) {
+	data, manifest := testMirrorData(1000)
+	mirror1 := newTestMirror(t, data, manifest)
+	mirror2 := newTestMirror(t, data, nil)
+	result, err := downloadFromTestMirrors(t, t.TempDir(), mirror1, mirror2)
+	require.NoError(t, err)
+	require.Equal(t, data, result)
+	require.NotEmpty(t, mirror1.getRangeRequests())
+	require.NotEmpty(t, mirror2.getRangeRequests())
+	require.Len(t, append(mirror1.getRangeRequests(), mirror2.getRangeRequests()...), 100)
+}
+
+func TestDownloadFromMirrorsCorrupted(t *testing.T) {
+	data, manifest := testMirrorData(1000)
+	corruptedData := bytes.Clone(data)
+	corruptedData[55] ^= 0xFF
+	mirror1 := newTestMirror(t, data, manifest)
+	mirror2 := newTestMirror(t, corruptedData, nil)
+	result, err := downloadFromTestMirrors(t, t.TempDir(), mirror1, mirror2)
+	require.NoError(t, err)
+	require.Equal(t, data, result)
+
+	// No mirror has a valid chunk
+	mirror3 := newTestMirror(t, corruptedData, manifest)
+	dir := t.TempDir()
+	_, err = downloadFromTestMirrors(t, dir, mirror3)
+	require.Error(t, err)
+	// Other chunks are downloaded and can be resumed from another mirror
+	mirror4 := newTestMirror(t, data, manifest)
+	result, err = downloadFromTestMirrors(t, dir, mirror4)
+	require.NoError(t, err)
+	require.Equal(t, data, result)
+	require.Equal(t, []string{"bytes=50-59"}, mirror4.getRangeRequests())
+	_, err = os.Stat(filepath.Join(dir, "file.bin"+tempFileSuffixConst+constProgressFileSuffix))
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestDownloadFromMirrorsChunkSizeTooLarge(t *testing.T) {
+	data, _ := testMirrorData(1000)
+	manifest := newSnapshotManifest(1000, constMirrorMaxChunkSize+1, []hashing.HashValue{hashing.HashDataBlake2b(data)})
+	mirror := newTestMirror(t, data, manifest.Bytes())
+	md := &mirrorDownloaderImpl{
+		ctx:      context.Background(),
+		log:      testlogger.NewLogger(t),
+		mirrors:  []string{mirror.url()},
+		fileSize: uint64(len(data)),
+	}
+	md.fetchManifest()
+	// The manifest is ignored and the default chunk size is used
+	require.Nil(t, md.manifest)
+	require.Equal(t, constMirrorChunkSize, md.chunkSize)
+
+	result, err := downloadFromTestMirrors(t, t.TempDir(), mirror)
+	require.NoError(t, err)
+	require.Equal(t, data, result)
+}
+
+func TestDownloadFromMirrorsRangeResume(t *testing.T) {
+	data, manifest := testMirrorData(100)
+	mirror := newTestMirror(t, data, manifest)
+	mirror.breakAfter = 4
+	result, err := downloadFromTestMirrors(t, t.TempDir(), mirror)
+	require.NoError(t, err)
+	require.Equal(t, data, result)
+	requests := mirror.getRangeRequests()
+	require.Contains(t, requests, "bytes=0-9")
+	require.Contains(t, requests, "bytes=4-9")
+}
+
+func TestDownloadFromMirrorsNoRanges(t *testing.T) {
+	data, _ := testMirrorData(100)
+	mirror1 := newTestMirror(t, data, nil)
+	mirror1.noRanges = true
+	result, err := downloadFromTestMirrors(t, t.TempDir(), mirror1)
+	require.NoError(t, err)
+	require.Equal(t, data, result)
+}
+
+func TestGroupSnapshotMirrors(t *testing.T) {
+	info1 := NewSnapshotInfo(1, state.PseudoRandL1Commitment())
+	info2 := NewSnapshotInfo(2, state.PseudoRandL1Commitment())
+	infos, paths := groupSnapshotMirrors(
+		[]SnapshotInfo{info1, info1, info2, info1},
+		[]string{constLocalAddress + "a", "http://m1/a", "http://m1/b", "http://m2/a"},
+	)
+	require.Equal(t, []SnapshotInfo{info1, info1, info2}, infos)
+	require.Equal(t, [][]string{{constLocalAddress + "a"}, {"http://m1/a", "http://m2/a"}, {"http://m1/b"}}, paths)
+}
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/iotaledger/hive.go/logger"
@@ -40,6 +41,7 @@ var (
 
 const (
 	constDownloadTimeout                     = 10 * time.Minute
+	constSnapshotDownloadTimeout             = 12 * time.Hour
 	constSnapshotIndexHashFileNameSepparator = "-"
 	constSnapshotFileSuffix                  = ".snap"
 	constSnapshotTmpFileSuffix               = ".tmp"
@@ -123,7 +125,8 @@ func (smiT *snapshotManagerImpl) createSnapshot(snapshotInfo SnapshotInfo) {
 		defer f.Close()
 
 		smiT.log.Debugf("Creating snapshot %v %s: storing it to file", stateIndex, commitment)
-		err := smiT.snapshotter.storeSnapshot(snapshotInfo, f)
+		manifestWriter := newManifestWriter(constManifestChunkSize)
+		err := smiT.snapshotter.storeSnapshot(snapshotInfo, io.MultiWriter(f, manifestWriter))
 		if err != nil {
 			smiT.log.Errorf("Creating snapshot %v %s: failed to write snapshot to temporary file %s: %v", stateIndex, commitment, tmpFilePath, err)
 			return
@@ -137,6 +140,11 @@ func (smiT *snapshotManagerImpl) createSnapshot(snapshotInfo SnapshotInfo) {
 				stateIndex, commitment, tmpFilePath, finalFilePath, err)
 			return
 		}
+		manifestFilePath := finalFilePath + constManifestFileSuffix
+		err = os.WriteFile(manifestFilePath, manifestWriter.manifest().Bytes(), 0o666)
+		if err != nil {
+			smiT.log.Warnf("Creating snapshot %v %s: failed to write snapshot manifest file %s: %v", stateIndex, commitment, manifestFilePath, err)
+		}
 		smiT.snapshotManagerRunner.snapshotCreated(snapshotInfo)
 		smiT.log.Infof("Creating snapshot %v %s: snapshot created in %s", stateIndex, commitment, finalFilePath)
 		smiT.metrics.SnapshotCreated(time.Since(start), stateIndex)
@@ -188,15 +196,16 @@ func (smiT *snapshotManagerImpl) loadSnapshot() SnapshotInfo {
 
 	smiT.searchLocalSnapshots(considerSnapshotFun)
 	smiT.searchNetworkSnapshots(smiT.baseNetworkPaths, considerSnapshotFun)
-	smiT.log.Debugf("%v snapshots with %s will be considered for loading in this order: %v", len(snapshotPaths), searchCondition, snapshotPaths)
+	snapshotInfos, snapshotMirrors := groupSnapshotMirrors(snapshotInfos, snapshotPaths)
+	smiT.log.Debugf("%v snapshots with %s will be considered for loading in this order: %v", len(snapshotMirrors), searchCondition, snapshotMirrors)
 
-	for i := range snapshotPaths {
-		err := smiT.loadSnapshotFromPath(snapshotInfos[i], snapshotPaths[i])
+	for i := range snapshotMirrors {
+		err := smiT.loadSnapshotFromPaths(snapshotInfos[i], snapshotMirrors[i])
 		if err == nil {
-			smiT.log.Infof("Snapshot %s successfully loaded from %s", snapshotInfos[i], snapshotPaths[i])
+			smiT.log.Infof("Snapshot %s successfully loaded from %s", snapshotInfos[i], snapshotMirrors[i])
 			return snapshotInfos[i]
 		}
-		smiT.log.Errorf("Failed to load snapshot %s from %s: %v", snapshotInfos[i], snapshotPaths[i], err)
+		smiT.log.Errorf("Failed to load snapshot %s from %s: %v", snapshotInfos[i], snapshotMirrors[i], err)
 	}
 	smiT.log.Warnf("Failed to load any snapshot; will continue with empty store")
 	return nil
@@ -312,7 +321,9 @@ func (smiT *snapshotManagerImpl) searchNetworkSnapshots(baseNetworkPaths []strin
 	}
 }
 
-func (smiT *snapshotManagerImpl) loadSnapshotFromPath(snapshotInfo SnapshotInfo, url string) error {
+// The same snapshot found on several network locations is downloaded from all
+// of them at once; see `DownloadToFileFromMirrors`.
+func (smiT *snapshotManagerImpl) loadSnapshotFromPaths(snapshotInfo SnapshotInfo, urls []string) error {
 	loadSnapshotFun := func(r io.Reader) error {
 		err := smiT.snapshotter.loadSnapshot(snapshotInfo, r)
 		if err != nil {
@@ -328,33 +339,33 @@ func (smiT *snapshotManagerImpl) loadSnapshotFromPath(snapshotInfo SnapshotInfo,
 		defer f.Close()
 		return loadSnapshotFun(f)
 	}
-	loadNetworkFun := func(url string) error {
+	loadNetworkFun := func(urls []string) error {
 		fileNameLocal := downloadedSnapshotFileName(snapshotInfo.StateIndex(), snapshotInfo.BlockHash())
 		filePathLocal := filepath.Join(smiT.localPath, fileNameLocal)
-		addProgressReporterFun := func(r io.Reader, f string, s uint64) io.Reader {
-			return smiT.addProgressReporter(r, fmt.Sprintf("snapshot %s", snapshotInfo), f, s)
-		}
-		err := DownloadToFile(smiT.ctx, url, filePathLocal, constDownloadTimeout, addProgressReporterFun)
+		err := DownloadToFileFromMirrors(smiT.ctx, urls, filePathLocal, fmt.Sprintf("snapshot %s", snapshotInfo), constSnapshotDownloadTimeout, smiT.log)
 		if err != nil {
 			return err
 		}
-		smiT.log.Debugf("Loading snapshot %s from url %s: snapshot successfully downloaded to %s", snapshotInfo, url, filePathLocal)
+		smiT.log.Debugf("Loading snapshot %s from urls %v: snapshot successfully downloaded to %s", snapshotInfo, urls, filePathLocal)
 		return loadLocalFun(filePathLocal)
 	}
 
-	scheme, path, err := smiT.splitURL(url)
+	if len(urls) == 0 {
+		return fmt.Errorf("Loading snapshot %s failed: no location", snapshotInfo)
+	}
+	scheme, path, err := smiT.splitURL(urls[0])
 	if err != nil {
 		return fmt.Errorf("Loading snapshot %s failed: %v", snapshotInfo, err)
 	}
 	switch scheme {
 	case constSchemeHTTP:
-		smiT.log.Debugf("Loading snapshot %s from url %s...", snapshotInfo, path)
-		return loadNetworkFun(path)
+		smiT.log.Debugf("Loading snapshot %s from urls %v...", snapshotInfo, urls)
+		return loadNetworkFun(urls)
 	case constSchemeFile:
 		smiT.log.Debugf("Loading snapshot %s from file %s...", snapshotInfo, path)
 		return loadLocalFun(path)
 	default:
-		return fmt.Errorf("Loading snapshot %s failed: unknown scheme %s in %s", snapshotInfo, scheme, url)
+		return fmt.Errorf("Loading snapshot %s failed: unknown scheme %s in %s", snapshotInfo, scheme, urls[0])
 	}
 }
 
@@ -403,6 +414,33 @@ func (smiT *snapshotManagerImpl) addProgressReporter(r io.Reader, fileType strin
 	return io.TeeReader(r, progressReporter)
 }
 
+// Network locations of the same snapshot are merged into a single entry, which
+// is placed where the first of them was. Local snapshots are kept separate.
+func groupSnapshotMirrors(snapshotInfos []SnapshotInfo, paths []string) ([]SnapshotInfo, [][]string) {
+	resultInfos := make([]SnapshotInfo, 0, len(snapshotInfos))
+	resultPaths := make([][]string, 0, len(paths))
+	isNetworkPath := func(path string) bool {
+		return !strings.HasPrefix(path, constLocalAddress)
+	}
+	for i := range snapshotInfos {
+		merged := false
+		if isNetworkPath(paths[i]) {
+			for j := range resultInfos {
+				if isNetworkPath(resultPaths[j][0]) && resultInfos[j].Equals(snapshotInfos[i]) {
+					resultPaths[j] = append(resultPaths[j], paths[i])
+					merged = true
+					break
+				}
+			}
+		}
+		if !merged {
+			resultInfos = append(resultInfos, snapshotInfos[i])
+			resultPaths = append(resultPaths, []string{paths[i]})
+		}
+	}
+	return resultInfos, resultPaths
+}
+
 func tempSnapshotFileName(index uint32, blockHash state.BlockHash) string {
 	return tempSnapshotFileNameString(fmt.Sprint(index), blockHash.String())
 }
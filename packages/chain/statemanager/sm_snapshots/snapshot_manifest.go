@@ -0,0 +1,133 @@
+package sm_snapshots
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// Snapshot manifest describes the chunks of a snapshot file. It is stored next
+// to the snapshot file (file name with `constManifestFileSuffix` appended) and
+// allows to verify each chunk of the snapshot file separately while it is being
+// downloaded. Mirrors are not required to provide a manifest; without it, the
+// downloaded snapshot is only verified when it is loaded.
+type snapshotManifest struct {
+	fileSize  uint64
+	chunkSize uint64
+	hashes    []hashing.HashValue
+}
+
+const (
+	constManifestFileSuffix = ".chunks"
+	constManifestChunkSize  = uint64(16 * 1024 * 1024) // 16Mb
+)
+
+func newSnapshotManifest(fileSize, chunkSize uint64, hashes []hashing.HashValue) *snapshotManifest {
+	return &snapshotManifest{
+		fileSize:  fileSize,
+		chunkSize: chunkSize,
+		hashes:    hashes,
+	}
+}
+
+func snapshotManifestFromBytes(data []byte) (*snapshotManifest, error) {
+	return rwutil.ReadFromBytes(data, new(snapshotManifest))
+}
+
+func (sm *snapshotManifest) Bytes() []byte {
+	return rwutil.WriteToBytes(sm)
+}
+
+func (sm *snapshotManifest) chunkCount() int {
+	return chunkCount(sm.fileSize, sm.chunkSize)
+}
+
+func (sm *snapshotManifest) verifyChunk(index int, data []byte) error {
+	if index >= len(sm.hashes) {
+		return fmt.Errorf("chunk %v is out of range, manifest contains %v chunks", index, len(sm.hashes))
+	}
+	hash := hashing.HashDataBlake2b(data)
+	if hash != sm.hashes[index] {
+		return fmt.Errorf("chunk %v hash mismatch: expected %s, got %s", index, sm.hashes[index], hash)
+	}
+	return nil
+}
+
+func (sm *snapshotManifest) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	sm.fileSize = rr.ReadUint64()
+	sm.chunkSize = rr.ReadUint64()
+	if rr.Err == nil && sm.chunkSize == 0 {
+		return fmt.Errorf("invalid manifest chunk size 0")
+	}
+	size := rr.ReadSize32()
+	if rr.Err == nil && size != chunkCount(sm.fileSize, sm.chunkSize) {
+		return fmt.Errorf("manifest of %v bytes in chunks of %v bytes contains %v hashes", sm.fileSize, sm.chunkSize, size)
+	}
+	sm.hashes = make([]hashing.HashValue, size)
+	for i := range sm.hashes {
+		rr.Read(&sm.hashes[i])
+	}
+	return rr.Err
+}
+
+func (sm *snapshotManifest) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteUint64(sm.fileSize)
+	ww.WriteUint64(sm.chunkSize)
+	ww.WriteSize32(len(sm.hashes))
+	for i := range sm.hashes {
+		ww.Write(&sm.hashes[i])
+	}
+	return ww.Err
+}
+
+// manifestWriter computes the manifest of the data written through it.
+type manifestWriter struct {
+	chunkSize uint64
+	fileSize  uint64
+	chunk     []byte
+	hashes    []hashing.HashValue
+}
+
+var _ io.Writer = &manifestWriter{}
+
+func newManifestWriter(chunkSize uint64) *manifestWriter {
+	return &manifestWriter{
+		chunkSize: chunkSize,
+		chunk:     make([]byte, 0, chunkSize),
+		hashes:    make([]hashing.HashValue, 0),
+	}
+}
+
+func (mw *manifestWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		missing := int(mw.chunkSize) - len(mw.chunk)
+		if missing > len(p) {
+			missing = len(p)
+		}
+		mw.chunk = append(mw.chunk, p[:missing]...)
+		p = p[missing:]
+		if uint64(len(mw.chunk)) == mw.chunkSize {
+			mw.hashes = append(mw.hashes, hashing.HashDataBlake2b(mw.chunk))
+			mw.chunk = mw.chunk[:0]
+		}
+	}
+	mw.fileSize += uint64(n)
+	return n, nil
+}
+
+func (mw *manifestWriter) manifest() *snapshotManifest {
+	hashes := mw.hashes
+	if len(mw.chunk) > 0 {
+		hashes = append(hashes, hashing.HashDataBlake2b(mw.chunk))
+	}
+	return newSnapshotManifest(mw.fileSize, mw.chunkSize, hashes)
+}
+
+func chunkCount(fileSize, chunkSize uint64) int {
+	return int((fileSize + chunkSize - 1) / chunkSize)
+}
@@ -50,10 +50,72 @@ func (sn *snapshotterImpl) loadSnapshot(snapshotInfo SnapshotInfo, r io.Reader)
 	if !readSnapshotInfo.Equals(snapshotInfo) {
 		return fmt.Errorf("snapshot read %s is different than expected %v", readSnapshotInfo, snapshotInfo)
 	}
-	err = sn.store.RestoreSnapshot(readSnapshotInfo.TrieRoot(), r)
+	return sn.restoreSnapshot(snapshotInfo, r)
+}
+
+// restoreSnapshot restores the snapshot into the store. The store checks that
+// the trie is complete and deletes the restored nodes if it is not. If the
+// restored state doesn't match the snapshot information, it is deleted too.
+func (sn *snapshotterImpl) restoreSnapshot(snapshotInfo SnapshotInfo, r io.Reader) error {
+	restored := !sn.store.HasTrieRoot(snapshotInfo.TrieRoot())
+	err := sn.store.RestoreSnapshot(snapshotInfo.TrieRoot(), r)
 	if err != nil {
 		return fmt.Errorf("failed restoring snapshot: %w", err)
 	}
+	err = sn.verifySnapshot(snapshotInfo)
+	if err != nil {
+		if restored && sn.store.HasTrieRoot(snapshotInfo.TrieRoot()) {
+			if _, pruneErr := sn.store.Prune(snapshotInfo.TrieRoot()); pruneErr != nil {
+				return fmt.Errorf("restored snapshot is invalid: %w, failed deleting it: %v", err, pruneErr)
+			}
+		}
+		return fmt.Errorf("restored snapshot is invalid: %w", err)
+	}
+	return nil
+}
+
+// WriteSnapshot writes the snapshot of the given state of the store in the
+// same format as the snapshot manager does.
+func WriteSnapshot(store state.Store, snapshotInfo SnapshotInfo, w io.Writer) error {
+	return newSnapshotter(store).storeSnapshot(snapshotInfo, w)
+}
+
+// ReadSnapshot restores the state from the snapshot, written by the snapshot
+// manager (or WriteSnapshot), into the store and returns the information about
+// the snapshot. The restored block is not marked as the latest one.
+func ReadSnapshot(store state.Store, r io.Reader) (SnapshotInfo, error) {
+	snapshotInfo, err := readSnapshotInfo(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading snapshot info: %w", err)
+	}
+	sn := &snapshotterImpl{store: store}
+	if err := sn.restoreSnapshot(snapshotInfo, r); err != nil {
+		return nil, err
+	}
+	return snapshotInfo, nil
+}
+
+// Checks that the restored block and state match the L1 commitment of the
+// snapshot, so that the snapshot is not used if it is incomplete or forged.
+func (sn *snapshotterImpl) verifySnapshot(snapshotInfo SnapshotInfo) error {
+	trieRoot := snapshotInfo.TrieRoot()
+	if !sn.store.HasTrieRoot(trieRoot) {
+		return fmt.Errorf("trie root %s is not in the store", trieRoot)
+	}
+	block, err := sn.store.BlockByTrieRoot(trieRoot)
+	if err != nil {
+		return fmt.Errorf("failed to get block of trie root %s: %w", trieRoot, err)
+	}
+	if !block.L1Commitment().Equals(snapshotInfo.Commitment()) {
+		return fmt.Errorf("block commitment %s does not match snapshot commitment %s", block.L1Commitment(), snapshotInfo.Commitment())
+	}
+	chainState, err := sn.store.StateByTrieRoot(trieRoot)
+	if err != nil {
+		return fmt.Errorf("failed to get state of trie root %s: %w", trieRoot, err)
+	}
+	if chainState.BlockIndex() != snapshotInfo.StateIndex() {
+		return fmt.Errorf("state index %v does not match snapshot index %v", chainState.BlockIndex(), snapshotInfo.StateIndex())
+	}
 	return nil
 }
 
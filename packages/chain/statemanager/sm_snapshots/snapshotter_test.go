@@ -1,6 +1,7 @@
 package sm_snapshots
 
 import (
+	"bytes"
 	"os"
 	"testing"
 
@@ -46,3 +47,44 @@ func TestWriteReadDifferentStores(t *testing.T) {
 	sm_gpa_utils.CheckBlockInStore(t, store, lastBlock)
 	sm_gpa_utils.CheckStateInStores(t, factory.GetStore(), store, lastCommitment)
 }
+
+func TestVerifyRestoredSnapshot(t *testing.T) {
+	numberOfBlocks := 5
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(numberOfBlocks, 1)
+	lastBlock := blocks[numberOfBlocks-1]
+	sn := newSnapshotter(factory.GetStore()).(*snapshotterImpl)
+
+	require.NoError(t, sn.verifySnapshot(NewSnapshotInfo(lastBlock.StateIndex(), lastBlock.L1Commitment())))
+	require.Error(t, sn.verifySnapshot(NewSnapshotInfo(lastBlock.StateIndex()+1, lastBlock.L1Commitment())))
+	require.Error(t, sn.verifySnapshot(NewSnapshotInfo(lastBlock.StateIndex(), state.PseudoRandL1Commitment())))
+
+	emptySnapshotter := newSnapshotter(state.NewStoreWithUniqueWriteMutex(mapdb.NewMapDB())).(*snapshotterImpl)
+	require.Error(t, emptySnapshotter.verifySnapshot(NewSnapshotInfo(lastBlock.StateIndex(), lastBlock.L1Commitment())))
+}
+
+func TestRestoreTruncatedSnapshot(t *testing.T) {
+	numberOfBlocks := 5
+	factory := sm_gpa_utils.NewBlockFactory(t)
+	blocks := factory.GetBlocks(numberOfBlocks, 1)
+	lastBlock := blocks[numberOfBlocks-1]
+	snapshotInfo := NewSnapshotInfo(lastBlock.StateIndex(), lastBlock.L1Commitment())
+	var buf bytes.Buffer
+	require.NoError(t, newSnapshotter(factory.GetStore()).storeSnapshot(snapshotInfo, &buf))
+	snapshot := buf.Bytes()
+
+	// a truncated snapshot is rejected and nothing of it is left in the store
+	for length := 0; length < len(snapshot); length++ {
+		db := mapdb.NewMapDB()
+		sn := newSnapshotter(state.NewStoreWithUniqueWriteMutex(db))
+		require.Error(t, sn.loadSnapshot(snapshotInfo, bytes.NewReader(snapshot[:length])), "length %d", length)
+		require.NoError(t, db.Iterate(nil, func(key, value []byte) bool {
+			require.Failf(t, "key left in the store", "length %d, key %x", length, key)
+			return false
+		}))
+	}
+
+	db := mapdb.NewMapDB()
+	sn := newSnapshotter(state.NewStoreWithUniqueWriteMutex(db))
+	require.NoError(t, sn.loadSnapshot(snapshotInfo, bytes.NewReader(snapshot)))
+}
@@ -88,6 +88,7 @@ type stateManager struct {
 	preliminaryBlockPipe pipe.Pipe[*reqPreliminaryBlock]
 	snapshotManager      sm_snapshots.SnapshotManager
 	wal                  sm_gpa_utils.BlockWAL
+	store                state.Store
 	net                  peering.NetworkProvider
 	netPeeringID         peering.PeeringID
 	parameters           sm_gpa.StateManagerParameters
@@ -139,6 +140,7 @@ func New(
 		preliminaryBlockPipe: pipe.NewInfinitePipe[*reqPreliminaryBlock](),
 		snapshotManager:      snapshotManager,
 		wal:                  wal,
+		store:                store,
 		net:                  net,
 		netPeeringID:         peering.HashPeeringIDFromBytes(chainID.Bytes(), []byte("StateManager")), // ChainID × StateManager
 		parameters:           parameters,
@@ -172,6 +174,7 @@ func New(
 	}
 
 	go result.run()
+	go result.pruneTries()
 	return result, nil
 }
 
@@ -296,6 +299,45 @@ func (smT *stateManager) run() { //nolint:gocyclo
 	}
 }
 
+// Deletes the tries of the pruned states (see `state.Store.SchedulePrune`) in
+// the background. The store is only locked while a single batch is pruned, so
+// that the blocks can be committed in between. The number of the released trie
+// node references per second is limited by `PruningMaxNodesPerSecond`.
+func (smT *stateManager) pruneTries() {
+	for {
+		var wait time.Duration
+		backlog := smT.store.TriePruningBacklog()
+		if backlog == 0 {
+			wait = smT.parameters.StateManagerTimerTickPeriod
+		} else {
+			start := time.Now()
+			stats, err := smT.store.PruneBatch(smT.parameters.PruningBatchSize)
+			if err != nil {
+				smT.log.Errorf("Failed to prune trie nodes: %v", err)
+				wait = smT.parameters.StateManagerTimerTickPeriod
+			} else {
+				smT.log.Debugf("Trie nodes pruned: %v nodes and %v values deleted, %v references left to release",
+					stats.DeletedNodes, stats.DeletedValues, smT.store.TriePruningBacklog())
+				if smT.parameters.PruningMaxNodesPerSecond > 0 {
+					released := util.MinUint64(backlog, uint64(smT.parameters.PruningBatchSize))
+					wait = time.Duration(released)*time.Second/time.Duration(smT.parameters.PruningMaxNodesPerSecond) - time.Since(start)
+				}
+			}
+		}
+		if wait <= 0 {
+			if smT.ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+		select {
+		case <-smT.parameters.TimeProvider.After(wait):
+		case <-smT.ctx.Done():
+			return
+		}
+	}
+}
+
 func (smT *stateManager) handleInput(input gpa.Input) {
 	outMsgs := smT.stateManagerGPA.Input(input)
 	smT.sendMessages(outMsgs)
@@ -5,5 +5,7 @@ const (
 	PrefixTrie                    = 1
 	PrefixLatestTrieRoot          = 2
 	PrefixLargestPrunedBlockIndex = 3
+	PrefixMempoolOffLedgerJournal = 4
+	PrefixTriePruningQueue        = 5
 	PrefixHealthTracker           = 255
 )
@@ -17,6 +17,7 @@ import (
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chain/cmt_log"
+	"github.com/iotaledger/wasp/packages/chain/mempool"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
 	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
@@ -61,14 +62,17 @@ type Chains struct {
 	walLoadToStore                      bool
 	walEnabled                          bool
 	walFolderPath                       string
+	walSegmentedOptions                 *sm_gpa_utils.SegmentedBlockWALOptions
 	smBlockCacheMaxSize                 int
 	smBlockCacheBlocksInCacheDuration   time.Duration
 	smBlockCacheBlockCleaningPeriod     time.Duration
 	smStateManagerGetBlockRetry         time.Duration
 	smStateManagerRequestCleaningPeriod time.Duration
 	smStateManagerTimerTickPeriod       time.Duration
-	smPruningMinStatesToKeep            int
 	smPruningMaxStatesToDelete          int
+	smPruningBatchSize                  int
+	smPruningMaxNodesPerSecond          int
+	retention                           *Retention
 	defaultSnapshotToLoad               *state.BlockHash
 	snapshotsToLoad                     map[isc.ChainIDKey]state.BlockHash
 	snapshotPeriod                      uint32
@@ -117,14 +121,17 @@ func New(
 	walLoadToStore bool,
 	walEnabled bool,
 	walFolderPath string,
+	walSegmentedOptions *sm_gpa_utils.SegmentedBlockWALOptions, // nil, if the WAL of one file per block is used
 	smBlockCacheMaxSize int,
 	smBlockCacheBlocksInCacheDuration time.Duration,
 	smBlockCacheBlockCleaningPeriod time.Duration,
 	smStateManagerGetBlockRetry time.Duration,
 	smStateManagerRequestCleaningPeriod time.Duration,
 	smStateManagerTimerTickPeriod time.Duration,
-	smPruningMinStatesToKeep int,
 	smPruningMaxStatesToDelete int,
+	smPruningBatchSize int,
+	smPruningMaxNodesPerSecond int,
+	retention *Retention,
 	snapshotsToLoad []string,
 	snapshotPeriod uint32,
 	snapshotDelay uint32,
@@ -168,14 +175,17 @@ func New(
 		walLoadToStore:                      walLoadToStore,
 		walEnabled:                          walEnabled,
 		walFolderPath:                       walFolderPath,
+		walSegmentedOptions:                 walSegmentedOptions,
 		smBlockCacheMaxSize:                 smBlockCacheMaxSize,
 		smBlockCacheBlocksInCacheDuration:   smBlockCacheBlocksInCacheDuration,
 		smBlockCacheBlockCleaningPeriod:     smBlockCacheBlockCleaningPeriod,
 		smStateManagerGetBlockRetry:         smStateManagerGetBlockRetry,
 		smStateManagerRequestCleaningPeriod: smStateManagerRequestCleaningPeriod,
 		smStateManagerTimerTickPeriod:       smStateManagerTimerTickPeriod,
-		smPruningMinStatesToKeep:            smPruningMinStatesToKeep,
 		smPruningMaxStatesToDelete:          smPruningMaxStatesToDelete,
+		smPruningBatchSize:                  smPruningBatchSize,
+		smPruningMaxNodesPerSecond:          smPruningMaxNodesPerSecond,
+		retention:                           retention,
 		snapshotPeriod:                      snapshotPeriod,
 		snapshotDelay:                       snapshotDelay,
 		snapshotFolderPath:                  snapshotFolderPath,
@@ -338,12 +348,23 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 	// Initialize WAL
 	chainLog := c.log.Named(chainID.ShortString())
 	var chainWAL sm_gpa_utils.BlockWAL
-	if c.walEnabled {
-		chainWAL, err = sm_gpa_utils.NewBlockWAL(chainLog, c.walFolderPath, chainID, chainMetrics.BlockWAL)
+	switch {
+	case c.walEnabled && c.walSegmentedOptions != nil:
+		walOptions := *c.walSegmentedOptions
+		walOptions.Retention.Blocks = c.retention.Blocks
+		if _, err = sm_gpa_utils.MigrateBlockWAL(chainLog, c.walFolderPath, chainID, chainMetrics.BlockWAL, &walOptions); err != nil {
+			panic(fmt.Errorf("cannot migrate WAL to segments: %w", err))
+		}
+		chainWAL, err = sm_gpa_utils.NewSegmentedBlockWAL(chainLog, c.walFolderPath, chainID, chainMetrics.BlockWAL, &walOptions)
 		if err != nil {
 			panic(fmt.Errorf("cannot create WAL: %w", err))
 		}
-	} else {
+	case c.walEnabled:
+		chainWAL, err = sm_gpa_utils.NewPrunedBlockWAL(chainLog, c.walFolderPath, chainID, chainMetrics.BlockWAL, c.retention.Blocks)
+		if err != nil {
+			panic(fmt.Errorf("cannot create WAL: %w", err))
+		}
+	default:
 		chainWAL = sm_gpa_utils.NewEmptyBlockWAL()
 	}
 
@@ -354,8 +375,11 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 	stateManagerParameters.StateManagerGetBlockRetry = c.smStateManagerGetBlockRetry
 	stateManagerParameters.StateManagerRequestCleaningPeriod = c.smStateManagerRequestCleaningPeriod
 	stateManagerParameters.StateManagerTimerTickPeriod = c.smStateManagerTimerTickPeriod
-	stateManagerParameters.PruningMinStatesToKeep = c.smPruningMinStatesToKeep
+	stateManagerParameters.PruningMinStatesToKeep = int(c.retention.States)
+	stateManagerParameters.PruningMinReceiptsToKeep = int(c.retention.Receipts)
 	stateManagerParameters.PruningMaxStatesToDelete = c.smPruningMaxStatesToDelete
+	stateManagerParameters.PruningBatchSize = c.smPruningBatchSize
+	stateManagerParameters.PruningMaxNodesPerSecond = c.smPruningMaxNodesPerSecond
 
 	// Initialize Snapshotter
 	chainStore := indexedstore.New(state.NewStoreWithMetrics(chainKVStore, writeMutex, chainMetrics.State))
@@ -401,6 +425,7 @@ func (c *Chains) activateWithoutLocking(chainID isc.ChainID) error { //nolint:fu
 		c.consensusStateRegistry,
 		c.walLoadToStore,
 		chainWAL,
+		mempool.NewOffLedgerJournal(chainKVStore, chainLog.Named("MP")),
 		chainSnapshotManager,
 		c.chainListener,
 		chainRecord.AccessNodes,
@@ -475,6 +500,6 @@ func (c *Chains) ValidatorAddress() iotago.Address {
 	return c.validatorFeeAddr
 }
 
-func (c *Chains) IsArchiveNode() bool {
-	return c.smPruningMinStatesToKeep < 1
+func (c *Chains) Retention() *Retention {
+	return c.retention
 }
@@ -0,0 +1,110 @@
+package chains
+
+import (
+	"fmt"
+)
+
+type NodeMode string
+
+const (
+	// NodeModeArchive keeps the whole history of all the data classes.
+	NodeModeArchive NodeMode = "archive"
+	// NodeModeFull prunes the old trie states, but keeps all the blocks and,
+	// unless configured otherwise, the whole EVM JSON-RPC index.
+	NodeModeFull NodeMode = "full"
+	// NodeModePruned prunes the old data of all the data classes.
+	NodeModePruned NodeMode = "pruned"
+)
+
+// RetentionDefault in the retention configuration means that the value
+// should be derived from the node mode.
+const RetentionDefault = -1
+
+// Retention defines how many latest blocks the node keeps for each of the
+// data classes. 0 means that the whole history is kept.
+type Retention struct {
+	Mode NodeMode
+	// States is the number of the latest trie states kept in the store.
+	States uint32
+	// Blocks is the number of the latest blocks kept in the block WAL.
+	Blocks uint32
+	// Receipts is the number of the latest blocks, for which the blocklog
+	// receipts and events can be served. They are pruned from the chain state
+	// according to the chain's block keep amount, so the older ones can only
+	// be served from the older trie states, which are kept for that long.
+	Receipts uint32
+	// EVMIndex is the number of the latest blocks kept in the EVM JSON-RPC index.
+	EVMIndex uint32
+}
+
+// NewRetention validates the retention configuration. If the mode is empty,
+// it is derived from the retention values; the values equal to
+// RetentionDefault are derived from the mode.
+func NewRetention(mode string, states, blocks, receipts, evmIndex int) (*Retention, error) {
+	if states < 0 {
+		return nil, fmt.Errorf("invalid states retention %v", states)
+	}
+	for name, value := range map[string]int{"blocks": blocks, "receipts": receipts, "EVM index": evmIndex} {
+		if value < RetentionDefault {
+			return nil, fmt.Errorf("invalid %s retention %v", name, value)
+		}
+	}
+	nodeMode := NodeMode(mode)
+	if nodeMode == "" {
+		switch {
+		case states == 0:
+			nodeMode = NodeModeArchive
+		case blocks > 0:
+			nodeMode = NodeModePruned
+		default:
+			nodeMode = NodeModeFull
+		}
+	}
+	orDefault := func(value int, defaultValue uint32) uint32 {
+		if value == RetentionDefault {
+			return defaultValue
+		}
+		return uint32(value)
+	}
+	r := &Retention{Mode: nodeMode, States: uint32(states)}
+	switch nodeMode {
+	case NodeModeArchive:
+		r.Blocks = orDefault(blocks, 0)
+		r.Receipts = orDefault(receipts, 0)
+		r.EVMIndex = orDefault(evmIndex, 0)
+		if r.States != 0 || r.Blocks != 0 || r.Receipts != 0 || r.EVMIndex != 0 {
+			return nil, fmt.Errorf("%s node must keep the whole history, but retention is %s", nodeMode, r)
+		}
+	case NodeModeFull:
+		r.Blocks = orDefault(blocks, 0)
+		r.Receipts = orDefault(receipts, r.States)
+		r.EVMIndex = orDefault(evmIndex, 0)
+		if r.States == 0 {
+			return nil, fmt.Errorf("%s node must prune the trie states; use %s mode to keep them", nodeMode, NodeModeArchive)
+		}
+		if r.Blocks != 0 {
+			return nil, fmt.Errorf("%s node must keep all the blocks, but retention is %s", nodeMode, r)
+		}
+		if r.Receipts == 0 {
+			return nil, fmt.Errorf("%s node cannot serve the receipts of all the blocks without keeping all the trie states; use %s mode for that", nodeMode, NodeModeArchive)
+		}
+	case NodeModePruned:
+		r.Blocks = orDefault(blocks, r.States)
+		r.Receipts = orDefault(receipts, r.States)
+		r.EVMIndex = orDefault(evmIndex, r.States)
+		if r.States == 0 || r.Blocks == 0 || r.Receipts == 0 || r.EVMIndex == 0 {
+			return nil, fmt.Errorf("%s node must prune all the data classes, but retention is %s", nodeMode, r)
+		}
+	default:
+		return nil, fmt.Errorf("unknown node mode %q", mode)
+	}
+	return r, nil
+}
+
+func (r *Retention) IsArchive() bool {
+	return r.Mode == NodeModeArchive
+}
+
+func (r *Retention) String() string {
+	return fmt.Sprintf("{mode=%s, states=%v, blocks=%v, receipts=%v, evmIndex=%v}", r.Mode, r.States, r.Blocks, r.Receipts, r.EVMIndex)
+}
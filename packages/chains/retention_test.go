@@ -0,0 +1,53 @@
+package chains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRetention(t *testing.T) {
+	d := RetentionDefault
+	valid := []struct {
+		mode                             string
+		states, blocks, receipts, evmIdx int
+		expected                         Retention
+	}{
+		{"", 0, d, d, d, Retention{Mode: NodeModeArchive}},
+		{"", 10000, d, d, d, Retention{Mode: NodeModeFull, States: 10000, Receipts: 10000}},
+		{"", 100, d, 1000, 50, Retention{Mode: NodeModeFull, States: 100, Receipts: 1000, EVMIndex: 50}},
+		{"", 100, 200, d, d, Retention{Mode: NodeModePruned, States: 100, Blocks: 200, Receipts: 100, EVMIndex: 100}},
+		{"archive", 0, 0, 0, 0, Retention{Mode: NodeModeArchive}},
+		{"full", 100, d, 50, 0, Retention{Mode: NodeModeFull, States: 100, Receipts: 50}},
+		{"pruned", 100, d, 10, 1000, Retention{Mode: NodeModePruned, States: 100, Blocks: 100, Receipts: 10, EVMIndex: 1000}},
+	}
+	for _, v := range valid {
+		r, err := NewRetention(v.mode, v.states, v.blocks, v.receipts, v.evmIdx)
+		require.NoError(t, err)
+		require.Equal(t, v.expected, *r)
+	}
+
+	invalid := []struct {
+		mode                             string
+		states, blocks, receipts, evmIdx int
+	}{
+		{"", -1, d, d, d},         // negative states retention
+		{"", 100, -2, d, d},       // invalid blocks retention
+		{"", 100, d, -2, d},       // invalid receipts retention
+		{"unknown", 100, d, d, d}, // unknown mode
+		{"archive", 100, d, d, d}, // archive node prunes the states
+		{"archive", 0, 100, d, d}, // archive node prunes the blocks
+		{"archive", 0, d, 100, d}, // archive node prunes the receipts
+		{"archive", 0, d, d, 100}, // archive node prunes the EVM index
+		{"full", 0, d, d, d},      // full node does not prune the states
+		{"full", 100, 100, d, d},  // full node prunes the blocks
+		{"full", 100, d, 0, d},    // full node serves all the receipts
+		{"pruned", 100, 0, d, d},  // pruned node keeps all the blocks
+		{"pruned", 100, d, 0, d},  // pruned node serves all the receipts
+		{"pruned", 100, d, d, 0},  // pruned node keeps the whole EVM index
+	}
+	for _, v := range invalid {
+		_, err := NewRetention(v.mode, v.states, v.blocks, v.receipts, v.evmIdx)
+		require.Error(t, err, "%+v", v)
+	}
+}
@@ -1,11 +1,14 @@
 package chainutil
 
 import (
+	"errors"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
 	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/isc"
 )
 
@@ -16,6 +19,29 @@ func EVMTraceTransaction(
 	iscRequestsInBlock []isc.Request,
 	txIndex uint64,
 	tracer tracers.Tracer,
+) error {
+	return EVMTraceBlock(
+		ch,
+		aliasOutput,
+		blockTime,
+		iscRequestsInBlock,
+		func(i uint64) tracers.Tracer {
+			if i != txIndex {
+				return nil
+			}
+			return tracer
+		},
+	)
+}
+
+// EVMTraceBlock re-runs all requests of a block, tracing each EVM tx with the
+// tracer returned by tracerForTx.
+func EVMTraceBlock(
+	ch chain.ChainCore,
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	iscRequestsInBlock []isc.Request,
+	tracerForTx func(txIndex uint64) tracers.Tracer,
 ) error {
 	_, err := runISCTask(
 		ch,
@@ -24,9 +50,47 @@ func EVMTraceTransaction(
 		iscRequestsInBlock,
 		false,
 		&isc.EVMTracer{
-			Tracer:  tracer,
-			TxIndex: txIndex,
+			TracerForTx: tracerForTx,
 		},
 	)
 	return err
 }
+
+// EVMTraceCall executes an EVM contract call with the given tracer, after
+// applying the given state overrides, in a block with the given time.
+// The resulting chain state is discarded.
+// A reverted call is not an error, since the tracer captures the failure.
+func EVMTraceCall(
+	ch chain.ChainCore,
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	call ethereum.CallMsg,
+	stateOverride evmtypes.StateOverride,
+	tracer tracers.Tracer,
+) error {
+	gasLimit := getMaxCallGasLimit(ch)
+	if call.Gas != 0 && call.Gas > gasLimit {
+		call.Gas = gasLimit
+	}
+
+	results, err := runISCTask(
+		ch,
+		aliasOutput,
+		blockTime,
+		[]isc.Request{isc.NewEVMOffLedgerCallRequest(ch.ID(), call)},
+		true,
+		&isc.EVMTracer{
+			TracerForTx: func(uint64) tracers.Tracer {
+				return tracer
+			},
+			StateOverride: stateOverride,
+		},
+	)
+	if err != nil {
+		return err
+	}
+	if len(results) == 0 {
+		return errors.New("request was skipped")
+	}
+	return nil
+}
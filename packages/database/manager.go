@@ -1,8 +1,10 @@
 package database
 
 import (
+	"bytes"
 	"fmt"
 	"path"
+	"sort"
 	"sync"
 
 	"golang.org/x/crypto/blake2b"
@@ -81,8 +83,16 @@ func (m *ChainStateDatabaseManager) DBHash() (ret hashing.HashValue) {
 	if h.Size() != hashing.HashSize {
 		panic("blake2b: hash size != 32")
 	}
-	for _, db := range m.databases {
-		err := db.database.store.Iterate([]byte{}, func(k []byte, v []byte) bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	// the chains are sorted to make the hash independent of the map order
+	chainIDs := lo.Keys(m.databases)
+	sort.Slice(chainIDs, func(i, j int) bool {
+		return bytes.Compare(chainIDs[i].Bytes(), chainIDs[j].Bytes()) < 0
+	})
+	for _, chainID := range chainIDs {
+		err := m.databases[chainID].database.store.Iterate([]byte{}, func(k []byte, v []byte) bool {
 			_, err := h.Write(k)
 			if err != nil {
 				panic(err)
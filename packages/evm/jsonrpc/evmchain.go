@@ -9,10 +9,13 @@ import (
 	"math"
 	"math/big"
 	"path"
+	"sort"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/labstack/gommon/log"
@@ -48,8 +51,9 @@ type EVMChain struct {
 	backend  ChainBackend
 	chainID  uint16 // cache
 	newBlock *event.Event1[*NewBlockEvent]
+	newTx    *event.Event1[common.Hash]
 	log      *logger.Logger
-	index    *jsonrpcindex.Index // only indexes blocks that will be pruned from the active state
+	index    *jsonrpcindex.Index // indexes all logs, and the blocks that will be pruned from the active state
 }
 
 type NewBlockEvent struct {
@@ -60,7 +64,7 @@ type NewBlockEvent struct {
 func NewEVMChain(
 	backend ChainBackend,
 	pub *publisher.Publisher,
-	isArchiveNode bool,
+	indexBlocksToKeep uint32,
 	indexDbEngine hivedb.Engine,
 	indexDbPath string,
 	log *logger.Logger,
@@ -68,22 +72,48 @@ func NewEVMChain(
 	e := &EVMChain{
 		backend:  backend,
 		newBlock: event.New1[*NewBlockEvent](),
+		newTx:    event.New1[common.Hash](),
 		log:      log,
-		index:    jsonrpcindex.New(blockchainDB, backend.ISCStateByTrieRoot, indexDbEngine, path.Join(indexDbPath, backend.ISCChainID().String())),
+		index:    jsonrpcindex.New(blockchainDB, backend.ISCStateByTrieRoot, indexBlocksToKeep, indexDbEngine, path.Join(indexDbPath, backend.ISCChainID().String())),
 	}
 
 	blocksFromPublisher := pipe.NewInfinitePipe[*publisher.BlockWithTrieRoot]()
+	blocksToIndex := pipe.NewInfinitePipe[trie.Hash]()
+	blocksToIndexLogs := pipe.NewInfinitePipe[trie.Hash]()
+
+	// backfill the log index up to the current block
+	blocksToIndexLogs.In() <- backend.ISCLatestState().TrieRoot()
 
 	pub.Events.NewBlock.Hook(func(ev *publisher.ISCEvent[*publisher.BlockWithTrieRoot]) {
 		if !ev.ChainID.Equals(*e.backend.ISCChainID()) {
 			return
 		}
 		blocksFromPublisher.In() <- ev.Payload
-		if isArchiveNode {
-			e.index.IndexBlock(ev.Payload.TrieRoot)
-		}
+		blocksToIndex.In() <- ev.Payload.TrieRoot
+		blocksToIndexLogs.In() <- ev.Payload.TrieRoot
 	})
 
+	// index blocks on a separate goroutine, so that the publisher is not
+	// blocked while the index is locked (e.g. by a batch of IndexLogs)
+	go func() {
+		for trieRoot := range blocksToIndex.Out() {
+			// the index is bounded by indexBlocksToKeep (the EVM index retention
+			// of the node), it only grows without limit on archive nodes
+			if err := e.index.IndexBlock(trieRoot); err != nil {
+				e.log.Errorf("EVMChain: IndexBlock(trieRoot=%v) returned error: %v", trieRoot, err)
+			}
+		}
+	}()
+
+	// index logs on a separate goroutine, the backfill may take a while
+	go func() {
+		for trieRoot := range blocksToIndexLogs.Out() {
+			if err := e.index.IndexLogs(trieRoot); err != nil {
+				e.log.Errorf("EVMChain: IndexLogs(trieRoot=%v) returned error: %v", trieRoot, err)
+			}
+		}
+	}()
+
 	// publish blocks on a separate goroutine so that we don't block the publisher
 	go func() {
 		for ev := range blocksFromPublisher.Out() {
@@ -188,7 +218,11 @@ func (e *EVMChain) SendTransaction(tx *types.Transaction) error {
 	if err := e.checkEnoughL2FundsForGasBudget(sender, tx.Gas()); err != nil {
 		return err
 	}
-	return e.backend.EVMSendTransaction(tx)
+	if err := e.backend.EVMSendTransaction(tx); err != nil {
+		return err
+	}
+	e.newTx.Trigger(tx.Hash())
+	return nil
 }
 
 func (e *EVMChain) checkEnoughL2FundsForGasBudget(sender common.Address, evmGas uint64) error {
@@ -313,6 +347,56 @@ func (e *EVMChain) Code(address common.Address, blockNumberOrHash *rpc.BlockNumb
 	return emulator.GetCode(stateDBSubrealmR(chainState), address), nil
 }
 
+// GetProof returns the account data and storage values of the given EVM
+// account, along with their ISC trie proofs.
+func (e *EVMChain) GetProof(address common.Address, storageKeys []common.Hash, blockNumberOrHash *rpc.BlockNumberOrHash) (*ProofResult, error) {
+	e.log.Debugf("GetProof(address=%v, storageKeys=%v, blockNumberOrHash=%v)", address, storageKeys, blockNumberOrHash)
+	chainState, err := e.iscStateFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	chainID := *e.backend.ISCChainID()
+	accountsPartition := subrealm.NewReadOnly(chainState, kv.Key(accounts.Contract.Hname().Bytes()))
+	baseTokens := accounts.GetBaseTokensBalance(accountsPartition, isc.NewEthereumAddressAgentID(chainID, address), chainID)
+	stateDB := stateDBSubrealmR(chainState)
+
+	result := &ProofResult{
+		Address:     address,
+		BlockNumber: hexutil.Uint64(chainState.BlockIndex()),
+		StateRoot:   chainState.TrieRoot().Bytes(),
+		Balance:     (*hexutil.Big)(util.BaseTokensDecimalsToEthereumDecimals(baseTokens, parameters.L1().BaseToken.Decimals)),
+		BalanceProof: stateProof(chainState, kv.Key(accounts.Contract.Hname().Bytes())+
+			accounts.BaseTokensKey(isc.NewEthereumAddressAgentID(chainID, address), chainID)),
+		Nonce:        hexutil.Uint64(emulator.GetNonce(stateDB, address)),
+		NonceProof:   stateProof(chainState, evmStateDBKey(emulator.AccountNonceKey(address))),
+		CodeHash:     crypto.Keccak256Hash(emulator.GetCode(stateDB, address)),
+		CodeProof:    stateProof(chainState, evmStateDBKey(emulator.AccountCodeKey(address))),
+		StorageProof: make([]*StorageProof, len(storageKeys)),
+	}
+	for i, key := range storageKeys {
+		result.StorageProof[i] = &StorageProof{
+			Key:   key,
+			Value: emulator.GetState(stateDB, address, key),
+			Proof: stateProof(chainState, evmStateDBKey(emulator.AccountStateKey(address, key))),
+		}
+	}
+	return result, nil
+}
+
+// evmStateDBKey returns the key in the chain state where the given key of
+// the EVM StateDB is stored.
+func evmStateDBKey(key kv.Key) kv.Key {
+	return kv.Key(evm.Contract.Hname().Bytes()) + evm.EmulatorStateKey(emulator.StateDBKey(key))
+}
+
+func stateProof(chainState state.State, key kv.Key) *StateProof {
+	return &StateProof{
+		Key:   []byte(key),
+		Value: chainState.Get(key),
+		Proof: chainState.GetMerkleProof([]byte(key)).Bytes(),
+	}
+}
+
 func (e *EVMChain) BlockByNumber(blockNumber *big.Int) (*types.Block, error) {
 	e.log.Debugf("BlockByNumber(blockNumber=%v)", blockNumber)
 
@@ -357,6 +441,82 @@ func (e *EVMChain) TransactionByHash(hash common.Hash) (tx *types.Transaction, b
 	return db.GetTransactionByHash(hash)
 }
 
+// PendingTransactions returns the EVM transactions waiting in the mempool,
+// grouped by sender and sorted by nonce. The transactions with nonces
+// contiguous to the sender's nonce in the latest state are pending; the ones
+// after a nonce gap are queued.
+func (e *EVMChain) PendingTransactions() (pending, queued map[common.Address][]*types.Transaction, err error) {
+	e.log.Debugf("PendingTransactions()")
+	reqs, err := e.backend.ISCMempoolOffLedgerRequests()
+	if err != nil {
+		return nil, nil, err
+	}
+	txsBySender := make(map[common.Address][]*types.Transaction)
+	for _, req := range reqs {
+		tx, ok := evmTransactionFromRequest(req)
+		if !ok {
+			continue
+		}
+		sender, err := evmutil.GetSender(tx)
+		if err != nil {
+			continue
+		}
+		txsBySender[sender] = append(txsBySender[sender], tx)
+	}
+
+	pending = make(map[common.Address][]*types.Transaction)
+	queued = make(map[common.Address][]*types.Transaction)
+	stateDB := stateDBSubrealmR(e.backend.ISCLatestState())
+	for sender, txs := range txsBySender {
+		sort.Slice(txs, func(i, j int) bool { return txs[i].Nonce() < txs[j].Nonce() })
+		expectedNonce := emulator.GetNonce(stateDB, sender)
+		for _, tx := range txs {
+			switch {
+			case tx.Nonce() < expectedNonce:
+				continue // already processed, will be removed from the mempool
+			case tx.Nonce() == expectedNonce && len(queued[sender]) == 0:
+				pending[sender] = append(pending[sender], tx)
+				expectedNonce++
+			default:
+				queued[sender] = append(queued[sender], tx)
+			}
+		}
+	}
+	return pending, queued, nil
+}
+
+// PendingTransactionByHash returns the transaction with the given hash if it
+// is waiting in the mempool, or nil otherwise.
+func (e *EVMChain) PendingTransactionByHash(hash common.Hash) (*types.Transaction, error) {
+	e.log.Debugf("PendingTransactionByHash(hash=%v)", hash)
+	reqs, err := e.backend.ISCMempoolOffLedgerRequests()
+	if err != nil {
+		return nil, err
+	}
+	reqID := isc.RequestIDFromEVMTxHash(hash)
+	for _, req := range reqs {
+		if req.ID() != reqID {
+			continue
+		}
+		if tx, ok := evmTransactionFromRequest(req); ok {
+			return tx, nil
+		}
+	}
+	return nil, nil
+}
+
+func evmTransactionFromRequest(req isc.Request) (*types.Transaction, bool) {
+	target := req.CallTarget()
+	if target.Contract != evm.Contract.Hname() || target.EntryPoint != evm.FuncSendTransaction.Hname() {
+		return nil, false
+	}
+	tx, err := evmtypes.DecodeTransaction(req.Params().Get(evm.FieldTransaction))
+	if err != nil {
+		return nil, false
+	}
+	return tx, true
+}
+
 func (e *EVMChain) TransactionByBlockHashAndIndex(hash common.Hash, index uint64) (tx *types.Transaction, blockNumber uint64, err error) {
 	e.log.Debugf("TransactionByBlockHashAndIndex(hash=%v, index=%v)", hash, index)
 	cachedTx, bn := e.index.TxByBlockHashAndIndex(hash, index)
@@ -443,27 +603,115 @@ func (e *EVMChain) EstimateGas(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.
 
 func (e *EVMChain) GasPrice() *big.Int {
 	e.log.Debugf("GasPrice()")
+	return gasPrice(e.backend.ISCLatestState())
+}
 
-	iscState := e.backend.ISCLatestState()
-	governancePartition := subrealm.NewReadOnly(iscState, kv.Key(governance.Contract.Hname().Bytes()))
+// gasPrice returns the price of an EVM gas unit in wei, according to the
+// fee policy in the given state.
+func gasPrice(chainState state.State) *big.Int {
+	governancePartition := subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes()))
 	feePolicy := governance.MustGetGasFeePolicy(governancePartition)
+	return feePolicy.EVMGasPrice(parameters.L1().BaseToken.Decimals)
+}
 
-	// special case '0:0' for free request
-	if feePolicy.GasPerToken.IsZero() {
-		return big.NewInt(0)
+// maxFeeHistoryBlocks is the maximum amount of blocks that can be requested
+// in eth_feeHistory (same as go-ethereum).
+const maxFeeHistoryBlocks = 1024
+
+// FeeHistory returns the base fee and gas used ratio of the blockCount blocks
+// ending at newestBlock. There are no priority fees in ISC, so all rewards are
+// 0.
+func (e *EVMChain) FeeHistory(blockCount uint64, newestBlock *big.Int, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	e.log.Debugf("FeeHistory(blockCount=%v, newestBlock=%v, rewardPercentiles=%v)", blockCount, newestBlock, rewardPercentiles)
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return nil, fmt.Errorf("invalid reward percentile: %f", p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return nil, fmt.Errorf("invalid reward percentile: #%d:%f > #%d:%f", i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+	if blockCount == 0 {
+		return &FeeHistoryResult{OldestBlock: (*hexutil.Big)(big.NewInt(0))}, nil
+	}
+	if blockCount > maxFeeHistoryBlocks {
+		blockCount = maxFeeHistoryBlocks
+	}
+
+	latestState := e.backend.ISCLatestState()
+	latest := blockchainDB(latestState).GetNumber()
+	newest, err := blockNumberU64(blockchainDB(latestState), newestBlock)
+	if err != nil {
+		return nil, err
+	}
+	if newest > latest {
+		return nil, fmt.Errorf("requested block %d is after the latest block %d", newest, latest)
 	}
+	if blockCount > newest+1 {
+		blockCount = newest + 1
+	}
+	oldest := newest + 1 - blockCount
 
-	// convert to wei (18 decimals)
-	decimalsDifference := 18 - parameters.L1().BaseToken.Decimals
-	price := big.NewInt(10)
-	price.Exp(price, new(big.Int).SetUint64(uint64(decimalsDifference)), nil)
+	result := &FeeHistoryResult{
+		OldestBlock:  (*hexutil.Big)(new(big.Int).SetUint64(oldest)),
+		BaseFee:      make([]*hexutil.Big, 0, blockCount+1),
+		GasUsedRatio: make([]float64, 0, blockCount),
+	}
+	if len(rewardPercentiles) > 0 {
+		result.Reward = make([][]*hexutil.Big, 0, blockCount)
+	}
+	for n := oldest; n <= newest; n++ {
+		block, err := e.BlockByNumber(new(big.Int).SetUint64(n))
+		if err != nil {
+			return nil, err
+		}
+		baseFee, err := e.baseFee(block.Number())
+		if err != nil {
+			return nil, err
+		}
+		result.BaseFee = append(result.BaseFee, (*hexutil.Big)(baseFee))
+		gasUsedRatio := 0.0
+		if block.GasLimit() > 0 {
+			gasUsedRatio = float64(block.GasUsed()) / float64(block.GasLimit())
+		}
+		result.GasUsedRatio = append(result.GasUsedRatio, gasUsedRatio)
+		if result.Reward != nil {
+			rewards := make([]*hexutil.Big, len(rewardPercentiles))
+			for i := range rewards {
+				rewards[i] = (*hexutil.Big)(big.NewInt(0))
+			}
+			result.Reward = append(result.Reward, rewards)
+		}
+	}
 
-	price.Mul(price, new(big.Int).SetUint64(uint64(feePolicy.GasPerToken.B)))
-	price.Div(price, new(big.Int).SetUint64(uint64(feePolicy.GasPerToken.A)))
-	price.Mul(price, new(big.Int).SetUint64(uint64(feePolicy.EVMGasRatio.A)))
-	price.Div(price, new(big.Int).SetUint64(uint64(feePolicy.EVMGasRatio.B)))
+	// the base fee of the block after newest
+	var nextBaseFee *big.Int
+	if newest == latest {
+		nextBaseFee = gasPrice(latestState)
+	} else {
+		nextBaseFee, err = e.baseFee(new(big.Int).SetUint64(newest + 1))
+		if err != nil {
+			return nil, err
+		}
+	}
+	result.BaseFee = append(result.BaseFee, (*hexutil.Big)(nextBaseFee))
+	return result, nil
+}
 
-	return price
+// baseFee returns the base fee of the given block, i.e. the gas price
+// according to the fee policy in the state the block ran on (the state after
+// the previous block). It is not stored in the EVM block header, so that block
+// hashes are not affected.
+func (e *EVMChain) baseFee(blockNumber *big.Int) (*big.Int, error) {
+	prevBlockNumber := new(big.Int).Set(blockNumber)
+	if prevBlockNumber.Sign() > 0 {
+		prevBlockNumber.Sub(prevBlockNumber, big.NewInt(1))
+	}
+	chainState, err := e.iscStateFromEVMBlockNumber(prevBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return gasPrice(chainState), nil
 }
 
 func (e *EVMChain) StorageAt(address common.Address, key common.Hash, blockNumberOrHash *rpc.BlockNumberOrHash) (common.Hash, error) {
@@ -496,6 +744,9 @@ func (e *EVMChain) BlockTransactionCountByNumber(blockNumber *big.Int) (uint64,
 const (
 	maxBlocksInFilterRange = 1_000
 	maxLogsInResult        = 10_000
+	// maxIndexedLogsInBatch is the amount of logs read at once from the log
+	// index, which is locked meanwhile
+	maxIndexedLogsInBatch = 1_000
 )
 
 // Logs executes a log filter operation, blocking during execution and
@@ -525,12 +776,19 @@ func (e *EVMChain) Logs(query *ethereum.FilterQuery) ([]*types.Log, error) {
 
 	// block range query
 
-	// Initialize unset filter boundaries to run from genesis to chain head
+	// Initialize unset filter boundaries to the chain head, as go-ethereum does
 	first := big.NewInt(1) // skip genesis since it has no logs
 	last := new(big.Int).SetUint64(uint64(e.backend.ISCLatestState().BlockIndex()))
-	from := first
-	if query.FromBlock != nil && query.FromBlock.Cmp(first) >= 0 && query.FromBlock.Cmp(last) <= 0 {
+	from := last
+	if query.FromBlock != nil && query.FromBlock.Sign() >= 0 {
 		from = query.FromBlock
+		if from.Cmp(first) < 0 {
+			from = first
+			if indexedFirst, _, ok := e.index.LogsIndexedRange(); ok && indexedFirst > from.Uint64() {
+				// the older blocks were pruned from the node (see jsonrpcindex.IndexLogs)
+				from = new(big.Int).SetUint64(indexedFirst)
+			}
+		}
 	}
 	to := last
 	if query.ToBlock != nil && query.ToBlock.Cmp(first) >= 0 && query.ToBlock.Cmp(last) <= 0 {
@@ -540,30 +798,77 @@ func (e *EVMChain) Logs(query *ethereum.FilterQuery) ([]*types.Log, error) {
 	if !from.IsUint64() || !to.IsUint64() {
 		return nil, errors.New("block number is too large")
 	}
-	{
-		from := from.Uint64()
-		to := to.Uint64()
-		if to > from && to-from > maxBlocksInFilterRange {
-			return nil, errors.New("too many blocks in filter range")
+	if from.Cmp(to) > 0 {
+		return logs, nil
+	}
+	return e.logsInRange(query, from.Uint64(), to.Uint64())
+}
+
+// logsInRange answers the range query from the log index as far as it
+// covers it, and scans the state of the blocks before and after the indexed
+// range. The logs are read from the index in batches.
+func (e *EVMChain) logsInRange(query *ethereum.FilterQuery, from, to uint64) ([]*types.Log, error) {
+	logs := make([]*types.Log, 0)
+
+	indexedFrom, indexedTo := to+1, to // empty range
+	if first, last, ok := e.index.LogsIndexedRange(); ok && first <= to && from <= last {
+		indexedFrom, indexedTo = from, to
+		if indexedFrom < first {
+			indexedFrom = first
+		}
+		if indexedTo > last {
+			indexedTo = last
 		}
-		for i := from; i <= to; i++ {
-			state, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(i))
-			if err != nil {
-				return nil, err
+	}
+	scanBefore := indexedFrom - from
+	scanAfter := to - indexedTo
+	if scanBefore+scanAfter > maxBlocksInFilterRange+1 {
+		return nil, errors.New("too many blocks in filter range")
+	}
+
+	if err := e.scanLogs(query, from, indexedFrom-1, &logs); err != nil {
+		return nil, err
+	}
+	if indexedFrom <= indexedTo {
+		start := jsonrpcindex.LogPosition{BlockNumber: indexedFrom}
+		for {
+			indexedLogs, next := e.index.Logs(query, indexedFrom, indexedTo, start, maxIndexedLogsInBatch)
+			if len(logs)+len(indexedLogs) > maxLogsInResult {
+				return nil, errors.New("too many logs in result")
 			}
-			err = filterAndAppendToLogs(
-				query,
-				blockchainDB(state).GetReceiptsByBlockNumber(i),
-				&logs,
-			)
-			if err != nil {
-				return nil, err
+			logs = append(logs, indexedLogs...)
+			if next == nil {
+				break
 			}
+			start = *next
 		}
 	}
+	if err := e.scanLogs(query, indexedTo+1, to, &logs); err != nil {
+		return nil, err
+	}
 	return logs, nil
 }
 
+// scanLogs appends the logs matching the query in the blocks [from, to],
+// reading them from the state of each block.
+func (e *EVMChain) scanLogs(query *ethereum.FilterQuery, from, to uint64, logs *[]*types.Log) error {
+	for i := from; i <= to; i++ {
+		state, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(i))
+		if err != nil {
+			return err
+		}
+		err = filterAndAppendToLogs(
+			query,
+			blockchainDB(state).GetReceiptsByBlockNumber(i),
+			logs,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func filterAndAppendToLogs(query *ethereum.FilterQuery, receipts []*types.Receipt, logs *[]*types.Log) error {
 	for _, r := range receipts {
 		if !evmtypes.BloomFilter(r.Bloom, query.Addresses, query.Topics) {
@@ -619,6 +924,15 @@ func (e *EVMChain) SubscribeLogs(q *ethereum.FilterQuery, ch chan<- []*types.Log
 	}).Unhook
 }
 
+// SubscribePendingTransactions notifies the hashes of the transactions
+// accepted by this node via SendTransaction.
+func (e *EVMChain) SubscribePendingTransactions(ch chan<- common.Hash) (unsubscribe func()) {
+	e.log.Debugf("SubscribePendingTransactions(ch=?)")
+	return e.newTx.Hook(func(txHash common.Hash) {
+		ch <- txHash
+	}).Unhook
+}
+
 func (e *EVMChain) iscRequestsInBlock(evmBlockNumber uint64) (*blocklog.BlockInfo, []isc.Request, error) {
 	iscState, err := e.iscStateFromEVMBlockNumber(new(big.Int).SetUint64(evmBlockNumber))
 	if err != nil {
@@ -631,11 +945,7 @@ func (e *EVMChain) iscRequestsInBlock(evmBlockNumber uint64) (*blocklog.BlockInf
 
 func (e *EVMChain) TraceTransaction(txHash common.Hash, config *tracers.TraceConfig) (any, error) {
 	e.log.Debugf("TraceTransaction(txHash=%v, config=?)", txHash)
-	tracerType := "callTracer"
-	if config.Tracer != nil {
-		tracerType = *config.Tracer
-	}
-	tracer, err := newTracer(tracerType, config.TracerConfig)
+	tracer, err := newTracerFromConfig(config)
 	if err != nil {
 		return nil, err
 	}
@@ -667,6 +977,110 @@ func (e *EVMChain) TraceTransaction(txHash common.Hash, config *tracers.TraceCon
 	return tracer.GetResult()
 }
 
+func (e *EVMChain) TraceBlockByNumber(blockNumber *big.Int, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	e.log.Debugf("TraceBlockByNumber(blockNumber=%v, config=?)", blockNumber)
+	block, err := e.BlockByNumber(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+	return e.traceBlock(block, config)
+}
+
+func (e *EVMChain) TraceBlockByHash(blockHash common.Hash, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	e.log.Debugf("TraceBlockByHash(blockHash=%v, config=?)", blockHash)
+	block := e.BlockByHash(blockHash)
+	if block == nil {
+		return nil, errors.New("block not found")
+	}
+	return e.traceBlock(block, config)
+}
+
+// traceBlock re-runs all ISC requests of the block, tracing each EVM tx with
+// its own tracer.
+func (e *EVMChain) traceBlock(block *types.Block, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	txs := block.Transactions()
+	results := make([]*TxTraceResult, len(txs))
+	if len(txs) == 0 {
+		return results, nil
+	}
+
+	txTracers := make([]tracers.Tracer, len(txs))
+	for i := range txTracers {
+		var err error
+		txTracers[i], err = newTracerFromConfig(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	iscBlock, iscRequestsInBlock, err := e.iscRequestsInBlock(block.NumberU64())
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.backend.EVMTraceBlock(
+		iscBlock.PreviousAliasOutput,
+		iscBlock.Timestamp,
+		iscRequestsInBlock,
+		func(txIndex uint64) tracers.Tracer {
+			if txIndex >= uint64(len(txTracers)) {
+				return nil
+			}
+			return txTracers[txIndex]
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, tx := range txs {
+		results[i] = &TxTraceResult{TxHash: tx.Hash()}
+		res, err := txTracers[i].GetResult()
+		if err != nil {
+			results[i].Error = err.Error()
+			continue
+		}
+		results[i].Result = res
+	}
+	return results, nil
+}
+
+// TraceCall executes the given call on top of the given block, with the given
+// state overrides, and returns the trace.
+func (e *EVMChain) TraceCall(callMsg ethereum.CallMsg, blockNumberOrHash *rpc.BlockNumberOrHash, config *TraceCallConfig) (any, error) {
+	e.log.Debugf("TraceCall(callMsg=..., blockNumberOrHash=%v, config=?)", blockNumberOrHash)
+	var traceConfig *tracers.TraceConfig
+	var stateOverride evmtypes.StateOverride
+	if config != nil {
+		traceConfig = &config.TraceConfig
+		if config.StateOverrides != nil {
+			stateOverride = *config.StateOverrides
+		}
+	}
+	tracer, err := newTracerFromConfig(traceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	aliasOutput, err := e.iscAliasOutputFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	// the call sees the time of the block it runs on, not the current time
+	chainState, err := e.iscStateFromEVMBlockNumberOrHash(blockNumberOrHash)
+	if err != nil {
+		return nil, err
+	}
+	err = e.backend.EVMTraceCall(aliasOutput, chainState.Timestamp(), callMsg, stateOverride, tracer)
+	if err != nil {
+		return nil, err
+	}
+	return tracer.GetResult()
+}
+
 var maxUint32 = big.NewInt(math.MaxUint32)
 
 // the first EVM block (number 0) is "minted" at ISC block index 0 (init chain)
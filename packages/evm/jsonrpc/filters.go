@@ -0,0 +1,195 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// filterTimeout is the time after which a filter that has not been polled is
+// uninstalled automatically (same default as go-ethereum).
+const filterTimeout = 5 * time.Minute
+
+// filterDrainTimeout is how long the events of an uninstalled filter are still
+// consumed, so that a concurrent trigger of the EVMChain events never blocks.
+const filterDrainTimeout = 1 * time.Second
+
+var errFilterNotFound = errors.New("filter not found")
+
+type filterKind int
+
+const (
+	logsFilter filterKind = iota
+	blocksFilter
+	pendingTxFilter
+)
+
+type filter struct {
+	kind     filterKind
+	query    *ethereum.FilterQuery // only for logsFilter
+	hashes   []common.Hash
+	logs     []*types.Log
+	deadline *time.Timer
+	done     chan struct{}
+}
+
+// filterRegistry keeps track of the polling filters installed via
+// eth_newFilter, eth_newBlockFilter and eth_newPendingTransactionFilter.
+// Filters that are not polled within the timeout are uninstalled.
+type filterRegistry struct {
+	evmChain *EVMChain
+	timeout  time.Duration
+
+	mutex   sync.Mutex
+	filters map[rpc.ID]*filter
+}
+
+func newFilterRegistry(evmChain *EVMChain, timeout time.Duration) *filterRegistry {
+	return &filterRegistry{
+		evmChain: evmChain,
+		timeout:  timeout,
+		filters:  make(map[rpc.ID]*filter),
+	}
+}
+
+func (r *filterRegistry) newLogsFilter(q *ethereum.FilterQuery) rpc.ID {
+	ch := make(chan []*types.Log)
+	unsubscribe := r.evmChain.SubscribeLogs(q, ch)
+	f := &filter{kind: logsFilter, query: q}
+	return r.install(f, func() {
+		for {
+			select {
+			case logs := <-ch:
+				r.mutex.Lock()
+				f.logs = append(f.logs, logs...)
+				r.mutex.Unlock()
+			case <-f.done:
+				unsubscribe()
+				drain(ch)
+				return
+			}
+		}
+	})
+}
+
+func (r *filterRegistry) newBlocksFilter() rpc.ID {
+	ch := make(chan *types.Header)
+	unsubscribe := r.evmChain.SubscribeNewHeads(ch)
+	f := &filter{kind: blocksFilter}
+	return r.install(f, func() {
+		for {
+			select {
+			case h := <-ch:
+				r.mutex.Lock()
+				f.hashes = append(f.hashes, h.Hash())
+				r.mutex.Unlock()
+			case <-f.done:
+				unsubscribe()
+				drain(ch)
+				return
+			}
+		}
+	})
+}
+
+func (r *filterRegistry) newPendingTxFilter() rpc.ID {
+	ch := make(chan common.Hash)
+	unsubscribe := r.evmChain.SubscribePendingTransactions(ch)
+	f := &filter{kind: pendingTxFilter}
+	return r.install(f, func() {
+		for {
+			select {
+			case txHash := <-ch:
+				r.mutex.Lock()
+				f.hashes = append(f.hashes, txHash)
+				r.mutex.Unlock()
+			case <-f.done:
+				unsubscribe()
+				drain(ch)
+				return
+			}
+		}
+	})
+}
+
+func (r *filterRegistry) install(f *filter, loop func()) rpc.ID {
+	id := rpc.NewID()
+	f.done = make(chan struct{})
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	f.deadline = time.AfterFunc(r.timeout, func() { r.uninstall(id) })
+	r.filters[id] = f
+	go loop()
+	return id
+}
+
+func (r *filterRegistry) uninstall(id rpc.ID) bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	f, ok := r.filters[id]
+	if !ok {
+		return false
+	}
+	delete(r.filters, id)
+	f.deadline.Stop()
+	close(f.done)
+	return true
+}
+
+// changes returns the events accumulated by the filter since the last poll,
+// either as a list of hashes or as a list of logs.
+func (r *filterRegistry) changes(id rpc.ID) (any, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	f, ok := r.filters[id]
+	if !ok {
+		return nil, errFilterNotFound
+	}
+	f.deadline.Reset(r.timeout)
+	switch f.kind {
+	case logsFilter:
+		logs := f.logs
+		f.logs = nil
+		if logs == nil {
+			return []*types.Log{}, nil
+		}
+		return logs, nil
+	default:
+		hashes := f.hashes
+		f.hashes = nil
+		if hashes == nil {
+			return []common.Hash{}, nil
+		}
+		return hashes, nil
+	}
+}
+
+// logsQuery returns the criteria of a logs filter.
+func (r *filterRegistry) logsQuery(id rpc.ID) (*ethereum.FilterQuery, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	f, ok := r.filters[id]
+	if !ok || f.kind != logsFilter {
+		return nil, errFilterNotFound
+	}
+	f.deadline.Reset(r.timeout)
+	return f.query, nil
+}
+
+func drain[T any](ch <-chan T) {
+	for {
+		select {
+		case <-ch:
+		case <-time.After(filterDrainTimeout):
+			return
+		}
+	}
+}
@@ -1,13 +1,16 @@
 package jsonrpcindex
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/iotaledger/hive.go/kvstore"
 	hivedb "github.com/iotaledger/hive.go/kvstore/database"
@@ -24,13 +27,16 @@ type Index struct {
 	store           kvstore.KVStore
 	blockchainDB    func(chainState state.State) *emulator.BlockchainDB
 	stateByTrieRoot func(trieRoot trie.Hash) (state.State, error)
+	blocksToKeep    uint32 // 0 means that the index is never pruned
 
-	mu sync.Mutex
+	mu          sync.Mutex
+	indexLogsMu sync.Mutex // serializes the calls to IndexLogs, which releases mu in between
 }
 
 func New(
 	blockchainDB func(chainState state.State) *emulator.BlockchainDB,
 	stateByTrieRoot func(trieRoot trie.Hash) (state.State, error),
+	blocksToKeep uint32,
 	indexDbEngine hivedb.Engine,
 	indexDbPath string,
 ) *Index {
@@ -42,63 +48,80 @@ func New(
 		store:           db.KVStore(),
 		blockchainDB:    blockchainDB,
 		stateByTrieRoot: stateByTrieRoot,
+		blocksToKeep:    blocksToKeep,
 		mu:              sync.Mutex{},
 	}
 }
 
-func (c *Index) IndexBlock(trieRoot trie.Hash) {
+// IndexBlock caches the blocks that are about to be pruned from the active
+// state (according to the block keep amount of the chain). The block itself
+// and its receipts are stored in the index, so they stay available even after
+// the trie states of the block are pruned from the node's store.
+//
+// If the state of an old block is not available anymore, the walk stops there.
+// Blocks older than blocksToKeep are deleted from the index.
+func (c *Index) IndexBlock(trieRoot trie.Hash) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	state, err := c.stateByTrieRoot(trieRoot)
 	if err != nil {
-		panic(err)
+		return err
 	}
+	firstIndexToKeep := c.firstIndexToKeep(state.BlockIndex())
+	c.pruneBlocks(firstIndexToKeep)
+
 	blockKeepAmount := governance.NewStateAccess(state).GetBlockKeepAmount()
 	if blockKeepAmount == -1 {
-		return // pruning disabled, never cache anything
+		return c.store.Flush() // pruning disabled, never cache anything
 	}
 	// cache the block that will be pruned next (this way reorgs are okay, as long as it never reorgs more than `blockKeepAmount`, which would be catastrophic)
 	if state.BlockIndex() < uint32(blockKeepAmount-1) {
-		return
+		return c.store.Flush()
 	}
 	blockIndexToCache := state.BlockIndex() - uint32(blockKeepAmount-1)
-	cacheUntil := uint32(0)
+	if blockIndexToCache < firstIndexToKeep {
+		return c.store.Flush()
+	}
+	cacheUntil := firstIndexToKeep
 	lastBlockIndexed := c.lastBlockIndexed()
-	if lastBlockIndexed != nil {
+	if lastBlockIndexed != nil && *lastBlockIndexed > cacheUntil {
 		cacheUntil = *lastBlockIndexed
 	}
 
 	// we need to look at the next block to get the trie commitment of the block we want to cache
 	nextBlockInfo, found := blocklog.NewStateAccess(state).BlockInfo(blockIndexToCache + 1)
 	if !found {
-		panic(fmt.Errorf("block %d not found on active state %d", blockIndexToCache, state.BlockIndex()))
+		return fmt.Errorf("block %d not found on active state %d", blockIndexToCache, state.BlockIndex())
 	}
 
 	// start in the active state of the block to cache
 	activeStateToCache, err := c.stateByTrieRoot(nextBlockInfo.PreviousL1Commitment().TrieRoot())
 	if err != nil {
-		panic(err)
+		return err
 	}
 
+	firstCached := blockIndexToCache
 	for i := blockIndexToCache; i >= cacheUntil; i-- {
 		// walk back and save all blocks between [lastBlockIndexCached...blockIndexToCache]
 
 		blockinfo, found := blocklog.NewStateAccess(activeStateToCache).BlockInfo(i)
 		if !found {
-			panic(fmt.Errorf("block %d not found on active state %d", i, state.BlockIndex()))
+			return fmt.Errorf("block %d not found on active state %d", i, state.BlockIndex())
 		}
 
 		db := c.blockchainDB(activeStateToCache)
-		blockTrieRoot := activeStateToCache.TrieRoot()
-		c.setBlockTrieRootByIndex(i, blockTrieRoot)
+		c.setBlockTrieRootByIndex(i, activeStateToCache.TrieRoot())
 
 		evmBlock := db.GetCurrentBlock()
+		c.setBlockByIndex(i, evmBlock)
+		c.setReceiptsByIndex(i, db.GetReceiptsByBlockNumber(evmBlock.NumberU64()))
 		c.setBlockIndexByHash(evmBlock.Hash(), i)
 
 		blockTransactions := evmBlock.Transactions()
 		for _, tx := range blockTransactions {
 			c.setBlockIndexByTxHash(tx.Hash(), i)
 		}
+		firstCached = i
 		// walk backwards until all blocks are cached
 		if i == 0 {
 			// nothing more to cache, don't try to walk back further
@@ -106,22 +129,55 @@ func (c *Index) IndexBlock(trieRoot trie.Hash) {
 		}
 		activeStateToCache, err = c.stateByTrieRoot(blockinfo.PreviousL1Commitment().TrieRoot())
 		if err != nil {
-			panic(err)
+			// the state is not available anymore, the cached blocks start after it
+			break
 		}
 	}
+	if first := c.firstBlockIndexed(); first == nil || *first > firstCached {
+		c.setFirstBlockIndexed(firstCached)
+	}
 	c.setLastBlockIndexed(blockIndexToCache)
-	c.store.Flush()
+	return c.store.Flush()
 }
 
-func (c *Index) BlockByNumber(n *big.Int) *types.Block {
-	if n == nil {
-		return nil
+// firstIndexToKeep returns the first block, which must be kept in the index,
+// if the latest block is headIndex.
+func (c *Index) firstIndexToKeep(headIndex uint32) uint32 {
+	if c.blocksToKeep == 0 || headIndex < c.blocksToKeep {
+		return 0
 	}
-	db := c.evmDBFromBlockIndex(uint32(n.Uint64()))
-	if db == nil {
+	return headIndex - c.blocksToKeep + 1
+}
+
+func (c *Index) pruneBlocks(firstIndexToKeep uint32) {
+	first := c.firstBlockIndexed()
+	last := c.lastBlockIndexed()
+	if first == nil || last == nil || *first >= firstIndexToKeep {
+		return
+	}
+	for i := *first; i < firstIndexToKeep && i <= *last; i++ {
+		c.deleteBlock(i)
+	}
+	c.setFirstBlockIndexed(firstIndexToKeep)
+}
+
+func (c *Index) deleteBlock(i uint32) {
+	if block := c.block(i); block != nil {
+		c.del(keyBlockIndexByHash(block.Hash()))
+		for _, tx := range block.Transactions() {
+			c.del(keyBlockIndexByTxHash(tx.Hash()))
+		}
+	}
+	c.del(keyBlockTrieRootByIndex(i))
+	c.del(keyBlockByIndex(i))
+	c.del(keyReceiptsByIndex(i))
+}
+
+func (c *Index) BlockByNumber(n *big.Int) *types.Block {
+	if n == nil || !n.IsUint64() || n.Uint64() > math.MaxUint32 {
 		return nil
 	}
-	return db.GetBlockByNumber(n.Uint64())
+	return c.block(uint32(n.Uint64()))
 }
 
 func (c *Index) BlockByHash(hash common.Hash) *types.Block {
@@ -129,7 +185,11 @@ func (c *Index) BlockByHash(hash common.Hash) *types.Block {
 	if blockIndex == nil {
 		return nil
 	}
-	return c.evmDBFromBlockIndex(*blockIndex).GetBlockByHash(hash)
+	block := c.block(*blockIndex)
+	if block == nil || block.Hash() != hash {
+		return nil
+	}
+	return block
 }
 
 func (c *Index) BlockTrieRootByIndex(n uint32) *trie.Hash {
@@ -141,11 +201,16 @@ func (c *Index) TxByHash(hash common.Hash) (tx *types.Transaction, blockHash com
 	if blockIndex == nil {
 		return nil, common.Hash{}, 0, 0
 	}
-	tx, blockHash, blockNumber, txIndex, err := c.evmDBFromBlockIndex(*blockIndex).GetTransactionByHash(hash)
-	if err != nil {
-		panic(err)
+	block := c.block(*blockIndex)
+	if block == nil {
+		return nil, common.Hash{}, 0, 0
+	}
+	for i, tx := range block.Transactions() {
+		if tx.Hash() == hash {
+			return tx, block.Hash(), block.NumberU64(), uint64(i)
+		}
 	}
-	return tx, blockHash, blockNumber, txIndex
+	return nil, common.Hash{}, 0, 0
 }
 
 func (c *Index) GetReceiptByTxHash(hash common.Hash) *types.Receipt {
@@ -153,44 +218,62 @@ func (c *Index) GetReceiptByTxHash(hash common.Hash) *types.Receipt {
 	if blockIndex == nil {
 		return nil
 	}
-	return c.evmDBFromBlockIndex(*blockIndex).GetReceiptByTxHash(hash)
+	for _, receipt := range c.receipts(*blockIndex) {
+		if receipt.TxHash == hash {
+			return receipt
+		}
+	}
+	return nil
 }
 
 func (c *Index) TxByBlockHashAndIndex(blockHash common.Hash, txIndex uint64) (tx *types.Transaction, blockNumber uint64) {
-	blockIndex := c.blockIndexByHash(blockHash)
-	if blockIndex == nil {
-		return nil, 0
-	}
-	block := c.evmDBFromBlockIndex(*blockIndex).GetBlockByHash(blockHash)
+	block := c.BlockByHash(blockHash)
 	if block == nil {
 		return nil, 0
 	}
 	txs := block.Transactions()
-	if txIndex > uint64(len(txs)) {
+	if txIndex >= uint64(len(txs)) {
 		return nil, 0
 	}
 	return txs[txIndex], block.NumberU64()
 }
 
 func (c *Index) TxByBlockNumberAndIndex(blockNumber *big.Int, txIndex uint64) (tx *types.Transaction, blockHash common.Hash) {
-	if blockNumber == nil {
-		return nil, common.Hash{}
-	}
-	db := c.evmDBFromBlockIndex(uint32(blockNumber.Uint64()))
-	if db == nil {
-		return nil, common.Hash{}
-	}
-	block := db.GetBlockByHash(blockHash)
+	block := c.BlockByNumber(blockNumber)
 	if block == nil {
 		return nil, common.Hash{}
 	}
 	txs := block.Transactions()
-	if txIndex > uint64(len(txs)) {
+	if txIndex >= uint64(len(txs)) {
 		return nil, common.Hash{}
 	}
 	return txs[txIndex], block.Hash()
 }
 
+// block returns the cached block. Blocks cached before the blocks were stored
+// in the index are read from the state of the block, if it is still available.
+func (c *Index) block(n uint32) *types.Block {
+	if block := c.blockByIndex(n); block != nil {
+		return block
+	}
+	db := c.evmDBFromBlockIndex(n)
+	if db == nil {
+		return nil
+	}
+	return db.GetBlockByNumber(uint64(n))
+}
+
+func (c *Index) receipts(n uint32) []*types.Receipt {
+	if receipts := c.receiptsByIndex(n); receipts != nil {
+		return receipts
+	}
+	db := c.evmDBFromBlockIndex(n)
+	if db == nil {
+		return nil
+	}
+	return db.GetReceiptsByBlockNumber(uint64(n))
+}
+
 // internals
 
 const (
@@ -198,12 +281,37 @@ const (
 	prefixBlockTrieRootByIndex
 	prefixBlockIndexByTxHash
 	prefixBlockIndexByHash
+	prefixFirstLogsIndexed
+	prefixLastLogsIndexed
+	prefixLogsBlockHash
+	prefixLog
+	prefixLogByAddress
+	prefixLogByTopic
+	prefixFirstBlockIndexed
+	prefixBlockByIndex
+	prefixReceiptsByIndex
 )
 
 func keyLastBlockIndexed() kvstore.Key {
 	return []byte{prefixLastBlockIndexed}
 }
 
+func keyFirstBlockIndexed() kvstore.Key {
+	return []byte{prefixFirstBlockIndexed}
+}
+
+func keyBlockByIndex(i uint32) kvstore.Key {
+	key := []byte{prefixBlockByIndex}
+	key = append(key, codec.EncodeUint32(i)...)
+	return key
+}
+
+func keyReceiptsByIndex(i uint32) kvstore.Key {
+	key := []byte{prefixReceiptsByIndex}
+	key = append(key, codec.EncodeUint32(i)...)
+	return key
+}
+
 func keyBlockTrieRootByIndex(i uint32) kvstore.Key {
 	key := []byte{prefixBlockTrieRootByIndex}
 	key = append(key, codec.EncodeUint32(i)...)
@@ -253,6 +361,61 @@ func (c *Index) lastBlockIndexed() *uint32 {
 	return &ret
 }
 
+func (c *Index) setFirstBlockIndexed(n uint32) {
+	c.set(keyFirstBlockIndexed(), codec.EncodeUint32(n))
+}
+
+func (c *Index) firstBlockIndexed() *uint32 {
+	bytes := c.get(keyFirstBlockIndexed())
+	if bytes == nil {
+		return nil
+	}
+	ret := codec.MustDecodeUint32(bytes)
+	return &ret
+}
+
+func (c *Index) setBlockByIndex(i uint32, block *types.Block) {
+	bytes, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		panic(err)
+	}
+	c.set(keyBlockByIndex(i), bytes)
+}
+
+func (c *Index) blockByIndex(i uint32) *types.Block {
+	bytes := c.get(keyBlockByIndex(i))
+	if bytes == nil {
+		return nil
+	}
+	block := new(types.Block)
+	if err := rlp.DecodeBytes(bytes, block); err != nil {
+		panic(err)
+	}
+	return block
+}
+
+// The receipts are stored as JSON, because the consensus RLP encoding does not
+// include the derived fields (tx hash, block hash, contract address, etc).
+func (c *Index) setReceiptsByIndex(i uint32, receipts []*types.Receipt) {
+	bytes, err := json.Marshal(receipts)
+	if err != nil {
+		panic(err)
+	}
+	c.set(keyReceiptsByIndex(i), bytes)
+}
+
+func (c *Index) receiptsByIndex(i uint32) []*types.Receipt {
+	bytes := c.get(keyReceiptsByIndex(i))
+	if bytes == nil {
+		return nil
+	}
+	var receipts []*types.Receipt
+	if err := json.Unmarshal(bytes, &receipts); err != nil {
+		panic(err)
+	}
+	return receipts
+}
+
 func (c *Index) setBlockTrieRootByIndex(i uint32, hash trie.Hash) {
 	c.set(keyBlockTrieRootByIndex(i), hash.Bytes())
 }
@@ -302,7 +465,7 @@ func (c *Index) evmDBFromBlockIndex(n uint32) *emulator.BlockchainDB {
 	}
 	state, err := c.stateByTrieRoot(*trieRoot)
 	if err != nil {
-		panic(err)
+		return nil // the state was pruned
 	}
 	return c.blockchainDB(state)
 }
@@ -0,0 +1,488 @@
+package jsonrpcindex
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
+	"github.com/iotaledger/wasp/packages/trie"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+)
+
+// The log index keeps a copy of every EVM log, plus an index by address and
+// by topic, so that eth_getLogs can be answered for arbitrary ranges without
+// loading the chain state of each block. Contrary to the rest of the Index,
+// the logs of every block are indexed, not only the ones about to be pruned.
+//
+// The indexed blocks form a contiguous range [first, last] (see
+// LogsIndexedRange). Blocks outside this range have to be looked up in the
+// chain state.
+
+// LogPosition identifies a log by the block it belongs to and its index in
+// the block.
+type LogPosition struct {
+	BlockNumber uint64
+	LogIndex    uint32
+}
+
+const logPositionSize = 8 + 4
+
+func (p LogPosition) Bytes() []byte {
+	ret := make([]byte, logPositionSize)
+	binary.BigEndian.PutUint64(ret[:8], p.BlockNumber)
+	binary.BigEndian.PutUint32(ret[8:], p.LogIndex)
+	return ret
+}
+
+func logPositionFromKey(key kvstore.Key) LogPosition {
+	b := key[len(key)-logPositionSize:]
+	return LogPosition{
+		BlockNumber: binary.BigEndian.Uint64(b[:8]),
+		LogIndex:    binary.BigEndian.Uint32(b[8:]),
+	}
+}
+
+func (p LogPosition) less(other LogPosition) bool {
+	if p.BlockNumber != other.BlockNumber {
+		return p.BlockNumber < other.BlockNumber
+	}
+	return p.LogIndex < other.LogIndex
+}
+
+// logsIndexBatchSize is the amount of blocks indexed by IndexLogs before it
+// releases the lock of the index, so that the queries are not blocked during a
+// long backfill.
+const logsIndexBatchSize = 100
+
+// IndexLogs indexes the logs of the block with the given trie root and of all
+// its predecessors that are not indexed yet. It walks back the chain until it
+// finds an indexed block with the same hash, so it is used both to backfill
+// the index and to follow new blocks. Blocks that were indexed on a branch
+// that is no longer active are replaced.
+//
+// If the state of an old block is not available (e.g. it was pruned), the
+// walk stops there and the indexed range starts at the next block. The logs
+// of the blocks older than blocksToKeep are deleted from the index.
+//
+// The blocks are indexed in batches. In between, the indexed range (see
+// LogsIndexedRange) only covers the blocks that are consistent with the logs
+// in the index, so that the queries can go on.
+func (c *Index) IndexLogs(trieRoot trie.Hash) error {
+	c.indexLogsMu.Lock()
+	defer c.indexLogsMu.Unlock()
+
+	chainState, err := c.stateByTrieRoot(trieRoot)
+	if err != nil {
+		return err
+	}
+	headIndex := chainState.BlockIndex()
+	firstToKeep := uint64(c.firstIndexToKeep(headIndex))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	first, last, indexed := c.logsIndexedRange()
+
+	// the chain was rolled back to a shorter branch
+	if indexed && last > uint64(headIndex) {
+		oldLast := last
+		last, indexed = c.truncateLogsIndexedRange(first, uint64(headIndex)+1)
+		for n := uint64(headIndex) + 1; n <= oldLast; n++ {
+			c.deleteBlockLogs(n)
+		}
+	}
+
+	// walk back, indexing the blocks until a common ancestor is found
+	reachedAncestor := false
+	newFirst := uint64(headIndex)
+	for i := 1; ; i++ {
+		blockIndex := chainState.BlockIndex()
+		blockNumber := uint64(blockIndex)
+		if blockNumber < firstToKeep {
+			// older blocks are not kept in the index
+			break
+		}
+		db := c.blockchainDB(chainState)
+		blockHash := db.GetBlockHashByBlockNumber(blockNumber)
+
+		if indexed && blockNumber >= first && blockNumber <= last {
+			if c.logsBlockHash(blockNumber) == blockHash {
+				reachedAncestor = true
+				break
+			}
+			// the block belongs to another branch, it is not part of the
+			// indexed range anymore
+			last, indexed = c.truncateLogsIndexedRange(first, blockNumber)
+			c.deleteBlockLogs(blockNumber)
+		}
+		c.addBlockLogs(blockNumber, blockHash, db.GetReceiptsByBlockNumber(blockNumber))
+
+		newFirst = blockNumber
+		if blockIndex == 0 {
+			break
+		}
+		blockInfo, ok := blocklog.NewStateAccess(chainState).BlockInfo(blockIndex)
+		if !ok {
+			return fmt.Errorf("block info %d not found", blockIndex)
+		}
+		chainState, err = c.stateByTrieRoot(blockInfo.PreviousL1Commitment().TrieRoot())
+		if err != nil {
+			// the state is not available anymore, the index starts after it
+			break
+		}
+
+		if i%logsIndexBatchSize == 0 {
+			if err := c.store.Flush(); err != nil {
+				return err
+			}
+			c.mu.Unlock()
+			c.mu.Lock()
+		}
+	}
+	if !reachedAncestor {
+		// no common ancestor: the previously indexed blocks are not contiguous
+		// with the new range anymore
+		if indexed {
+			for n := first; n <= last && n < newFirst; n++ {
+				c.deleteBlockLogs(n)
+			}
+		}
+		first = newFirst
+	}
+	// prune the blocks, which are not kept in the index anymore
+	for ; first < firstToKeep; first++ {
+		c.deleteBlockLogs(first)
+	}
+	c.setLogsIndexedRange(first, uint64(headIndex))
+	return c.store.Flush()
+}
+
+// LogsIndexedRange returns the range of blocks whose logs are indexed.
+func (c *Index) LogsIndexedRange() (first, last uint64, ok bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.logsIndexedRange()
+}
+
+func (c *Index) logsIndexedRange() (first, last uint64, ok bool) {
+	firstBytes := c.get(keyFirstLogsIndexed())
+	lastBytes := c.get(keyLastLogsIndexed())
+	if firstBytes == nil || lastBytes == nil {
+		return 0, 0, false
+	}
+	return binary.BigEndian.Uint64(firstBytes), binary.BigEndian.Uint64(lastBytes), true
+}
+
+// Logs returns the indexed logs in the blocks [from, to] matching the given
+// query, starting at the given position. At most limit logs are returned; if
+// there are more, the position of the next matching log is returned as well.
+func (c *Index) Logs(query *ethereum.FilterQuery, from, to uint64, start LogPosition, limit int) ([]*types.Log, *LogPosition) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if start.less(LogPosition{BlockNumber: from}) {
+		start = LogPosition{BlockNumber: from}
+	}
+
+	// Each prefix iterates the logs in ascending order, so the first limit+1
+	// matching logs of the union are among the first limit+1 of each prefix.
+	matches := make(map[LogPosition]struct{})
+	for _, prefix := range logsQueryPrefixes(query) {
+		count := 0
+		err := c.iterateLogsInRange(prefix, start, to, func(pos LogPosition, value kvstore.Value) bool {
+			header, err := decodeLogHeader(value)
+			if err != nil {
+				panic(err)
+			}
+			if !evmtypes.LogMatches(header, query.Addresses, query.Topics) {
+				return true
+			}
+			matches[pos] = struct{}{}
+			count++
+			return count <= limit
+		})
+		if err != nil {
+			panic(err)
+		}
+	}
+
+	positions := make([]LogPosition, 0, len(matches))
+	for pos := range matches {
+		positions = append(positions, pos)
+	}
+	sort.Slice(positions, func(i, j int) bool { return positions[i].less(positions[j]) })
+
+	var next *LogPosition
+	if len(positions) > limit {
+		next = &positions[limit]
+		positions = positions[:limit]
+	}
+	logs := make([]*types.Log, len(positions))
+	for i, pos := range positions {
+		log, err := decodeLog(pos, c.get(keyLog(pos)))
+		if err != nil {
+			panic(err)
+		}
+		logs[i] = log
+	}
+	return logs, next
+}
+
+// iterateLogsInRange iterates in ascending order the logs with the given
+// prefix, starting at the position start and ending at the block to. As the
+// store can only be iterated by prefix, the keys from start on are covered by
+// the prefix of the start block followed by the prefixes of the ever larger
+// aligned ranges of block numbers after it, so that the logs before start
+// (except the ones of the start block) are never visited.
+func (c *Index) iterateLogsInRange(
+	prefix kvstore.KeyPrefix,
+	start LogPosition,
+	to uint64,
+	f func(pos LogPosition, value kvstore.Value) bool,
+) error {
+	ok := true
+	consumer := func(key kvstore.Key, value kvstore.Value) bool {
+		pos := logPositionFromKey(key)
+		if pos.less(start) {
+			return true
+		}
+		if pos.BlockNumber > to {
+			ok = false
+			return false
+		}
+		ok = f(pos, value)
+		return ok
+	}
+
+	if start.BlockNumber > to {
+		return nil
+	}
+	startBlock := encodeUint64(start.BlockNumber)
+	if err := c.store.Iterate(concatBytes(prefix, startBlock), consumer); err != nil || !ok {
+		return err
+	}
+	for i := len(startBlock) - 1; i >= 0; i-- {
+		for b := int(startBlock[i]) + 1; b <= 0xff; b++ {
+			rangePrefix := concatBytes(startBlock[:i], []byte{byte(b)})
+			// the first block number of the range; the following ranges
+			// start even later
+			first := make([]byte, len(startBlock))
+			copy(first, rangePrefix)
+			if binary.BigEndian.Uint64(first) > to {
+				return nil
+			}
+			if err := c.store.Iterate(concatBytes(prefix, rangePrefix), consumer); err != nil || !ok {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// logsQueryPrefixes returns the index prefixes that contain all the
+// candidates matching the query: the address index if the query filters by
+// address, otherwise the topic index for the first filtered topic position,
+// otherwise the whole log table.
+func logsQueryPrefixes(query *ethereum.FilterQuery) []kvstore.KeyPrefix {
+	var prefixes []kvstore.KeyPrefix
+	if len(query.Addresses) > 0 {
+		for _, addr := range query.Addresses {
+			prefixes = append(prefixes, keyPrefixLogByAddress(addr))
+		}
+		return prefixes
+	}
+	for i, topics := range query.Topics {
+		if len(topics) == 0 {
+			continue
+		}
+		for _, topic := range topics {
+			prefixes = append(prefixes, keyPrefixLogByTopic(i, topic))
+		}
+		return prefixes
+	}
+	return []kvstore.KeyPrefix{{prefixLog}}
+}
+
+func (c *Index) addBlockLogs(blockNumber uint64, blockHash common.Hash, receipts []*types.Receipt) {
+	for _, r := range receipts {
+		for _, log := range r.Logs {
+			pos := LogPosition{BlockNumber: blockNumber, LogIndex: uint32(log.Index)}
+			header := encodeLogHeader(log)
+			c.set(keyLog(pos), encodeLog(log))
+			c.set(keyLogByAddress(log.Address, pos), header)
+			for i, topic := range log.Topics {
+				c.set(keyLogByTopic(i, topic, pos), header)
+			}
+		}
+	}
+	c.set(keyLogsBlockHash(blockNumber), blockHash[:])
+}
+
+func (c *Index) deleteBlockLogs(blockNumber uint64) {
+	var logs []*types.Log
+	err := c.store.Iterate(keyPrefixLogsInBlock(blockNumber), func(key kvstore.Key, value kvstore.Value) bool {
+		log, err := decodeLog(logPositionFromKey(key), value)
+		if err != nil {
+			panic(err)
+		}
+		logs = append(logs, log)
+		return true
+	})
+	if err != nil {
+		panic(err)
+	}
+	for _, log := range logs {
+		pos := LogPosition{BlockNumber: blockNumber, LogIndex: uint32(log.Index)}
+		c.del(keyLog(pos))
+		c.del(keyLogByAddress(log.Address, pos))
+		for i, topic := range log.Topics {
+			c.del(keyLogByTopic(i, topic, pos))
+		}
+	}
+	c.del(keyLogsBlockHash(blockNumber))
+}
+
+func (c *Index) logsBlockHash(blockNumber uint64) common.Hash {
+	return common.BytesToHash(c.get(keyLogsBlockHash(blockNumber)))
+}
+
+func (c *Index) setLogsIndexedRange(first, last uint64) {
+	c.set(keyFirstLogsIndexed(), encodeUint64(first))
+	c.set(keyLastLogsIndexed(), encodeUint64(last))
+}
+
+// truncateLogsIndexedRange removes the blocks from blockNumber on from the
+// indexed range, which may become empty. It must be called before the logs
+// of these blocks are deleted.
+func (c *Index) truncateLogsIndexedRange(first, blockNumber uint64) (last uint64, indexed bool) {
+	if blockNumber <= first {
+		c.del(keyFirstLogsIndexed())
+		c.del(keyLastLogsIndexed())
+		return 0, false
+	}
+	c.setLogsIndexedRange(first, blockNumber-1)
+	return blockNumber - 1, true
+}
+
+func (c *Index) del(key kvstore.Key) {
+	err := c.store.Delete(key)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// keys
+
+func keyFirstLogsIndexed() kvstore.Key {
+	return []byte{prefixFirstLogsIndexed}
+}
+
+func keyLastLogsIndexed() kvstore.Key {
+	return []byte{prefixLastLogsIndexed}
+}
+
+func keyLogsBlockHash(blockNumber uint64) kvstore.Key {
+	return append([]byte{prefixLogsBlockHash}, encodeUint64(blockNumber)...)
+}
+
+func keyPrefixLogsInBlock(blockNumber uint64) kvstore.KeyPrefix {
+	return append([]byte{prefixLog}, encodeUint64(blockNumber)...)
+}
+
+func keyLog(pos LogPosition) kvstore.Key {
+	return append([]byte{prefixLog}, pos.Bytes()...)
+}
+
+func keyPrefixLogByAddress(addr common.Address) kvstore.KeyPrefix {
+	return append([]byte{prefixLogByAddress}, addr[:]...)
+}
+
+func keyLogByAddress(addr common.Address, pos LogPosition) kvstore.Key {
+	return append(keyPrefixLogByAddress(addr), pos.Bytes()...)
+}
+
+func keyPrefixLogByTopic(topicIndex int, topic common.Hash) kvstore.KeyPrefix {
+	key := []byte{prefixLogByTopic, byte(topicIndex)}
+	return append(key, topic[:]...)
+}
+
+func keyLogByTopic(topicIndex int, topic common.Hash, pos LogPosition) kvstore.Key {
+	return append(keyPrefixLogByTopic(topicIndex, topic), pos.Bytes()...)
+}
+
+func concatBytes(a, b []byte) []byte {
+	ret := make([]byte, 0, len(a)+len(b))
+	ret = append(ret, a...)
+	return append(ret, b...)
+}
+
+func encodeUint64(n uint64) []byte {
+	ret := make([]byte, 8)
+	binary.BigEndian.PutUint64(ret, n)
+	return ret
+}
+
+// encoding
+
+// The header contains the fields needed to evaluate a filter query. It is
+// also the beginning of the full log encoding.
+func writeLogHeader(ww *rwutil.Writer, log *types.Log) {
+	ww.WriteN(log.Address[:])
+	ww.WriteSize16(len(log.Topics))
+	for _, topic := range log.Topics {
+		ww.WriteN(topic[:])
+	}
+}
+
+func readLogHeader(rr *rwutil.Reader, log *types.Log) {
+	rr.ReadN(log.Address[:])
+	log.Topics = make([]common.Hash, rr.ReadSize16())
+	for i := range log.Topics {
+		rr.ReadN(log.Topics[i][:])
+	}
+}
+
+func encodeLogHeader(log *types.Log) []byte {
+	ww := rwutil.NewBytesWriter()
+	writeLogHeader(ww, log)
+	return ww.Bytes()
+}
+
+func decodeLogHeader(data []byte) (*types.Log, error) {
+	log := new(types.Log)
+	rr := rwutil.NewBytesReader(data)
+	readLogHeader(rr, log)
+	return log, rr.Err
+}
+
+func encodeLog(log *types.Log) []byte {
+	ww := rwutil.NewBytesWriter()
+	writeLogHeader(ww, log)
+	ww.WriteBytes(log.Data)
+	ww.WriteN(log.BlockHash[:])
+	ww.WriteN(log.TxHash[:])
+	ww.WriteUint32(uint32(log.TxIndex))
+	return ww.Bytes()
+}
+
+func decodeLog(pos LogPosition, data []byte) (*types.Log, error) {
+	log := &types.Log{
+		BlockNumber: pos.BlockNumber,
+		Index:       uint(pos.LogIndex),
+	}
+	rr := rwutil.NewBytesReader(data)
+	readLogHeader(rr, log)
+	log.Data = rr.ReadBytes()
+	rr.ReadN(log.BlockHash[:])
+	rr.ReadN(log.TxHash[:])
+	log.TxIndex = uint(rr.ReadUint32())
+	rr.Close()
+	return log, rr.Err
+}
@@ -0,0 +1,127 @@
+package jsonrpcindex
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/kvstore/mapdb"
+)
+
+var (
+	testLogsAddress = common.HexToAddress("0x1234")
+	testLogsTopic   = common.HexToHash("0xabcd")
+)
+
+// newTestLogsIndex returns an index with 2 logs in each block of [1, blocks].
+// The first log of each block has a topic.
+func newTestLogsIndex(blocks uint64) *Index {
+	c := &Index{store: mapdb.NewMapDB()}
+	for n := uint64(1); n <= blocks; n++ {
+		receipt := &types.Receipt{Logs: []*types.Log{
+			{Address: testLogsAddress, Topics: []common.Hash{testLogsTopic}, Index: 0},
+			{Address: testLogsAddress, Index: 1},
+		}}
+		c.addBlockLogs(n, common.Hash{}, []*types.Receipt{receipt})
+	}
+	c.setLogsIndexedRange(1, blocks)
+	return c
+}
+
+func logPositions(logs []*types.Log) []LogPosition {
+	ret := make([]LogPosition, len(logs))
+	for i, log := range logs {
+		ret[i] = LogPosition{BlockNumber: log.BlockNumber, LogIndex: uint32(log.Index)}
+	}
+	return ret
+}
+
+func TestLogsStartPosition(t *testing.T) {
+	c := newTestLogsIndex(10)
+	query := &ethereum.FilterQuery{}
+
+	logs, next := c.Logs(query, 3, 4, LogPosition{BlockNumber: 3, LogIndex: 1}, 10)
+	require.Nil(t, next)
+	require.Equal(t, []LogPosition{{3, 1}, {4, 0}, {4, 1}}, logPositions(logs))
+
+	// a start before the range is moved to the start of the range
+	logs, next = c.Logs(query, 3, 4, LogPosition{BlockNumber: 1, LogIndex: 1}, 10)
+	require.Nil(t, next)
+	require.Equal(t, []LogPosition{{3, 0}, {3, 1}, {4, 0}, {4, 1}}, logPositions(logs))
+
+	// a start after the range returns nothing
+	logs, next = c.Logs(query, 3, 4, LogPosition{BlockNumber: 5}, 10)
+	require.Nil(t, next)
+	require.Empty(t, logs)
+}
+
+func TestLogsBatchLimit(t *testing.T) {
+	c := newTestLogsIndex(10)
+
+	logs, next := c.Logs(&ethereum.FilterQuery{}, 2, 9, LogPosition{BlockNumber: 2}, 3)
+	require.Equal(t, []LogPosition{{2, 0}, {2, 1}, {3, 0}}, logPositions(logs))
+	require.Equal(t, &LogPosition{BlockNumber: 3, LogIndex: 1}, next)
+
+	// the next position is the next matching log
+	query := &ethereum.FilterQuery{Topics: [][]common.Hash{{testLogsTopic}}}
+	logs, next = c.Logs(query, 2, 9, LogPosition{BlockNumber: 2}, 3)
+	require.Equal(t, []LogPosition{{2, 0}, {3, 0}, {4, 0}}, logPositions(logs))
+	require.Equal(t, &LogPosition{BlockNumber: 5}, next)
+
+	// exactly limit logs: no next position
+	logs, next = c.Logs(query, 2, 4, LogPosition{BlockNumber: 2}, 3)
+	require.Len(t, logs, 3)
+	require.Nil(t, next)
+}
+
+func TestLogsPaging(t *testing.T) {
+	// the range crosses the boundaries of the block number bytes
+	c := newTestLogsIndex(600)
+	queries := []*ethereum.FilterQuery{
+		{},
+		{Addresses: []common.Address{testLogsAddress}},
+		{Topics: [][]common.Hash{{testLogsTopic}}},
+	}
+	for _, query := range queries {
+		var all []*types.Log
+		start := LogPosition{BlockNumber: 200}
+		for {
+			logs, next := c.Logs(query, 200, 520, start, 7)
+			require.LessOrEqual(t, len(logs), 7)
+			all = append(all, logs...)
+			if next == nil {
+				break
+			}
+			require.True(t, start.less(*next))
+			start = *next
+		}
+		expected, _ := c.Logs(query, 200, 520, LogPosition{BlockNumber: 200}, 10_000)
+		require.Equal(t, logPositions(expected), logPositions(all))
+		require.Equal(t, LogPosition{BlockNumber: 200}, logPositions(all)[0])
+		require.EqualValues(t, 520, all[len(all)-1].BlockNumber)
+	}
+}
+
+func TestIterateLogsInRange(t *testing.T) {
+	c := newTestLogsIndex(600)
+	var visited []LogPosition
+	err := c.iterateLogsInRange(kvstore.KeyPrefix{prefixLog}, LogPosition{BlockNumber: 255, LogIndex: 1}, 257, func(pos LogPosition, _ kvstore.Value) bool {
+		visited = append(visited, pos)
+		return true
+	})
+	require.NoError(t, err)
+	require.Equal(t, []LogPosition{{255, 1}, {256, 0}, {256, 1}, {257, 0}, {257, 1}}, visited)
+
+	// the iteration stops when the callback returns false
+	visited = nil
+	err = c.iterateLogsInRange(kvstore.KeyPrefix{prefixLog}, LogPosition{BlockNumber: 10}, 600, func(pos LogPosition, _ kvstore.Value) bool {
+		visited = append(visited, pos)
+		return len(visited) < 3
+	})
+	require.NoError(t, err)
+	require.Equal(t, []LogPosition{{10, 0}, {10, 1}, {11, 0}}, visited)
+}
@@ -138,6 +138,23 @@ func (e *Env) BlockByNumber(number *big.Int) *types.Block {
 	return block
 }
 
+// BlockHashByNumber returns the hash of the block as reported by the server.
+// The hash of the block returned by BlockByNumber is computed by the client
+// from the header fields, including the base fee, which is not part of the
+// block hash.
+func (e *Env) BlockHashByNumber(number *big.Int) common.Hash {
+	var res struct {
+		Hash common.Hash `json:"hash"`
+	}
+	blockNumber := "latest"
+	if number != nil {
+		blockNumber = hexutil.EncodeBig(number)
+	}
+	err := e.RawClient.Call(&res, "eth_getBlockByNumber", blockNumber, false)
+	require.NoError(e.T, err)
+	return res.Hash
+}
+
 func (e *Env) BlockByHash(hash common.Hash) *types.Block {
 	block, err := e.Client.BlockByHash(context.Background(), hash)
 	if errors.Is(err, ethereum.NotFound) {
@@ -291,6 +308,7 @@ func (e *Env) TestRPCGetLogs() {
 	contractAddress := crypto.CreateAddress(creatorAddress, e.NonceAt(creatorAddress))
 
 	filterQuery := ethereum.FilterQuery{
+		FromBlock: big.NewInt(0),
 		Addresses: []common.Address{contractAddress},
 	}
 
@@ -320,6 +338,13 @@ func (e *Env) TestRPCGetLogs() {
 	transferReceipt := e.mustSendTransactionAndWait(transferTx)
 	require.Equal(e.T, 1, len(transferReceipt.Logs))
 	require.Equal(e.T, 2, len(e.getLogs(filterQuery)))
+
+	// an unset fromBlock is the latest block (ethclient always sets it)
+	var latestLogs []types.Log
+	err = e.RawClient.Call(&latestLogs, "eth_getLogs", map[string]any{"address": contractAddress})
+	require.NoError(e.T, err)
+	require.Len(e.T, latestLogs, 1)
+	require.Equal(e.T, transferTx.Hash(), latestLogs[0].TxHash)
 }
 
 func (e *Env) TestRPCInvalidNonce() {
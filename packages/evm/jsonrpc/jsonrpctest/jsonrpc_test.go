@@ -5,15 +5,18 @@ package jsonrpctest
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -26,9 +29,14 @@ import (
 	"github.com/iotaledger/wasp/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/origin"
 	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/packages/vm/core/evm/iscmagic"
 )
 
 type soloTestEnv struct {
@@ -37,7 +45,7 @@ type soloTestEnv struct {
 	soloChain *solo.Chain
 }
 
-func newSoloTestEnv(t testing.TB) *soloTestEnv {
+func newSoloTestEnv(t testing.TB, originParams ...dict.Dict) *soloTestEnv {
 	var log *logger.Logger
 	if _, ok := t.(*testing.B); ok {
 		log = testlogger.NewSilentLogger(t.Name(), true)
@@ -50,7 +58,7 @@ func newSoloTestEnv(t testing.TB) *soloTestEnv {
 		Log:                      log,
 	})
 	chainOwner, _ := s.NewKeyPairWithFunds()
-	chain, _ := s.NewChainExt(chainOwner, 0, "chain1")
+	chain, _ := s.NewChainExt(chainOwner, 0, "chain1", originParams...)
 
 	accounts := jsonrpc.NewAccountManager(nil)
 	rpcsrv, err := jsonrpc.NewServer(chain.EVM(), accounts, chain.GetChainMetrics().WebAPI)
@@ -150,9 +158,9 @@ func TestRPCGetBlockByHash(t *testing.T) {
 	env := newSoloTestEnv(t)
 	require.Nil(t, env.BlockByHash(common.Hash{}))
 	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
-	require.EqualValues(t, 0, env.BlockByHash(env.BlockByNumber(big.NewInt(0)).Hash()).Number().Uint64())
+	require.EqualValues(t, 0, env.BlockByHash(env.BlockHashByNumber(big.NewInt(0))).Number().Uint64())
 	env.deployStorageContract(creator)
-	require.EqualValues(t, 1, env.BlockByHash(env.BlockByNumber(big.NewInt(1)).Hash()).Number().Uint64())
+	require.EqualValues(t, 1, env.BlockByHash(env.BlockHashByNumber(big.NewInt(1))).Number().Uint64())
 }
 
 func TestRPCGetTransactionByHash(t *testing.T) {
@@ -165,13 +173,56 @@ func TestRPCGetTransactionByHash(t *testing.T) {
 	require.Equal(t, block.Transactions()[0].Hash(), tx.Hash())
 }
 
+func TestRPCTxPool(t *testing.T) {
+	env := newSoloTestEnv(t)
+	sender, senderAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, recipientAddress := solo.NewEthereumAccount()
+
+	// nonces 0 and 1 are contiguous (pending), 3 is after a gap (queued)
+	var txs []*types.Transaction
+	var reqs []isc.Request
+	for _, nonce := range []uint64{0, 1, 3} {
+		tx, err := types.SignTx(
+			types.NewTransaction(nonce, recipientAddress, big.NewInt(1), params.TxGas, evm.GasPrice, nil),
+			env.Signer(),
+			sender,
+		)
+		require.NoError(t, err)
+		req, err := isc.NewEVMOffLedgerTxRequest(env.soloChain.ChainID, tx)
+		require.NoError(t, err)
+		txs = append(txs, tx)
+		reqs = append(reqs, req)
+	}
+	env.solo.AddRequestsToMempool(env.soloChain, reqs)
+
+	var status map[string]hexutil.Uint
+	require.NoError(t, env.RawClient.Call(&status, "txpool_status"))
+	require.EqualValues(t, 2, status["pending"])
+	require.EqualValues(t, 1, status["queued"])
+
+	var content map[string]map[string]map[string]*jsonrpc.RPCTransaction
+	require.NoError(t, env.RawClient.Call(&content, "txpool_content"))
+	require.Len(t, content["pending"][senderAddress.Hex()], 2)
+	require.Equal(t, txs[1].Hash(), content["pending"][senderAddress.Hex()]["1"].Hash)
+	require.Equal(t, txs[2].Hash(), content["queued"][senderAddress.Hex()]["3"].Hash)
+
+	var inspect map[string]map[string]map[string]string
+	require.NoError(t, env.RawClient.Call(&inspect, "txpool_inspect"))
+	require.Contains(t, inspect["queued"][senderAddress.Hex()]["3"], recipientAddress.Hex())
+
+	var pendingTx *jsonrpc.RPCTransaction
+	require.NoError(t, env.RawClient.Call(&pendingTx, "eth_getTransactionByHash", txs[2].Hash()))
+	require.Equal(t, txs[2].Hash(), pendingTx.Hash)
+	require.Nil(t, pendingTx.BlockHash)
+}
+
 func TestRPCGetTransactionByBlockHashAndIndex(t *testing.T) {
 	env := newSoloTestEnv(t)
 	require.Nil(t, env.TransactionByBlockHashAndIndex(common.Hash{}, 0))
 	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
 	env.deployStorageContract(creator)
 	block := env.BlockByNumber(new(big.Int).SetUint64(env.BlockNumber()))
-	tx := env.TransactionByBlockHashAndIndex(block.Hash(), 0)
+	tx := env.TransactionByBlockHashAndIndex(env.BlockHashByNumber(block.Number()), 0)
 	require.Equal(t, block.Transactions()[0].Hash(), tx.Hash())
 }
 
@@ -181,7 +232,7 @@ func TestRPCGetUncleByBlockHashAndIndex(t *testing.T) {
 	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
 	env.deployStorageContract(creator)
 	block := env.BlockByNumber(new(big.Int).SetUint64(env.BlockNumber()))
-	require.Nil(t, env.UncleByBlockHashAndIndex(block.Hash(), 0))
+	require.Nil(t, env.UncleByBlockHashAndIndex(env.BlockHashByNumber(block.Number()), 0))
 }
 
 func TestRPCGetTransactionByBlockNumberAndIndex(t *testing.T) {
@@ -194,7 +245,7 @@ func TestRPCGetTransactionByBlockNumberAndIndex(t *testing.T) {
 	block := env.BlockByNumber(new(big.Int).SetUint64(env.BlockNumber()))
 	tx, err := env.TransactionByBlockNumberAndIndex(block.Number(), 0)
 	require.NoError(t, err)
-	require.EqualValues(t, block.Hash(), *tx.BlockHash)
+	require.EqualValues(t, env.BlockHashByNumber(block.Number()), *tx.BlockHash)
 	require.EqualValues(t, 0, *tx.TransactionIndex)
 }
 
@@ -213,7 +264,7 @@ func TestRPCGetTransactionCountByHash(t *testing.T) {
 	env.deployStorageContract(creator)
 	block := env.BlockByNumber(new(big.Int).SetUint64(env.BlockNumber()))
 	require.Positive(t, len(block.Transactions()))
-	require.EqualValues(t, len(block.Transactions()), env.BlockTransactionCountByHash(block.Hash()))
+	require.EqualValues(t, len(block.Transactions()), env.BlockTransactionCountByHash(env.BlockHashByNumber(block.Number())))
 	require.EqualValues(t, 0, env.BlockTransactionCountByHash(common.Hash{}))
 }
 
@@ -223,7 +274,7 @@ func TestRPCGetUncleCountByBlockHash(t *testing.T) {
 	env.deployStorageContract(creator)
 	block := env.BlockByNumber(new(big.Int).SetUint64(env.BlockNumber()))
 	require.Zero(t, len(block.Uncles()))
-	require.EqualValues(t, len(block.Uncles()), env.UncleCountByBlockHash(block.Hash()))
+	require.EqualValues(t, len(block.Uncles()), env.UncleCountByBlockHash(env.BlockHashByNumber(block.Number())))
 	require.EqualValues(t, 0, env.UncleCountByBlockHash(common.Hash{}))
 }
 
@@ -330,10 +381,39 @@ func TestRPCGetTxReceipt(t *testing.T) {
 	require.NotZero(t, receipt.GasUsed)
 
 	require.EqualValues(t, big.NewInt(2), receipt.BlockNumber)
-	require.EqualValues(t, env.BlockByNumber(big.NewInt(2)).Hash(), receipt.BlockHash)
+	require.EqualValues(t, env.BlockHashByNumber(big.NewInt(2)), receipt.BlockHash)
 	require.EqualValues(t, 0, receipt.TransactionIndex)
 }
 
+func TestRPCPrunedBlocksFromIndex(t *testing.T) {
+	env := newSoloTestEnv(t, dict.Dict{
+		origin.ParamBlockKeepAmount: codec.EncodeInt32(3),
+	})
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+
+	contractABI, err := abi.JSON(strings.NewReader(evmtest.StorageContractABI))
+	require.NoError(t, err)
+	tx, receipt, _ := env.DeployEVMContract(creator, contractABI, evmtest.StorageContractBytecode, uint32(42))
+	blockHash := env.BlockHashByNumber(receipt.BlockNumber)
+
+	// the block of the deployment is pruned from the active state
+	for i := 0; i < 5; i++ {
+		_, err = env.SendTransactionAndWait(types.MustSignNewTx(creator, env.Signer(), &types.LegacyTx{
+			Nonce:    env.NonceAt(creatorAddress),
+			To:       &creatorAddress,
+			Gas:      100_000,
+			GasPrice: evm.GasPrice,
+		}))
+		require.NoError(t, err)
+	}
+	require.Greater(t, env.BlockNumber(), receipt.BlockNumber.Uint64()+3)
+
+	require.Equal(t, blockHash, env.BlockHashByNumber(receipt.BlockNumber))
+	require.Equal(t, receipt.BlockNumber.Uint64(), env.BlockByHash(blockHash).NumberU64())
+	require.Equal(t, tx.Hash(), env.TransactionByHash(tx.Hash()).Hash())
+	require.Equal(t, receipt, env.MustTxReceipt(tx.Hash()))
+}
+
 func TestRPCGetTxReceiptMissing(t *testing.T) {
 	env := newSoloTestEnv(t)
 
@@ -422,6 +502,209 @@ func TestRPCLogIndex(t *testing.T) {
 	require.EqualValues(t, 1, logs[1].Index)
 }
 
+func TestRPCGetLogsIndexed(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+	require.NoError(t, err)
+	_, _, contractAddress := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+
+	_, recipient1 := solo.NewEthereumAccount()
+	_, recipient2 := solo.NewEthereumAccount()
+	for _, recipient := range []common.Address{recipient1, recipient2, recipient1} {
+		callArguments, err := contractABI.Pack("transfer", recipient, big.NewInt(1))
+		require.NoError(t, err)
+		tx, err := types.SignTx(
+			types.NewTransaction(env.NonceAt(creatorAddress), contractAddress, big.NewInt(0), 100_000, evm.GasPrice, callArguments),
+			env.Signer(),
+			creator,
+		)
+		require.NoError(t, err)
+		env.mustSendTransactionAndWait(tx)
+	}
+
+	transferTopic := contractABI.Events["Transfer"].ID
+	earliest := big.NewInt(0)
+	queries := map[*ethereum.FilterQuery]int{
+		{FromBlock: earliest, Addresses: []common.Address{contractAddress}}:                                       4, // mint + 3 transfers
+		{FromBlock: earliest, Topics: [][]common.Hash{{transferTopic}}}:                                           4,
+		{FromBlock: earliest, Topics: [][]common.Hash{{transferTopic}, nil, {common.BytesToHash(recipient1[:])}}}: 2,
+		{FromBlock: earliest, Topics: [][]common.Hash{nil, nil, {common.BytesToHash(recipient2[:])}}}:             1,
+		{FromBlock: earliest, Addresses: []common.Address{recipient1}, Topics: [][]common.Hash{{transferTopic}}}:  0,
+		{FromBlock: earliest, ToBlock: big.NewInt(1), Topics: [][]common.Hash{{transferTopic}}}:                   0,
+	}
+
+	// A new EVMChain backfills the log index from the current state. The
+	// states of the old blocks are not available to the queries, so they are
+	// answered only once the index covers them.
+	evmChain := env.soloChain.EVMWithBackend(&prunedStatesBackend{ChainBackend: env.soloChain.EVMBackend()})
+	for q, n := range queries {
+		require.Eventually(t, func() bool {
+			logs, err := evmChain.Logs(q)
+			return err == nil && len(logs) == n
+		}, 5*time.Second, 100*time.Millisecond)
+		require.Len(t, env.getLogs(*q), n)
+	}
+}
+
+// prunedStatesBackend is a backend whose chain states can't be looked up by
+// block index, as if they were pruned. The log index uses the trie roots.
+type prunedStatesBackend struct {
+	jsonrpc.ChainBackend
+}
+
+func (*prunedStatesBackend) ISCStateByBlockIndex(blockIndex uint32) (state.State, error) {
+	return nil, fmt.Errorf("state of block %d was pruned", blockIndex)
+}
+
+func TestRPCTraceBlockAndCall(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	tx, contractAddress, contractABI := env.deployStorageContract(creator)
+
+	var results []jsonrpc.TxTraceResult
+	err := env.RawClient.Call(&results, "debug_traceBlockByNumber", hexutil.Uint64(env.BlockNumber()), map[string]any{"tracer": "callTracer"})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, tx.Hash(), results[0].TxHash)
+
+	blockHash := env.BlockHashByNumber(nil)
+	err = env.RawClient.Call(&results, "debug_traceBlockByHash", blockHash, nil)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	require.Equal(t, tx.Hash(), results[0].TxHash)
+
+	callData, err := contractABI.Pack("retrieve")
+	require.NoError(t, err)
+	var frame jsonrpc.CallFrame
+	err = env.RawClient.Call(&frame, "debug_traceCall", map[string]any{
+		"from": creatorAddress,
+		"to":   contractAddress,
+		"data": hexutil.Bytes(callData),
+	}, "latest", map[string]any{
+		"tracer": "callTracer",
+		"stateOverrides": map[common.Address]any{
+			contractAddress: map[string]any{
+				"stateDiff": map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(43))},
+			},
+		},
+	})
+	require.NoError(t, err)
+	require.Equal(t, common.BigToHash(big.NewInt(43)), common.HexToHash(frame.Output))
+}
+
+func TestRPCTraceCallBlockTime(t *testing.T) {
+	// the logical clock of solo is far from the current time
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	env.deployStorageContract(creator)
+
+	sandboxABI, err := abi.JSON(strings.NewReader(iscmagic.SandboxABI))
+	require.NoError(t, err)
+	callData, err := sandboxABI.Pack("getTimestampUnixSeconds")
+	require.NoError(t, err)
+	var frame jsonrpc.CallFrame
+	err = env.RawClient.Call(&frame, "debug_traceCall", map[string]any{
+		"from": creatorAddress,
+		"to":   iscmagic.Address,
+		"data": hexutil.Bytes(callData),
+	}, "latest", map[string]any{"tracer": "callTracer"})
+	require.NoError(t, err)
+
+	var timestamp int64
+	require.NoError(t, sandboxABI.UnpackIntoInterface(&timestamp, "getTimestampUnixSeconds", hexutil.MustDecode(frame.Output)))
+	require.EqualValues(t, env.BlockByNumber(nil).Time(), timestamp)
+}
+
+func TestRPCFeeHistory(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	env.deployStorageContract(creator)
+
+	gasPrice, err := env.Client.SuggestGasPrice(context.Background())
+	require.NoError(t, err)
+
+	// the base fee of the block is the gas price given by the fee policy in
+	// the state it ran on; it is not part of the block hash
+	block := env.BlockByNumber(nil)
+	require.Equal(t, gasPrice, block.BaseFee())
+	require.EqualValues(t, block.NumberU64(), env.BlockByHash(env.BlockHashByNumber(block.Number())).NumberU64())
+
+	tip, err := env.Client.SuggestGasTipCap(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, tip.Sign())
+
+	feeHistory, err := env.Client.FeeHistory(context.Background(), 10, nil, []float64{25, 75})
+	require.NoError(t, err)
+	require.EqualValues(t, 0, feeHistory.OldestBlock.Uint64())
+	require.Len(t, feeHistory.BaseFee, int(block.NumberU64())+2)
+	require.Len(t, feeHistory.GasUsedRatio, int(block.NumberU64())+1)
+	require.Len(t, feeHistory.Reward, int(block.NumberU64())+1)
+	for _, baseFee := range feeHistory.BaseFee {
+		require.Equal(t, gasPrice, baseFee)
+	}
+	require.Equal(t, feeHistory.BaseFee[block.NumberU64()], block.BaseFee())
+	require.Positive(t, feeHistory.GasUsedRatio[len(feeHistory.GasUsedRatio)-1])
+	for _, reward := range feeHistory.Reward {
+		require.Len(t, reward, 2)
+		require.Zero(t, reward[0].Sign())
+	}
+
+	feeHistory, err = env.Client.FeeHistory(context.Background(), 1, big.NewInt(1), nil)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, feeHistory.OldestBlock.Uint64())
+	require.Len(t, feeHistory.BaseFee, 2)
+	require.Empty(t, feeHistory.Reward)
+
+	_, err = env.Client.FeeHistory(context.Background(), 1, nil, []float64{75, 25})
+	require.ErrorContains(t, err, "invalid reward percentile")
+}
+
+func TestRPCGetProof(t *testing.T) {
+	env := newSoloTestEnv(t)
+	creator, creatorAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+	_, contractAddress, _ := env.deployStorageContract(creator)
+
+	var res jsonrpc.ProofResult
+	err := env.RawClient.Call(&res, "evm_getProof", contractAddress, []common.Hash{{}, common.HexToHash("0x01")}, "latest")
+	require.NoError(t, err)
+	require.EqualValues(t, env.BlockNumber(), res.BlockNumber)
+
+	// the proofs can be verified against the state commitment on L1
+	stateRoot := env.soloChain.GetL1Commitment().TrieRoot()
+	require.EqualValues(t, stateRoot.Bytes(), res.StateRoot)
+
+	require.EqualValues(t, 1, res.Nonce)
+	require.NoError(t, res.NonceProof.Verify(stateRoot))
+	code, err := env.Client.CodeAt(context.Background(), contractAddress, nil)
+	require.NoError(t, err)
+	require.Equal(t, crypto.Keccak256Hash(code), res.CodeHash)
+	require.EqualValues(t, code, res.CodeProof.Value)
+	require.NoError(t, res.CodeProof.Verify(stateRoot))
+	require.Zero(t, res.Balance.ToInt().Sign())
+	require.Empty(t, res.BalanceProof.Value)
+	require.NoError(t, res.BalanceProof.Verify(stateRoot))
+
+	require.Len(t, res.StorageProof, 2)
+	require.Equal(t, common.BigToHash(big.NewInt(42)), res.StorageProof[0].Value)
+	require.NoError(t, res.StorageProof[0].Proof.Verify(stateRoot))
+	require.Equal(t, common.Hash{}, res.StorageProof[1].Value)
+	require.NoError(t, res.StorageProof[1].Proof.Verify(stateRoot))
+
+	// tampered proofs are rejected
+	res.StorageProof[0].Proof.Value = common.BigToHash(big.NewInt(43)).Bytes()
+	require.Error(t, res.StorageProof[0].Proof.Verify(stateRoot))
+	res.NonceProof.Key = res.CodeProof.Key
+	require.Error(t, res.NonceProof.Verify(stateRoot))
+
+	err = env.RawClient.Call(&res, "evm_getProof", creatorAddress, []common.Hash{}, "latest")
+	require.NoError(t, err)
+	require.Positive(t, res.Balance.ToInt().Sign())
+	require.NoError(t, res.BalanceProof.Verify(stateRoot))
+	require.Empty(t, res.CodeProof.Value)
+	require.NoError(t, res.CodeProof.Verify(stateRoot))
+}
+
 func TestRPCEthChainID(t *testing.T) {
 	env := newSoloTestEnv(t)
 	var chainID hexutil.Uint
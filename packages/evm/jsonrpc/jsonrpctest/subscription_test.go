@@ -5,6 +5,7 @@ package jsonrpctest
 
 import (
 	"context"
+	"math/big"
 	"strings"
 	"testing"
 	"time"
@@ -14,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/require"
 
 	"github.com/iotaledger/wasp/packages/evm/evmtest"
@@ -40,6 +42,9 @@ func TestSubscriptionNewHeads(t *testing.T) {
 		select {
 		case header := <-ch:
 			require.EqualValues(t, 1, header.Number.Uint64())
+			// the same base fee as in the block
+			require.NotNil(t, header.BaseFee)
+			require.Equal(t, env.BlockByNumber(header.Number).BaseFee(), header.BaseFee)
 			return
 
 		case err := <-sub.Err():
@@ -88,3 +93,66 @@ func TestSubscriptionLogs(t *testing.T) {
 		}
 	}
 }
+
+func TestPollingFilterNewBlocks(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	var filterID rpc.ID
+	err := env.RawClient.Call(&filterID, "eth_newBlockFilter")
+	require.NoError(t, err)
+
+	_, _ = env.soloChain.NewEthereumAccountWithL2Funds()
+
+	var hashes []common.Hash
+	require.Eventually(t, func() bool {
+		var changes []common.Hash
+		err := env.RawClient.Call(&changes, "eth_getFilterChanges", filterID)
+		require.NoError(t, err)
+		hashes = append(hashes, changes...)
+		return len(hashes) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Equal(t, env.BlockHashByNumber(big.NewInt(1)), hashes[0])
+
+	var uninstalled bool
+	err = env.RawClient.Call(&uninstalled, "eth_uninstallFilter", filterID)
+	require.NoError(t, err)
+	require.True(t, uninstalled)
+
+	var changes []common.Hash
+	err = env.RawClient.Call(&changes, "eth_getFilterChanges", filterID)
+	require.ErrorContains(t, err, "filter not found")
+}
+
+func TestPollingFilterLogs(t *testing.T) {
+	env := newSoloTestEnv(t)
+
+	creator, creatorAddress := env.NewAccountWithL2Funds()
+	contractABI, err := abi.JSON(strings.NewReader(evmtest.ERC20ContractABI))
+	require.NoError(env.T, err)
+	contractAddress := crypto.CreateAddress(creatorAddress, env.NonceAt(creatorAddress))
+
+	var filterID rpc.ID
+	err = env.RawClient.Call(&filterID, "eth_newFilter", map[string]any{
+		"address": contractAddress,
+	})
+	require.NoError(t, err)
+
+	_, receipt, _ := env.DeployEVMContract(creator, contractABI, evmtest.ERC20ContractBytecode, "TestCoin", "TEST")
+	require.Equal(env.T, 1, len(receipt.Logs))
+
+	var logs []types.Log
+	require.Eventually(t, func() bool {
+		var changes []types.Log
+		err := env.RawClient.Call(&changes, "eth_getFilterChanges", filterID)
+		require.NoError(t, err)
+		logs = append(logs, changes...)
+		return len(logs) > 0
+	}, 5*time.Second, 50*time.Millisecond)
+	require.Len(t, logs, 1)
+	require.Equal(t, receipt.TxHash, logs[0].TxHash)
+
+	var allLogs []types.Log
+	err = env.RawClient.Call(&allLogs, "eth_getFilterLogs", filterID)
+	require.NoError(t, err)
+	require.Len(t, allLogs, 1)
+}
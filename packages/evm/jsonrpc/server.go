@@ -24,7 +24,7 @@ func NewServer(
 		{"net", NewNetService(int(chainID))},
 		{"eth", NewEthService(evmChain, accountManager, metrics)},
 		{"debug", NewDebugService(evmChain, metrics)},
-		{"txpool", NewTxPoolService()},
+		{"txpool", NewTxPoolService(evmChain, metrics)},
 		{"evm", NewEVMService(evmChain)},
 	} {
 		err := rpcsrv.RegisterName(srv.namespace, srv.service)
@@ -9,11 +9,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/protocols/eth"
@@ -37,6 +39,7 @@ type EthService struct {
 	evmChain *EVMChain
 	accounts *AccountManager
 	metrics  *metrics.ChainWebAPIMetrics
+	filters  *filterRegistry
 }
 
 func NewEthService(evmChain *EVMChain, accounts *AccountManager, metrics *metrics.ChainWebAPIMetrics) *EthService {
@@ -44,6 +47,7 @@ func NewEthService(evmChain *EVMChain, accounts *AccountManager, metrics *metric
 		evmChain: evmChain,
 		accounts: accounts,
 		metrics:  metrics,
+		filters:  newFilterRegistry(evmChain, filterTimeout),
 	}
 }
 
@@ -121,7 +125,26 @@ func (e *EthService) getBlockByNumber(blockNumber rpc.BlockNumber, full bool) (m
 	if block == nil {
 		return nil, nil
 	}
-	return RPCMarshalBlock(block, true, full)
+	return e.marshalBlock(block, full)
+}
+
+// marshalBlock returns the RPC output of the block, with its base fee.
+func (e *EthService) marshalBlock(block *types.Block, full bool) (map[string]interface{}, error) {
+	fields, err := RPCMarshalBlock(block, true, full)
+	if err != nil {
+		return nil, err
+	}
+	e.addBaseFee(fields, block.Number())
+	return fields, nil
+}
+
+// addBaseFee adds the base fee of the block to its RPC output. The base fee is
+// not part of the block header, so the hash stays the stored one.
+func (e *EthService) addBaseFee(fields map[string]interface{}, blockNumber *big.Int) {
+	// the base fee is unknown if the state the block ran on was pruned
+	if baseFee, err := e.evmChain.baseFee(blockNumber); err == nil {
+		fields["baseFeePerGas"] = (*hexutil.Big)(baseFee)
+	}
 }
 
 func (e *EthService) GetBlockByNumber(blockNumber rpc.BlockNumber, full bool) (map[string]interface{}, error) {
@@ -138,7 +161,7 @@ func (e *EthService) getBlockByHash(hash common.Hash, full bool) (map[string]int
 	if block == nil {
 		return nil, nil
 	}
-	return RPCMarshalBlock(block, true, full)
+	return e.marshalBlock(block, full)
 }
 
 func (e *EthService) GetBlockByHash(hash common.Hash, full bool) (map[string]interface{}, error) {
@@ -156,11 +179,22 @@ func (e *EthService) getTransactionByHash(hash common.Hash) (*RPCTransaction, er
 		return nil, e.resolveError(err)
 	}
 	if tx == nil {
-		return nil, nil
+		return e.getPendingTransactionByHash(hash)
 	}
 	return newRPCTransaction(tx, blockHash, blockNumber, index), err
 }
 
+func (e *EthService) getPendingTransactionByHash(hash common.Hash) (*RPCTransaction, error) {
+	tx, err := e.evmChain.PendingTransactionByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	if tx == nil {
+		return nil, nil
+	}
+	return newRPCTransaction(tx, common.Hash{}, 0, 0), nil
+}
+
 func (e *EthService) GetTransactionByHash(hash common.Hash) (*RPCTransaction, error) {
 	return withMetrics(
 		e.metrics, "eth_getTransactionByHash",
@@ -392,6 +426,26 @@ func (e *EthService) GasPrice() (*hexutil.Big, error) {
 	)
 }
 
+// MaxPriorityFeePerGas always returns 0, since ISC does not charge priority
+// fees: the gas price is fixed by the chain's fee policy.
+func (e *EthService) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	return withMetrics(
+		e.metrics, "eth_maxPriorityFeePerGas",
+		func() (*hexutil.Big, error) {
+			return (*hexutil.Big)(big.NewInt(0)), nil
+		},
+	)
+}
+
+func (e *EthService) FeeHistory(blockCount math.HexOrDecimal64, newestBlock rpc.BlockNumber, rewardPercentiles []float64) (*FeeHistoryResult, error) {
+	return withMetrics(
+		e.metrics, "eth_feeHistory",
+		func() (*FeeHistoryResult, error) {
+			return e.evmChain.FeeHistory(uint64(blockCount), parseBlockNumber(newestBlock), rewardPercentiles)
+		},
+	)
+}
+
 func (e *EthService) Mining() bool {
 	return false
 }
@@ -544,7 +598,9 @@ func (e *EthService) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
 		for {
 			select {
 			case h := <-headers:
-				_ = notifier.Notify(rpcSub.ID, h)
+				fields := RPCMarshalHeader(h)
+				e.addBaseFee(fields, h.Number)
+				_ = notifier.Notify(rpcSub.ID, fields)
 			case <-rpcSub.Err():
 				return
 			case <-notifier.Closed():
@@ -586,14 +642,74 @@ func (e *EthService) Logs(ctx context.Context, q *RPCFilterQuery) (*rpc.Subscrip
 	return rpcSub, nil
 }
 
+func (e *EthService) NewFilter(q *RPCFilterQuery) (rpc.ID, error) {
+	return withMetrics(
+		e.metrics, "eth_newFilter",
+		func() (rpc.ID, error) {
+			return e.filters.newLogsFilter((*ethereum.FilterQuery)(q)), nil
+		},
+	)
+}
+
+func (e *EthService) NewBlockFilter() (rpc.ID, error) {
+	return withMetrics(
+		e.metrics, "eth_newBlockFilter",
+		func() (rpc.ID, error) {
+			return e.filters.newBlocksFilter(), nil
+		},
+	)
+}
+
+func (e *EthService) NewPendingTransactionFilter() (rpc.ID, error) {
+	return withMetrics(
+		e.metrics, "eth_newPendingTransactionFilter",
+		func() (rpc.ID, error) {
+			return e.filters.newPendingTxFilter(), nil
+		},
+	)
+}
+
+func (e *EthService) UninstallFilter(id rpc.ID) (bool, error) {
+	return withMetrics(
+		e.metrics, "eth_uninstallFilter",
+		func() (bool, error) {
+			return e.filters.uninstall(id), nil
+		},
+	)
+}
+
+func (e *EthService) GetFilterChanges(id rpc.ID) (interface{}, error) {
+	return withMetrics(
+		e.metrics, "eth_getFilterChanges",
+		func() (interface{}, error) {
+			return e.filters.changes(id)
+		},
+	)
+}
+
+func (e *EthService) getFilterLogs(id rpc.ID) ([]*types.Log, error) {
+	q, err := e.filters.logsQuery(id)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := e.evmChain.Logs(q)
+	if err != nil {
+		return nil, e.resolveError(err)
+	}
+	return logs, nil
+}
+
+func (e *EthService) GetFilterLogs(id rpc.ID) ([]*types.Log, error) {
+	return withMetrics(
+		e.metrics, "eth_getFilterLogs",
+		func() ([]*types.Log, error) {
+			return e.getFilterLogs(id)
+		},
+	)
+}
+
 /*
 Not implemented:
-func (e *EthService) NewFilter()
-func (e *EthService) NewBlockFilter()
-func (e *EthService) NewPendingTransactionFilter()
-func (e *EthService) UninstallFilter()
-func (e *EthService) GetFilterChanges()
-func (e *EthService) GetFilterLogs()
 func (e *EthService) SubmitWork()
 func (e *EthService) GetWork()
 func (e *EthService) SubmitHashrate()
@@ -633,31 +749,113 @@ func (s *Web3Service) Sha3(input hexutil.Bytes) hexutil.Bytes {
 	return crypto.Keccak256(input)
 }
 
-type TxPoolService struct{}
+// TxPoolService contains the implementations for the `txpool_*` JSONRPC
+// endpoints. The contents are the EVM transactions waiting in the mempool of
+// the node: pending transactions can be processed in the next block, while
+// queued ones are waiting for a nonce gap to be filled.
+type TxPoolService struct {
+	evmChain *EVMChain
+	metrics  *metrics.ChainWebAPIMetrics
+}
 
-func NewTxPoolService() *TxPoolService {
-	return &TxPoolService{}
+func NewTxPoolService(evmChain *EVMChain, metrics *metrics.ChainWebAPIMetrics) *TxPoolService {
+	return &TxPoolService{
+		evmChain: evmChain,
+		metrics:  metrics,
+	}
 }
 
-func (s *TxPoolService) Content() map[string]map[string]map[string]*RPCTransaction {
-	return map[string]map[string]map[string]*RPCTransaction{
-		"pending": make(map[string]map[string]*RPCTransaction),
-		"queued":  make(map[string]map[string]*RPCTransaction),
+func (s *TxPoolService) content() (map[string]map[string]map[string]*RPCTransaction, error) {
+	pending, queued, err := s.evmChain.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+	format := func(txsBySender map[common.Address][]*types.Transaction) map[string]map[string]*RPCTransaction {
+		ret := make(map[string]map[string]*RPCTransaction)
+		for sender, txs := range txsBySender {
+			dump := make(map[string]*RPCTransaction)
+			for _, tx := range txs {
+				dump[strconv.FormatUint(tx.Nonce(), 10)] = newRPCTransaction(tx, common.Hash{}, 0, 0)
+			}
+			ret[sender.Hex()] = dump
+		}
+		return ret
 	}
+	return map[string]map[string]map[string]*RPCTransaction{
+		"pending": format(pending),
+		"queued":  format(queued),
+	}, nil
 }
 
-func (s *TxPoolService) Inspect() map[string]map[string]map[string]string {
-	return map[string]map[string]map[string]string{
-		"pending": make(map[string]map[string]string),
-		"queued":  make(map[string]map[string]string),
+func (s *TxPoolService) Content() (map[string]map[string]map[string]*RPCTransaction, error) {
+	return withMetrics(
+		s.metrics, "txpool_content",
+		func() (map[string]map[string]map[string]*RPCTransaction, error) {
+			return s.content()
+		},
+	)
+}
+
+func (s *TxPoolService) inspect() (map[string]map[string]map[string]string, error) {
+	pending, queued, err := s.evmChain.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+	format := func(txsBySender map[common.Address][]*types.Transaction) map[string]map[string]string {
+		ret := make(map[string]map[string]string)
+		for sender, txs := range txsBySender {
+			dump := make(map[string]string)
+			for _, tx := range txs {
+				to := "contract creation"
+				if tx.To() != nil {
+					to = tx.To().Hex()
+				}
+				dump[strconv.FormatUint(tx.Nonce(), 10)] = fmt.Sprintf("%s: %v wei + %v gas × %v wei", to, tx.Value(), tx.Gas(), tx.GasPrice())
+			}
+			ret[sender.Hex()] = dump
+		}
+		return ret
 	}
+	return map[string]map[string]map[string]string{
+		"pending": format(pending),
+		"queued":  format(queued),
+	}, nil
 }
 
-func (s *TxPoolService) Status() map[string]hexutil.Uint {
-	return map[string]hexutil.Uint{
-		"pending": hexutil.Uint(0),
-		"queued":  hexutil.Uint(0),
+func (s *TxPoolService) Inspect() (map[string]map[string]map[string]string, error) {
+	return withMetrics(
+		s.metrics, "txpool_inspect",
+		func() (map[string]map[string]map[string]string, error) {
+			return s.inspect()
+		},
+	)
+}
+
+func (s *TxPoolService) status() (map[string]hexutil.Uint, error) {
+	pending, queued, err := s.evmChain.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
+	count := func(txsBySender map[common.Address][]*types.Transaction) hexutil.Uint {
+		n := 0
+		for _, txs := range txsBySender {
+			n += len(txs)
+		}
+		return hexutil.Uint(n)
 	}
+	return map[string]hexutil.Uint{
+		"pending": count(pending),
+		"queued":  count(queued),
+	}, nil
+}
+
+func (s *TxPoolService) Status() (map[string]hexutil.Uint, error) {
+	return withMetrics(
+		s.metrics, "txpool_status",
+		func() (map[string]hexutil.Uint, error) {
+			return s.status()
+		},
+	)
 }
 
 type DebugService struct {
@@ -685,6 +883,45 @@ func (d *DebugService) TraceTransaction(txHash common.Hash, config *tracers.Trac
 	)
 }
 
+func (d *DebugService) traceBlockByNumber(blockNumber rpc.BlockNumber, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return d.evmChain.TraceBlockByNumber(parseBlockNumber(blockNumber), config)
+}
+
+func (d *DebugService) TraceBlockByNumber(blockNumber rpc.BlockNumber, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return withMetrics(
+		d.metrics, "debug_traceBlockByNumber",
+		func() ([]*TxTraceResult, error) {
+			return d.traceBlockByNumber(blockNumber, config)
+		},
+	)
+}
+
+func (d *DebugService) traceBlockByHash(blockHash common.Hash, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return d.evmChain.TraceBlockByHash(blockHash, config)
+}
+
+func (d *DebugService) TraceBlockByHash(blockHash common.Hash, config *tracers.TraceConfig) ([]*TxTraceResult, error) {
+	return withMetrics(
+		d.metrics, "debug_traceBlockByHash",
+		func() ([]*TxTraceResult, error) {
+			return d.traceBlockByHash(blockHash, config)
+		},
+	)
+}
+
+func (d *DebugService) traceCall(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash, config *TraceCallConfig) (interface{}, error) {
+	return d.evmChain.TraceCall(args.parse(), blockNumberOrHash, config)
+}
+
+func (d *DebugService) TraceCall(args *RPCCallArgs, blockNumberOrHash *rpc.BlockNumberOrHash, config *TraceCallConfig) (interface{}, error) {
+	return withMetrics(
+		d.metrics, "debug_traceCall",
+		func() (interface{}, error) {
+			return d.traceCall(args, blockNumberOrHash, config)
+		},
+	)
+}
+
 type EVMService struct {
 	evmChain *EVMChain
 }
@@ -703,3 +940,10 @@ func (e *EVMService) Snapshot() (hexutil.Uint, error) {
 func (e *EVMService) Revert(snapshot hexutil.Uint) error {
 	return e.evmChain.backend.RevertToSnapshot(int(snapshot))
 }
+
+// GetProof is the ISC equivalent of eth_getProof: the EVM state is stored in
+// the ISC trie, so the returned proofs are ISC trie proofs instead of
+// Ethereum Merkle-Patricia proofs.
+func (e *EVMService) GetProof(address common.Address, storageKeys []common.Hash, blockNumberOrHash rpc.BlockNumberOrHash) (*ProofResult, error) {
+	return e.evmChain.GetProof(address, storageKeys, &blockNumberOrHash)
+}
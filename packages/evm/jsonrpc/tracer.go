@@ -22,3 +22,23 @@ func newTracer(tracerType string, cfg json.RawMessage) (tracers.Tracer, error) {
 	}
 	return fn(cfg)
 }
+
+// newTracerFromConfig creates the tracer specified in the config. As in
+// go-ethereum, the struct logger is used if no tracer is specified.
+func newTracerFromConfig(config *tracers.TraceConfig) (tracers.Tracer, error) {
+	if config == nil {
+		return newTracer(structLoggerTracer, nil)
+	}
+	if config.Tracer == nil || *config.Tracer == "" {
+		var loggerConfig json.RawMessage
+		if config.Config != nil {
+			var err error
+			loggerConfig, err = json.Marshal(config.Config)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return newTracer(structLoggerTracer, loggerConfig)
+	}
+	return newTracer(*config.Tracer, config.TracerConfig)
+}
@@ -0,0 +1,44 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package jsonrpc
+
+import (
+	"encoding/json"
+
+	"github.com/ethereum/go-ethereum/eth/tracers"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+
+	// register the go-ethereum native tracers in tracers.DefaultDirectory
+	_ "github.com/ethereum/go-ethereum/eth/tracers/native"
+)
+
+// structLoggerTracer is the opcode logger used by go-ethereum when no tracer
+// is specified.
+const structLoggerTracer = "structLogger"
+
+func init() {
+	registerTracer(structLoggerTracer, newStructLogger)
+	// These tracers only depend on the vm.StateDB interface, so the
+	// go-ethereum implementations can be used as they are.
+	registerTracer("prestateTracer", newNativeTracer("prestateTracer"))
+	registerTracer("4byteTracer", newNativeTracer("4byteTracer"))
+}
+
+// newStructLogger returns the go-ethereum struct logger. Contrary to the other
+// tracers, its config is the logger.Config embedded in the TraceConfig.
+func newStructLogger(cfg json.RawMessage) (tracers.Tracer, error) {
+	var config logger.Config
+	if cfg != nil {
+		if err := json.Unmarshal(cfg, &config); err != nil {
+			return nil, err
+		}
+	}
+	return logger.NewStructLogger(&config), nil
+}
+
+func newNativeTracer(name string) tracerFactory {
+	return func(cfg json.RawMessage) (tracers.Tracer, error) {
+		return tracers.DefaultDirectory.New(name, new(tracers.Context), cfg)
+	}
+}
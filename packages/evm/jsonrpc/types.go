@@ -15,10 +15,13 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/tracers"
 	"github.com/ethereum/go-ethereum/rpc"
 
 	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/packages/trie"
 	"github.com/iotaledger/wasp/packages/vm/core/evm"
 )
 
@@ -427,3 +430,81 @@ func newRevertError(revertData []byte) *revertError {
 		reason: hexutil.Encode(revertData),
 	}
 }
+
+// TxTraceResult is the result of tracing a single tx of a block (same format
+// as go-ethereum).
+type TxTraceResult struct {
+	TxHash common.Hash `json:"txHash"`
+	Result any         `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// TraceCallConfig is the config of debug_traceCall (same format as
+// go-ethereum, except that block overrides are not supported).
+type TraceCallConfig struct {
+	tracers.TraceConfig
+	StateOverrides *evmtypes.StateOverride `json:"stateOverrides"`
+}
+
+// FeeHistoryResult is the result of eth_feeHistory (same format as
+// go-ethereum).
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+// StateProof is a proof of the value stored under a key of the ISC chain
+// state (or of its absence, if Value is empty).
+type StateProof struct {
+	Key   hexutil.Bytes `json:"key"`
+	Value hexutil.Bytes `json:"value"`
+	// Proof is the encoded trie.MerkleProof
+	Proof hexutil.Bytes `json:"proof"`
+}
+
+// Verify checks the proof against the given trie root.
+func (p *StateProof) Verify(stateRoot trie.Hash) error {
+	proof, err := trie.MerkleProofFromBytes(p.Proof)
+	if err != nil {
+		return err
+	}
+	if !proof.IsForKey(p.Key) {
+		return errors.New("the proof is for a different key")
+	}
+	if len(p.Value) == 0 {
+		if err := proof.Validate(stateRoot.Bytes()); err != nil {
+			return err
+		}
+		if !proof.IsProofOfAbsence() {
+			return errors.New("not a proof of absence")
+		}
+		return nil
+	}
+	return proof.ValidateValue(stateRoot, p.Value)
+}
+
+// StorageProof is the proof of an EVM storage slot.
+type StorageProof struct {
+	Key   common.Hash `json:"key"`
+	Value common.Hash `json:"value"`
+	Proof *StateProof `json:"proof"`
+}
+
+// ProofResult is the result of evm_getProof. It is the equivalent of the
+// eth_getProof result, but the proofs are ISC trie proofs that can be
+// verified against StateRoot, i.e. the trie root committed in the L1 alias
+// output of the block.
+type ProofResult struct {
+	Address      common.Address  `json:"address"`
+	BlockNumber  hexutil.Uint64  `json:"blockNumber"`
+	StateRoot    hexutil.Bytes   `json:"stateRoot"`
+	Balance      *hexutil.Big    `json:"balance"`
+	BalanceProof *StateProof     `json:"balanceProof"`
+	Nonce        hexutil.Uint64  `json:"nonce"`
+	NonceProof   *StateProof     `json:"nonceProof"`
+	CodeHash     common.Hash     `json:"codeHash"`
+	CodeProof    *StateProof     `json:"codeProof"`
+	StorageProof []*StorageProof `json:"storageProof"`
+}
@@ -4,6 +4,7 @@
 package jsonrpc
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chainutil"
 	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/dict"
@@ -26,6 +28,10 @@ import (
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 )
 
+// mempoolQueryTimeout bounds the time spent waiting for the mempool to list
+// its requests.
+const mempoolQueryTimeout = 5 * time.Second
+
 // WaspEVMBackend is the implementation of [ChainBackend] for the production environment.
 type WaspEVMBackend struct {
 	chain      chain.Chain
@@ -99,6 +105,31 @@ func (b *WaspEVMBackend) EVMTraceTransaction(
 	)
 }
 
+func (b *WaspEVMBackend) EVMTraceBlock(
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	iscRequestsInBlock []isc.Request,
+	tracerForTx func(txIndex uint64) tracers.Tracer,
+) error {
+	return chainutil.EVMTraceBlock(
+		b.chain,
+		aliasOutput,
+		blockTime,
+		iscRequestsInBlock,
+		tracerForTx,
+	)
+}
+
+func (b *WaspEVMBackend) EVMTraceCall(
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	callMsg ethereum.CallMsg,
+	stateOverride evmtypes.StateOverride,
+	tracer tracers.Tracer,
+) error {
+	return chainutil.EVMTraceCall(b.chain, aliasOutput, blockTime, callMsg, stateOverride, tracer)
+}
+
 func (b *WaspEVMBackend) ISCCallView(chainState state.State, scName, funName string, args dict.Dict) (dict.Dict, error) {
 	return chainutil.CallView(chainState, b.chain, isc.Hn(scName), isc.Hn(funName), args)
 }
@@ -123,6 +154,20 @@ func (b *WaspEVMBackend) ISCLatestState() state.State {
 	return latestState
 }
 
+func (b *WaspEVMBackend) ISCMempoolOffLedgerRequests() ([]isc.OffLedgerRequest, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), mempoolQueryTimeout)
+	defer cancel()
+	select {
+	case reqs, ok := <-b.chain.OffLedgerRequestsInMempool(ctx):
+		if !ok {
+			return nil, errors.New("mempool query was cancelled")
+		}
+		return reqs, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("mempool query: %w", ctx.Err())
+	}
+}
+
 func (b *WaspEVMBackend) ISCStateByBlockIndex(blockIndex uint32) (state.State, error) {
 	latestState, err := b.chain.LatestState(chain.ActiveOrCommittedState)
 	if err != nil {
@@ -5,6 +5,8 @@ import (
 	"io"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/hashing"
@@ -27,6 +29,7 @@ type Calldata interface {
 	Allowance() *Assets // transfer of assets to the smart contract. Debited from sender account
 	Assets() *Assets    // attached assets for the UTXO request, nil for off-ledger. All goes to sender
 	CallTarget() CallTarget
+	EVMCallMsg() *ethereum.CallMsg // the EVM call or transaction; nil if the request is not an EVM request
 	GasBudget() (gas uint64, isEVM bool)
 	ID() RequestID
 	NFT() *NFT // Not nil if the request is an NFT request
@@ -77,6 +77,10 @@ func (req *evmOffLedgerCallRequest) ChainID() ChainID {
 	return req.chainID
 }
 
+func (req *evmOffLedgerCallRequest) EVMCallMsg() *ethereum.CallMsg {
+	return &req.callMsg
+}
+
 func (req *evmOffLedgerCallRequest) GasBudget() (gas uint64, isEVM bool) {
 	return req.callMsg.Gas, true
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/core/types"
 
 	iotago "github.com/iotaledger/iota.go/v3"
@@ -93,6 +94,20 @@ func (req *evmOffLedgerTxRequest) ChainID() ChainID {
 	return req.chainID
 }
 
+func (req *evmOffLedgerTxRequest) EVMCallMsg() *ethereum.CallMsg {
+	return &ethereum.CallMsg{
+		From:       req.sender.EthAddress(),
+		To:         req.tx.To(),
+		Gas:        req.tx.Gas(),
+		GasPrice:   req.tx.GasPrice(),
+		GasFeeCap:  req.tx.GasFeeCap(),
+		GasTipCap:  req.tx.GasTipCap(),
+		Value:      req.tx.Value(),
+		Data:       req.tx.Data(),
+		AccessList: req.tx.AccessList(),
+	}
+}
+
 func (req *evmOffLedgerTxRequest) GasBudget() (gas uint64, isEVM bool) {
 	return req.tx.Gas(), true
 }
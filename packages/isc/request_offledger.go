@@ -6,6 +6,8 @@ import (
 	"io"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/hashing"
@@ -131,6 +133,10 @@ func (req *OffLedgerRequestData) Expiry() (time.Time, iotago.Address) {
 	return time.Time{}, nil
 }
 
+func (req *OffLedgerRequestData) EVMCallMsg() *ethereum.CallMsg {
+	return nil
+}
+
 func (req *OffLedgerRequestData) GasBudget() (gasBudget uint64, isEVM bool) {
 	return req.gasBudget, false
 }
@@ -5,6 +5,8 @@ import (
 	"io"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
+
 	"github.com/iotaledger/hive.go/serializer/v2"
 	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/kv/dict"
@@ -150,6 +152,10 @@ func (req *onLedgerRequestData) Features() Features {
 	return req
 }
 
+func (req *onLedgerRequestData) EVMCallMsg() *ethereum.CallMsg {
+	return nil
+}
+
 func (req *onLedgerRequestData) GasBudget() (gasBudget uint64, isEVM bool) {
 	if req.requestMetadata == nil {
 		return 0, false
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/eth/tracers"
 
 	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/hashing"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/dict"
@@ -117,7 +118,7 @@ type Sandbox interface {
 	// StateAnchor properties of the anchor output
 	StateAnchor() *StateAnchor
 
-	// EVMTracer returns a non-nil tracer if an EVM tx is being traced
+	// EVMTracer returns a non-nil tracer if EVM txs are being traced
 	// (e.g. with the debug_traceTransaction JSONRPC method).
 	EVMTracer() *EVMTracer
 
@@ -232,6 +233,10 @@ type BLS interface {
 }
 
 type EVMTracer struct {
-	Tracer  tracers.Tracer
-	TxIndex uint64
+	// TracerForTx returns the tracer for the EVM tx with the given index in
+	// the block, or nil if the tx is not traced.
+	TracerForTx func(txIndex uint64) tracers.Tracer
+	// StateOverride is applied before executing a traced EVM call (e.g. with
+	// the debug_traceCall JSONRPC method).
+	StateOverride evmtypes.StateOverride
 }
@@ -0,0 +1,186 @@
+package l1simclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/parameters"
+)
+
+const reconnectInterval = 1 * time.Second
+
+// nodeConn is the connection of a wasp node to the simulator at url.
+type nodeConn struct {
+	url      string
+	log      *logger.Logger
+	l1Params *parameters.L1Params
+}
+
+var _ chain.NodeConnection = &nodeConn{}
+
+// New connects to the simulator served at url and initializes the L1
+// parameters with the ones of the simulated ledger.
+func New(ctx context.Context, url string, log *logger.Logger) (chain.NodeConnection, error) {
+	nc := &nodeConn{url: url, log: log}
+	if err := nc.get(ctx, PathInfo, &nc.l1Params); err != nil {
+		return nil, fmt.Errorf("failed to get the L1 parameters from %v: %w", url, err)
+	}
+	parameters.InitL1(nc.l1Params)
+	return nc, nil
+}
+
+func (nc *nodeConn) get(ctx context.Context, path string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nc.url+path, http.NoBody)
+	if err != nil {
+		return err
+	}
+	return nc.do(req, result)
+}
+
+func (nc *nodeConn) do(req *http.Request, result any) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%v %v: unexpected status %v", req.Method, req.URL.Path, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (nc *nodeConn) PublishTX(
+	ctx context.Context,
+	chainID isc.ChainID,
+	tx *iotago.Transaction,
+	callback chain.TxPostHandler,
+) error {
+	txBytes, err := tx.Serialize(serializer.DeSeriModePerformValidation, nc.l1Params.Protocol)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, nc.url+PathTx, bytes.NewReader(txBytes))
+	if err != nil {
+		return err
+	}
+	// transactions are published asynchronously, same as in the nodeconn package
+	go func() {
+		var result TxResult
+		if err := nc.do(req, &result); err != nil {
+			nc.log.Debugf("Publishing TX for chain %v failed: %v", chainID, err)
+		}
+		callback(tx, result.Confirmed)
+	}()
+	return nil
+}
+
+// AttachChain streams the notifications of the chain until the context is
+// canceled. The stream is reopened if the connection is lost, the simulator
+// then sends the current milestone, alias output and requests again.
+func (nc *nodeConn) AttachChain(
+	ctx context.Context,
+	chainID isc.ChainID,
+	recvRequestCB chain.RequestOutputHandler,
+	recvAliasOutput chain.AliasOutputHandler,
+	recvMilestone chain.MilestoneHandler,
+	onChainConnect func(),
+	onChainDisconnect func(),
+) {
+	if onChainConnect != nil {
+		onChainConnect()
+	}
+	go func() {
+		for {
+			err := nc.attach(ctx, chainID, recvRequestCB, recvAliasOutput, recvMilestone)
+			if ctx.Err() != nil {
+				break
+			}
+			nc.log.Warnf("Chain %v lost the connection to the simulated L1, reconnecting: %v", chainID, err)
+			select {
+			case <-ctx.Done():
+			case <-time.After(reconnectInterval):
+			}
+		}
+		nc.log.Infof("Chain %v detached from the simulated L1.", chainID)
+		if onChainDisconnect != nil {
+			onChainDisconnect()
+		}
+	}()
+}
+
+func (nc *nodeConn) attach(
+	ctx context.Context,
+	chainID isc.ChainID,
+	recvRequestCB chain.RequestOutputHandler,
+	recvAliasOutput chain.AliasOutputHandler,
+	recvMilestone chain.MilestoneHandler,
+) error {
+	url := fmt.Sprintf("%v%v?%v=%v", nc.url, PathAttach, ParamChainID, iotago.EncodeHex(chainID.Bytes()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %v", resp.Status)
+	}
+	nc.log.Infof("Chain %v attached to the simulated L1.", chainID)
+
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event Event
+		if err := decoder.Decode(&event); err != nil {
+			return err
+		}
+		if event.Type == EventMilestone {
+			recvMilestone(event.Timestamp)
+			continue
+		}
+		oi, err := event.OutputInfo()
+		if err != nil {
+			return err
+		}
+		switch event.Type {
+		case EventAliasOutput:
+			recvAliasOutput(oi)
+		case EventRequest:
+			recvRequestCB(oi)
+		default:
+			return fmt.Errorf("unknown event type %q", event.Type)
+		}
+	}
+}
+
+func (nc *nodeConn) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (nc *nodeConn) WaitUntilInitiallySynced(context.Context) error {
+	return nil
+}
+
+func (nc *nodeConn) GetBech32HRP() iotago.NetworkPrefix {
+	return nc.l1Params.Protocol.Bech32HRP
+}
+
+func (nc *nodeConn) GetL1Params() *parameters.L1Params {
+	return nc.l1Params
+}
+
+func (nc *nodeConn) GetL1ProtocolParams() *iotago.ProtocolParameters {
+	return nc.l1Params.Protocol
+}
@@ -0,0 +1,79 @@
+// Package l1simclient connects a wasp node running in another process to the
+// L1 ledger simulator served by l1sim.Server (see packages/testutil/l1sim). It
+// is kept out of testutil, so that the node does not depend on the testing
+// utilities.
+package l1simclient
+
+import (
+	"time"
+
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/util"
+)
+
+const (
+	// PathInfo returns the L1 parameters (parameters.L1Params) as JSON.
+	PathInfo = "/info"
+	// PathTx attaches the serialized transaction in the request body and
+	// responds with a TxResult once it is confirmed or rejected.
+	PathTx = "/tx"
+	// PathAttach attaches the chain given by ParamChainID and streams the
+	// Events for it as a sequence of JSON values.
+	PathAttach = "/attach"
+
+	// ParamChainID is the hex encoded chain ID.
+	ParamChainID = "chainID"
+)
+
+type TxResult struct {
+	Confirmed bool `json:"confirmed"`
+}
+
+type EventType string
+
+const (
+	EventMilestone   EventType = "milestone"
+	EventAliasOutput EventType = "aliasOutput"
+	EventRequest     EventType = "request"
+)
+
+// Event is a notification of an attached chain, in the same order as the
+// callbacks of chain.NodeConnection.AttachChain are called.
+type Event struct {
+	Type               EventType `json:"type"`
+	Timestamp          time.Time `json:"timestamp,omitempty"`
+	OutputID           []byte    `json:"outputId,omitempty"`
+	Output             []byte    `json:"output,omitempty"`
+	TransactionIDSpent []byte    `json:"transactionIdSpent,omitempty"`
+}
+
+func NewMilestoneEvent(timestamp time.Time) *Event {
+	return &Event{Type: EventMilestone, Timestamp: timestamp}
+}
+
+func NewOutputEvent(eventType EventType, oi *isc.OutputInfo) (*Event, error) {
+	outputBytes, err := oi.Output.Serialize(serializer.DeSeriModeNoValidation, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Event{
+		Type:               eventType,
+		OutputID:           oi.OutputID[:],
+		Output:             outputBytes,
+		TransactionIDSpent: oi.TransactionIDSpent[:],
+	}, nil
+}
+
+func (e *Event) OutputInfo() (*isc.OutputInfo, error) {
+	output, err := util.OutputFromBytes(e.Output)
+	if err != nil {
+		return nil, err
+	}
+	var outputID iotago.OutputID
+	copy(outputID[:], e.OutputID)
+	var txIDSpent iotago.TransactionID
+	copy(txIDSpent[:], e.TransactionIDSpent)
+	return isc.NewOutputInfo(outputID, output, txIDSpent), nil
+}
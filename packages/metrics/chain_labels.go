@@ -25,6 +25,7 @@ const (
 	labelNameWebapiRequestOperation                 = "api_req_type"
 	labelNameWebapiRequestStatusCode                = "api_req_status_code"
 	labelNameWebapiEvmRPCSuccess                    = "success"
+	labelNameMempoolDeferReason                     = "reason"
 )
 
 func getChainLabels(chainID isc.ChainID) prometheus.Labels {
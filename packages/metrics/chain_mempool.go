@@ -23,6 +23,8 @@ type ChainMempoolMetricsProvider struct {
 	offLedgerReqTime  *prometheus.HistogramVec
 	totalSize         *prometheus.GaugeVec
 	missingReqs       *prometheus.GaugeVec
+	proposalGas       *prometheus.GaugeVec
+	proposalDeferred  *prometheus.GaugeVec
 }
 
 func newChainMempoolMetricsProvider() *ChainMempoolMetricsProvider {
@@ -107,6 +109,18 @@ func newChainMempoolMetricsProvider() *ChainMempoolMetricsProvider {
 			Name:      "missing_reqs",
 			Help:      "Number of requests missing at this node (asking others to send them).",
 		}, []string{labelNameChain}),
+		proposalGas: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "mempool",
+			Name:      "proposal_gas",
+			Help:      "Estimated gas of the requests in the last proposal.",
+		}, []string{labelNameChain}),
+		proposalDeferred: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "mempool",
+			Name:      "proposal_deferred",
+			Help:      "Number of ready requests left out of the last proposal, by reason.",
+		}, []string{labelNameChain, labelNameMempoolDeferReason}),
 	}
 }
 
@@ -125,6 +139,8 @@ func (p *ChainMempoolMetricsProvider) register(reg prometheus.Registerer) {
 		p.offLedgerReqTime,
 		p.totalSize,
 		p.missingReqs,
+		p.proposalGas,
+		p.proposalDeferred,
 	)
 }
 
@@ -134,6 +150,7 @@ func (p *ChainMempoolMetricsProvider) createForChain(chainID isc.ChainID) *Chain
 
 type ChainMempoolMetrics struct {
 	collectors *ChainMempoolMetricsProvider
+	chainID    isc.ChainID
 	labels     prometheus.Labels
 
 	vTimePoolSize      int
@@ -159,9 +176,11 @@ func newChainMempoolMetrics(collectors *ChainMempoolMetricsProvider, chainID isc
 	collectors.offLedgerReqTime.With(labels)
 	collectors.totalSize.With(labels)
 	collectors.missingReqs.With(labels)
+	collectors.proposalGas.With(labels)
 
 	return &ChainMempoolMetrics{
 		collectors: collectors,
+		chainID:    chainID,
 		labels:     labels,
 	}
 }
@@ -223,3 +242,13 @@ func (m *ChainMempoolMetrics) SetOnLedgerReqTime(d time.Duration) {
 func (m *ChainMempoolMetrics) SetOffLedgerReqTime(d time.Duration) {
 	m.collectors.offLedgerReqTime.With(m.labels).Observe(d.Seconds())
 }
+
+func (m *ChainMempoolMetrics) SetProposalGas(gas uint64) {
+	m.collectors.proposalGas.With(m.labels).Set(float64(gas))
+}
+
+func (m *ChainMempoolMetrics) SetProposalDeferred(reason string, count int) {
+	labels := getChainLabels(m.chainID)
+	labels[labelNameMempoolDeferReason] = reason
+	m.collectors.proposalDeferred.With(labels).Set(float64(count))
+}
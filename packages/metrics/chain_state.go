@@ -15,6 +15,8 @@ type ChainStateMetricsProvider struct {
 	blockPruneTimes             *prometheus.HistogramVec
 	blockPruneDeletedTrieNodes  *prometheus.CounterVec
 	blockPruneDeletedTrieValues *prometheus.CounterVec
+	triePruneBatchTimes         *prometheus.HistogramVec
+	triePruneBacklog            *prometheus.GaugeVec
 }
 
 func newChainStateMetricsProvider() *ChainStateMetricsProvider {
@@ -57,6 +59,19 @@ func newChainStateMetricsProvider() *ChainStateMetricsProvider {
 			Name:      "state_block_prune_deleted_trie_values",
 			Help:      "Deleted trie values",
 		}, []string{labelNameChain}),
+		triePruneBatchTimes: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "state",
+			Name:      "state_trie_prune_batch_times",
+			Help:      "Time elapsed (s) pruning a batch of trie nodes",
+			Buckets:   execTimeBuckets,
+		}, []string{labelNameChain}),
+		triePruneBacklog: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "state",
+			Name:      "state_trie_prune_backlog",
+			Help:      "Trie node references still to be released by the incremental pruning",
+		}, []string{labelNameChain}),
 	}
 }
 
@@ -68,6 +83,8 @@ func (p *ChainStateMetricsProvider) register(reg prometheus.Registerer) {
 		p.blockPruneTimes,
 		p.blockPruneDeletedTrieNodes,
 		p.blockPruneDeletedTrieValues,
+		p.triePruneBatchTimes,
+		p.triePruneBacklog,
 	)
 }
 
@@ -90,9 +107,18 @@ func (m *ChainStateMetrics) BlockCommitted(elapsed time.Duration, createdNodes,
 	m.collector.blockCommitNewTrieValues.With(labels).Add(float64(createdValues))
 }
 
-func (m *ChainStateMetrics) BlockPruned(elapsed time.Duration, deletedNodes, deletedValues uint) {
+// BlockPruned is called when the block is deleted and its trie is scheduled for pruning.
+func (m *ChainStateMetrics) BlockPruned(elapsed time.Duration, backlog uint64) {
 	labels := getChainLabels(m.chainID)
 	m.collector.blockPruneTimes.With(labels).Observe(elapsed.Seconds())
+	m.collector.triePruneBacklog.With(labels).Set(float64(backlog))
+}
+
+// TrieNodesPruned is called when a batch of the scheduled trie nodes is pruned.
+func (m *ChainStateMetrics) TrieNodesPruned(elapsed time.Duration, deletedNodes, deletedValues uint, backlog uint64) {
+	labels := getChainLabels(m.chainID)
+	m.collector.triePruneBatchTimes.With(labels).Observe(elapsed.Seconds())
 	m.collector.blockPruneDeletedTrieNodes.With(labels).Add(float64(deletedNodes))
 	m.collector.blockPruneDeletedTrieValues.With(labels).Add(float64(deletedValues))
+	m.collector.triePruneBacklog.With(labels).Set(float64(backlog))
 }
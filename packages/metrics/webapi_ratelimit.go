@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/iotaledger/wasp/packages/webapi/ratelimit"
+)
+
+const (
+	labelNameWebapiRateLimitBudget     = "budget"
+	labelNameWebapiRateLimitClientType = "client_type"
+	labelNameWebapiRateLimitAllowed    = "allowed"
+)
+
+type WebAPIRateLimitMetricsProvider struct {
+	requests *prometheus.CounterVec
+}
+
+var _ ratelimit.Metrics = &WebAPIRateLimitMetricsProvider{}
+
+func NewWebAPIRateLimitMetricsProvider() *WebAPIRateLimitMetricsProvider {
+	return &WebAPIRateLimitMetricsProvider{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "iota_wasp",
+			Subsystem: "webapi",
+			Name:      "ratelimit_requests",
+			Help:      "Number of requests checked by the rate limiter, by budget, client type and whether they were allowed",
+		}, []string{labelNameWebapiRateLimitBudget, labelNameWebapiRateLimitClientType, labelNameWebapiRateLimitAllowed}),
+	}
+}
+
+func (m *WebAPIRateLimitMetricsProvider) Register(reg prometheus.Registerer) {
+	reg.MustRegister(
+		m.requests,
+	)
+}
+
+func (m *WebAPIRateLimitMetricsProvider) RateLimitedRequest(budget string, clientType string, allowed bool) {
+	m.requests.With(prometheus.Labels{
+		labelNameWebapiRateLimitBudget:     budget,
+		labelNameWebapiRateLimitClientType: clientType,
+		labelNameWebapiRateLimitAllowed:    fmt.Sprintf("%v", allowed),
+	}).Inc()
+}
@@ -531,8 +531,7 @@ func (ch *Chain) RotateStateController(newStateAddr iotago.Address, newStateKeyP
 	).WithMaxAffordableGasBudget()
 	result := ch.postRequestSyncTxSpecial(req, ownerKeyPair)
 	if result.Receipt.Error == nil {
-		ch.StateControllerAddress = newStateAddr
-		ch.StateControllerKeyPair = newStateKeyPair
+		ch.setStateController(newStateAddr, newStateKeyPair)
 	}
 	return ch.ResolveVMError(result.Receipt.Error).AsGoError()
 }
@@ -696,6 +695,14 @@ func (*Chain) AwaitRequestProcessed(ctx context.Context, requestID isc.RequestID
 	panic("unimplemented")
 }
 
+// OffLedgerRequestsInMempool implements chain.Chain
+func (ch *Chain) OffLedgerRequestsInMempool(ctx context.Context) <-chan []isc.OffLedgerRequest {
+	res := make(chan []isc.OffLedgerRequest, 1)
+	res <- ch.mempool.OffLedgerRequests()
+	close(res)
+	return res
+}
+
 func (ch *Chain) LatestBlockIndex() uint32 {
 	return ch.GetLatestBlockInfo().BlockIndex()
 }
@@ -12,9 +12,15 @@ func (env *Solo) GlobalTime() time.Time {
 	return env.utxoDB.GlobalTime()
 }
 
-// AdvanceClockBy advances logical clock by time step
+// AdvanceClockBy advances logical clock by time step; a zero step does nothing
 func (env *Solo) AdvanceClockBy(step time.Duration) {
+	if step == 0 {
+		// not recorded either: a zero step could not be told apart from the
+		// other kinds of steps on replay
+		return
+	}
 	env.utxoDB.AdvanceClockBy(step)
+	env.recordStep(&ScenarioStep{AdvanceClock: step})
 	env.logger.Infof("AdvanceClockBy: logical clock advanced by %v to %s",
 		step, env.utxoDB.GlobalTime().Format(timeLayout))
 }
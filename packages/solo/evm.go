@@ -15,6 +15,7 @@ import (
 	hivedb "github.com/iotaledger/hive.go/kvstore/database"
 	"github.com/iotaledger/wasp/packages/chain"
 	"github.com/iotaledger/wasp/packages/chainutil"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv/dict"
@@ -65,6 +66,31 @@ func (b *jsonRPCSoloBackend) EVMTraceTransaction(
 	)
 }
 
+func (b *jsonRPCSoloBackend) EVMTraceBlock(
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	iscRequestsInBlock []isc.Request,
+	tracerForTx func(txIndex uint64) tracers.Tracer,
+) error {
+	return chainutil.EVMTraceBlock(
+		b.Chain,
+		aliasOutput,
+		blockTime,
+		iscRequestsInBlock,
+		tracerForTx,
+	)
+}
+
+func (b *jsonRPCSoloBackend) EVMTraceCall(
+	aliasOutput *isc.AliasOutputWithID,
+	blockTime time.Time,
+	callMsg ethereum.CallMsg,
+	stateOverride evmtypes.StateOverride,
+	tracer tracers.Tracer,
+) error {
+	return chainutil.EVMTraceCall(b.Chain, aliasOutput, blockTime, callMsg, stateOverride, tracer)
+}
+
 func (b *jsonRPCSoloBackend) ISCCallView(chainState state.State, scName, funName string, args dict.Dict) (dict.Dict, error) {
 	return b.Chain.CallViewAtState(chainState, scName, funName, args)
 }
@@ -85,6 +111,10 @@ func (b *jsonRPCSoloBackend) ISCLatestState() state.State {
 	return latestState
 }
 
+func (b *jsonRPCSoloBackend) ISCMempoolOffLedgerRequests() ([]isc.OffLedgerRequest, error) {
+	return b.Chain.mempool.OffLedgerRequests(), nil
+}
+
 func (b *jsonRPCSoloBackend) ISCStateByBlockIndex(blockIndex uint32) (state.State, error) {
 	return b.Chain.store.StateByIndex(blockIndex)
 }
@@ -116,10 +146,21 @@ func (b *jsonRPCSoloBackend) TakeSnapshot() (int, error) {
 }
 
 func (ch *Chain) EVM() *jsonrpc.EVMChain {
+	return ch.EVMWithBackend(ch.EVMBackend())
+}
+
+// EVMBackend returns the backend of the JSON-RPC service of the chain.
+func (ch *Chain) EVMBackend() jsonrpc.ChainBackend {
+	return newJSONRPCSoloBackend(ch, parameters.L1().BaseToken)
+}
+
+// EVMWithBackend returns the JSON-RPC service of the chain with the given
+// backend, which is usually a wrapper of EVMBackend.
+func (ch *Chain) EVMWithBackend(backend jsonrpc.ChainBackend) *jsonrpc.EVMChain {
 	return jsonrpc.NewEVMChain(
-		newJSONRPCSoloBackend(ch, parameters.L1().BaseToken),
+		backend,
 		ch.Env.publisher,
-		true,
+		0,
 		hivedb.EngineMapDB,
 		"",
 		ch.log,
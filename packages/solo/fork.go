@@ -0,0 +1,181 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package solo
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/stretchr/testify/require"
+
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
+	"github.com/iotaledger/wasp/packages/database"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/subrealm"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/trie"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/root"
+)
+
+// ForkChainFromSnapshot creates a chain from the state snapshot file written
+// by the snapshot manager of a wasp node (see sm_snapshots), so that requests
+// and EVM calls can be run against a copy of the real chain state.
+// See forkChain for the details.
+func (env *Solo) ForkChainFromSnapshot(name string, chainID isc.ChainID, fileName string) *Chain {
+	f, err := os.Open(fileName)
+	require.NoError(env.T, err)
+	defer f.Close()
+
+	return env.forkChain(name, chainID, func(store state.Store) trie.Hash {
+		snapshotInfo, err2 := sm_snapshots.ReadSnapshot(store, bufio.NewReader(f))
+		require.NoError(env.T, err2)
+		return snapshotInfo.TrieRoot()
+	})
+}
+
+// ForkChainFromDB creates a chain from the state at the given block index
+// (the latest one, if negative) in the chain state database of a wasp node
+// (i.e. the <chainID> directory in the chain state database path). The node
+// must not be running. See forkChain for the details.
+func (env *Solo) ForkChainFromDB(name string, chainID isc.ChainID, dbDir string, blockIndex int64) *Chain {
+	db, err := database.DatabaseWithDefaultSettings(dbDir, false, hivedb.EngineRocksDB, false, database.AllowedEnginesStorage...)
+	require.NoError(env.T, err)
+	defer db.KVStore().Close()
+	srcStore := indexedstore.New(state.NewStoreWithUniqueWriteMutex(db.KVStore()))
+
+	var block state.Block
+	if blockIndex < 0 {
+		block, err = srcStore.LatestBlock()
+	} else {
+		block, err = srcStore.BlockByIndex(uint32(blockIndex))
+	}
+	require.NoError(env.T, err)
+
+	return env.forkChain(name, chainID, func(store state.Store) trie.Hash {
+		r, w := io.Pipe()
+		go func() {
+			w.CloseWithError(srcStore.TakeSnapshot(block.TrieRoot(), w))
+		}()
+		require.NoError(env.T, store.RestoreSnapshot(block.TrieRoot(), r))
+		return block.TrieRoot()
+	})
+}
+
+// forkChain creates a chain with the given ID from the state restored by the
+// given function into the chain store. As the real committee is not
+// available, a local state controller key takes over the chain: the anchor
+// output of the forked state, owned by that key, is added to the L1 ledger
+// together with the native token, foundry and NFT outputs of the chain (with
+// their original output IDs, see utxodb.AddForeignOutputs). The chain owner
+// and the rest of the state are not changed; the on-ledger requests pending on
+// the real L1 are not carried over.
+func (env *Solo) forkChain(name string, chainID isc.ChainID, restore func(state.Store) trie.Hash) *Chain {
+	require.Nil(env.T, env.recorder, "a forked chain cannot be recorded in a scenario")
+
+	env.chainsMutex.Lock()
+	defer env.chainsMutex.Unlock()
+
+	_, exists := env.chains[chainID]
+	require.False(env.T, exists, "chain %s already exists", chainID)
+
+	db, writeMutex, err := env.chainStateDatabaseManager.ChainStateKVStore(chainID)
+	require.NoError(env.T, err)
+	store := indexedstore.New(state.NewStore(db, writeMutex))
+	trieRoot := restore(store)
+	require.NoError(env.T, store.SetLatest(trieRoot))
+
+	block, err := store.BlockByTrieRoot(trieRoot)
+	require.NoError(env.T, err)
+	chainState, err := store.StateByTrieRoot(trieRoot)
+	require.NoError(env.T, err)
+
+	// the logical clock must not go back in time in the forked chain
+	if d := chainState.Timestamp().Sub(env.utxoDB.GlobalTime()); d > 0 {
+		env.utxoDB.AdvanceClockBy(d)
+	}
+
+	stateControllerKey := env.NewKeyPairFromIndex(-1)
+	originator := env.NewKeyPairFromIndex(-1000 + len(env.chains))
+	_, err = env.utxoDB.GetFundsFromFaucet(originator.Address())
+	require.NoError(env.T, err)
+
+	// The outputs created in the latest block get their transaction ID from
+	// the anchor output in the next block, so the forked anchor output takes
+	// the place of the real one in a made-up transaction.
+	anchorTxID := iotago.TransactionID(hashing.HashData(block.L1Commitment().Bytes()))
+	outputs := make(iotago.OutputSet)
+	maxFoundrySN := uint32(0)
+	for outputID, output := range accounts.ChainOutputs(subrealm.NewReadOnly(chainState, kv.Key(accounts.Contract.Hname().Bytes())), chainID) {
+		if outputID.TransactionID() == (iotago.TransactionID{}) {
+			outputID = iotago.OutputIDFromTransactionIDAndIndex(anchorTxID, outputID.Index())
+		}
+		if foundry, ok := output.(*iotago.FoundryOutput); ok && foundry.SerialNumber > maxFoundrySN {
+			maxFoundrySN = foundry.SerialNumber
+		}
+		outputs[outputID] = output
+	}
+	anchorOutput := env.forkedAnchorOutput(chainID, chainState, block.L1Commitment(), stateControllerKey.Address(), maxFoundrySN)
+	outputs[iotago.OutputIDFromTransactionIDAndIndex(anchorTxID, 0)] = anchorOutput
+	require.NoError(env.T, env.utxoDB.AddForeignOutputs(outputs))
+
+	env.logger.Infof("forked chain '%s'. ID: %s, block index: %d, trie root: %s",
+		name, chainID, chainState.BlockIndex(), trieRoot)
+
+	ch := env.addChain(chainData{
+		Name:                   name,
+		ChainID:                chainID,
+		StateControllerKeyPair: stateControllerKey,
+		OriginatorPrivateKey:   originator,
+		ValidatorFeeTarget:     isc.NewAgentID(originator.Address()),
+		db:                     db,
+		writeMutex:             writeMutex,
+	})
+	return ch
+}
+
+// forkedAnchorOutput makes the anchor output of the forked chain state,
+// controlled by the given state controller. It holds the base tokens of the
+// L2 accounts plus the minimum storage deposit.
+func (env *Solo) forkedAnchorOutput(
+	chainID isc.ChainID,
+	chainState state.State,
+	l1Commitment *state.L1Commitment,
+	stateController iotago.Address,
+	foundryCounter uint32,
+) *iotago.AliasOutput {
+	governanceState := subrealm.NewReadOnly(chainState, kv.Key(governance.Contract.Hname().Bytes()))
+	publicURL, _ := governance.GetPublicURL(governanceState)
+	stateMetadata := transaction.NewStateMetadata(
+		l1Commitment,
+		governance.MustGetGasFeePolicy(governanceState),
+		root.GetSchemaVersion(subrealm.NewReadOnly(chainState, kv.Key(root.Contract.Hname().Bytes()))),
+		publicURL,
+	)
+	ao := &iotago.AliasOutput{
+		AliasID:        chainID.AsAliasID(),
+		StateIndex:     chainState.BlockIndex(),
+		StateMetadata:  stateMetadata.Bytes(),
+		FoundryCounter: foundryCounter,
+		Conditions: iotago.UnlockConditions{
+			&iotago.StateControllerAddressUnlockCondition{Address: stateController},
+			&iotago.GovernorAddressUnlockCondition{Address: stateController},
+		},
+		Features: iotago.Features{
+			&iotago.SenderFeature{Address: chainID.AsAddress()},
+		},
+	}
+	totalL2BaseTokens := accounts.GetTotalL2FungibleTokens(subrealm.NewReadOnly(chainState, kv.Key(accounts.Contract.Hname().Bytes()))).BaseTokens
+	ao.Amount = totalL2BaseTokens + parameters.L1().Protocol.RentStructure.MinRent(ao)
+	return ao
+}
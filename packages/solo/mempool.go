@@ -18,6 +18,7 @@ type Mempool interface {
 	ReceiveRequests(reqs ...isc.Request)
 	RequestBatchProposal() []isc.Request
 	RemoveRequest(reqs isc.RequestID)
+	OffLedgerRequests() []isc.OffLedgerRequest
 	Info() MempoolInfo
 }
 
@@ -99,6 +100,20 @@ func (mi *mempoolImpl) RemoveRequest(rID isc.RequestID) {
 	delete(mi.requests, rID)
 }
 
+func (mi *mempoolImpl) OffLedgerRequests() []isc.OffLedgerRequest {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	ret := []isc.OffLedgerRequest{}
+	for _, request := range mi.requests {
+		if offLedgerReq, ok := request.(isc.OffLedgerRequest); ok {
+			ret = append(ret, offLedgerReq)
+		}
+	}
+	return ret
+}
+
 func (mi *mempoolImpl) Info() MempoolInfo {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
 	return mi.info
 }
@@ -49,21 +49,21 @@ func (ch *Chain) estimateGas(req isc.Request) (result *vm.RequestResult) {
 	ch.runVMMutex.Lock()
 	defer ch.runVMMutex.Unlock()
 
-	res := ch.runTaskNoLock([]isc.Request{req}, true)
+	res := ch.runTaskNoLock([]isc.Request{req}, ch.Env.GlobalTime(), hashing.PseudoRandomHash(nil), true)
 	require.Len(ch.Env.T, res.RequestResults, 1, "cannot estimate gas: request was skipped")
 	return res.RequestResults[0]
 }
 
-func (ch *Chain) runTaskNoLock(reqs []isc.Request, estimateGas bool) *vm.VMTaskResult {
+func (ch *Chain) runTaskNoLock(reqs []isc.Request, timestamp time.Time, entropy hashing.HashValue, estimateGas bool) *vm.VMTaskResult {
 	anchorOutput := ch.GetAnchorOutputFromL1()
 	task := &vm.VMTask{
 		Processors:         ch.proc,
 		AnchorOutput:       anchorOutput.GetAliasOutput(),
 		AnchorOutputID:     anchorOutput.OutputID(),
 		Requests:           reqs,
-		TimeAssumption:     ch.Env.GlobalTime(),
+		TimeAssumption:     timestamp,
 		Store:              ch.store,
-		Entropy:            hashing.PseudoRandomHash(nil),
+		Entropy:            entropy,
 		ValidatorFeeTarget: ch.ValidatorFeeTarget,
 		Log:                ch.Log().Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar(),
 		// state baseline is always valid in Solo
@@ -79,9 +79,15 @@ func (ch *Chain) runTaskNoLock(reqs []isc.Request, estimateGas bool) *vm.VMTaskR
 }
 
 func (ch *Chain) runRequestsNolock(reqs []isc.Request, trace string) (results []*vm.RequestResult) {
+	return ch.runBlockNolock(reqs, ch.Env.GlobalTime(), hashing.PseudoRandomHash(nil), trace)
+}
+
+// runBlockNolock runs the requests with the given time assumption and entropy,
+// so that blocks can be reproduced when replaying a scenario
+func (ch *Chain) runBlockNolock(reqs []isc.Request, timestamp time.Time, entropy hashing.HashValue, trace string) (results []*vm.RequestResult) {
 	ch.Log().Debugf("runRequestsNolock ('%s')", trace)
 
-	res := ch.runTaskNoLock(reqs, false)
+	res := ch.runTaskNoLock(reqs, timestamp, entropy, false)
 
 	var essence *iotago.TransactionEssence
 	if res.RotationAddress == nil {
@@ -111,7 +117,8 @@ func (ch *Chain) runRequestsNolock(reqs []isc.Request, trace string) (results []
 		ch.settleStateTransition(tx, res.StateDraft)
 	}
 
-	err = ch.Env.AddToLedger(tx)
+	// the anchor transaction is not recorded in the scenario, it is produced again when replaying the block
+	err = ch.Env.utxoDB.AddToLedger(tx)
 	require.NoError(ch.Env.T, err)
 
 	anchor, _, err := transaction.GetAnchorFromTransaction(tx)
@@ -125,6 +132,7 @@ func (ch *Chain) runRequestsNolock(reqs []isc.Request, trace string) (results []
 	l1C := ch.GetL1Commitment()
 	require.Equal(ch.Env.T, rootC, l1C.TrieRoot())
 
+	ch.recordBlock(reqs, timestamp, entropy)
 	ch.Env.EnqueueRequests(tx)
 
 	return res.RequestResults
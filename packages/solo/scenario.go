@@ -0,0 +1,269 @@
+// Copyright 2020 IOTA Stiftung
+// SPDX-License-Identifier: Apache-2.0
+
+package solo
+
+import (
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/hashing"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/origin"
+	"github.com/iotaledger/wasp/packages/state"
+	"github.com/iotaledger/wasp/packages/state/indexedstore"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
+// Scenario is the recording of everything that changed the Solo environment:
+// the L1 transactions, the chain deployments, the blocks produced by the
+// chains and the clock advancements. Replayed in a fresh environment, it
+// produces the same chain databases (see GetDBHash).
+type Scenario struct {
+	Steps []*ScenarioStep
+	// DBHash is the hash of the chain databases at the end of the recording
+	DBHash hashing.HashValue
+}
+
+// ScenarioStep is a single recorded action; exactly one of the fields is set.
+type ScenarioStep struct {
+	AdvanceClock    time.Duration            `json:",omitempty"`
+	L1Tx            *iotago.Transaction      `json:",omitempty"`
+	Chain           *ScenarioChain           `json:",omitempty"`
+	StateController *ScenarioStateController `json:",omitempty"`
+	Block           *ScenarioBlock           `json:",omitempty"`
+}
+
+// ScenarioChain is the deployment of a chain by its origin transaction.
+type ScenarioChain struct {
+	Name                   string
+	OriginTx               *iotago.Transaction
+	StateControllerKeyPair []byte
+	OriginatorPrivateKey   []byte
+}
+
+// ScenarioStateController is the rotation of the state controller of a chain.
+type ScenarioStateController struct {
+	ChainID                []byte
+	StateControllerKeyPair []byte
+}
+
+// ScenarioBlock is a block produced by a chain, with the data needed to run
+// the VM deterministically and the resulting L1 commitment.
+type ScenarioBlock struct {
+	ChainID            []byte
+	Requests           [][]byte
+	Timestamp          time.Time
+	Entropy            []byte
+	ValidatorFeeTarget []byte
+	L1Commitment       []byte
+}
+
+// scenarioRecorder collects the steps while the environment is recording
+type scenarioRecorder struct {
+	mutex sync.Mutex
+	steps []*ScenarioStep
+}
+
+// RecordScenario starts recording the actions on the environment. It must be
+// called on a fresh environment, before any chain is deployed and any L1
+// transaction is added.
+// Forked chains, restored snapshots and migrations added with AddMigration
+// are not recorded.
+func (env *Solo) RecordScenario() {
+	env.chainsMutex.Lock()
+	defer env.chainsMutex.Unlock()
+
+	require.Empty(env.T, env.chains, "scenario recording must start on a fresh environment")
+	env.recorder = &scenarioRecorder{}
+}
+
+// Scenario returns the steps recorded so far, along with the current hash of
+// the chain databases.
+func (env *Solo) Scenario() *Scenario {
+	require.NotNil(env.T, env.recorder, "scenario is not being recorded")
+	env.recorder.mutex.Lock()
+	defer env.recorder.mutex.Unlock()
+
+	return &Scenario{
+		Steps:  append([]*ScenarioStep(nil), env.recorder.steps...),
+		DBHash: env.GetDBHash(),
+	}
+}
+
+func (env *Solo) recordStep(step *ScenarioStep) {
+	if env.recorder == nil {
+		return
+	}
+	env.recorder.mutex.Lock()
+	defer env.recorder.mutex.Unlock()
+	env.recorder.steps = append(env.recorder.steps, step)
+}
+
+// SaveScenario saves the given scenario to a file, e.g. to be attached to a bug report
+func (env *Solo) SaveScenario(scenario *Scenario, fname string) {
+	b, err := json.Marshal(scenario)
+	require.NoError(env.T, err)
+	err = os.WriteFile(fname, b, 0o600)
+	require.NoError(env.T, err)
+}
+
+// LoadScenario loads a scenario previously saved with SaveScenario
+func (env *Solo) LoadScenario(fname string) *Scenario {
+	b, err := os.ReadFile(fname)
+	require.NoError(env.T, err)
+	var scenario Scenario
+	err = json.Unmarshal(b, &scenario)
+	require.NoError(env.T, err)
+	return &scenario
+}
+
+// ReplayScenario runs the recorded steps on a fresh environment and asserts
+// that every block and, at the end, the hash of the chain databases are the
+// same as in the recording. The native contracts used in the scenario must be
+// registered in the environment (see WithNativeContract).
+// The batch loop is paused during the replay, as the blocks are replayed
+// exactly as they were recorded.
+func (env *Solo) ReplayScenario(scenario *Scenario) {
+	env.batchLoopPaused.Store(true)
+	defer env.batchLoopPaused.Store(false)
+
+	for i, step := range scenario.Steps {
+		switch {
+		case step.AdvanceClock != 0:
+			env.AdvanceClockBy(step.AdvanceClock)
+		case step.L1Tx != nil:
+			err := env.AddToLedger(step.L1Tx)
+			require.NoError(env.T, err, "scenario step #%d", i)
+		case step.Chain != nil:
+			env.replayChain(step.Chain)
+		case step.StateController != nil:
+			ch := env.replayChainByID(step.StateController.ChainID)
+			kp, err := rwutil.ReadFromBytes(step.StateController.StateControllerKeyPair, new(cryptolib.KeyPair))
+			require.NoError(env.T, err)
+			ch.setStateController(kp.Address(), kp)
+		case step.Block != nil:
+			env.replayBlock(i, step.Block)
+		default:
+			env.T.Fatalf("invalid scenario step #%d", i)
+		}
+	}
+	require.Equal(env.T, scenario.DBHash, env.GetDBHash(), "DB hash mismatch after replaying the scenario")
+}
+
+func (env *Solo) replayChainByID(chainIDBytes []byte) *Chain {
+	chainID, err := isc.ChainIDFromBytes(chainIDBytes)
+	require.NoError(env.T, err)
+
+	env.chainsMutex.RLock()
+	defer env.chainsMutex.RUnlock()
+	ch, ok := env.chains[chainID]
+	require.True(env.T, ok, "chain %s does not exist", chainID)
+	return ch
+}
+
+func (env *Solo) replayChain(rec *ScenarioChain) {
+	sckp, err := rwutil.ReadFromBytes(rec.StateControllerKeyPair, new(cryptolib.KeyPair))
+	require.NoError(env.T, err)
+	okp, err := rwutil.ReadFromBytes(rec.OriginatorPrivateKey, new(cryptolib.KeyPair))
+	require.NoError(env.T, err)
+
+	err = env.utxoDB.AddToLedger(rec.OriginTx)
+	require.NoError(env.T, err)
+	_, originAO, err := transaction.GetAnchorFromTransaction(rec.OriginTx)
+	require.NoError(env.T, err)
+	txID, err := rec.OriginTx.ID()
+	require.NoError(env.T, err)
+	originAOID := iotago.OutputIDFromTransactionIDAndIndex(txID, 0)
+	chainID := isc.ChainIDFromAliasID(iotago.AliasIDFromOutputID(originAOID))
+
+	db, writeMutex, err := env.chainStateDatabaseManager.ChainStateKVStore(chainID)
+	require.NoError(env.T, err)
+	_, err = origin.InitChainByAliasOutput(
+		indexedstore.New(state.NewStoreWithUniqueWriteMutex(db)),
+		isc.NewAliasOutputWithID(originAO, originAOID),
+	)
+	require.NoError(env.T, err)
+
+	env.chainsMutex.Lock()
+	defer env.chainsMutex.Unlock()
+	ch := env.addChain(chainData{
+		Name:                   rec.Name,
+		ChainID:                chainID,
+		StateControllerKeyPair: sckp,
+		OriginatorPrivateKey:   okp,
+		ValidatorFeeTarget:     isc.NewAgentID(okp.Address()),
+		db:                     db,
+		writeMutex:             writeMutex,
+	})
+	ch.recordDeployment(rec.OriginTx)
+	ch.log.Infof("chain '%s' replayed. Chain ID: %s", ch.Name, ch.ChainID.String())
+}
+
+func (env *Solo) replayBlock(i int, rec *ScenarioBlock) {
+	ch := env.replayChainByID(rec.ChainID)
+	reqs := make([]isc.Request, len(rec.Requests))
+	for j, data := range rec.Requests {
+		var err error
+		reqs[j], err = isc.RequestFromBytes(data)
+		require.NoError(env.T, err)
+	}
+	entropy, err := hashing.HashValueFromBytes(rec.Entropy)
+	require.NoError(env.T, err)
+	ch.ValidatorFeeTarget, err = isc.AgentIDFromBytes(rec.ValidatorFeeTarget)
+	require.NoError(env.T, err)
+	expected, err := state.L1CommitmentFromBytes(rec.L1Commitment)
+	require.NoError(env.T, err)
+
+	func() {
+		ch.runVMMutex.Lock()
+		defer ch.runVMMutex.Unlock()
+		ch.runBlockNolock(reqs, rec.Timestamp, entropy, "replay")
+	}()
+	actual := ch.GetL1Commitment()
+	require.True(env.T, expected.Equals(actual),
+		"scenario step #%d: L1 commitment mismatch in chain %s: expected %s, got %s", i, ch.ChainID, expected, actual)
+}
+
+func (ch *Chain) recordDeployment(originTx *iotago.Transaction) {
+	ch.Env.recordStep(&ScenarioStep{Chain: &ScenarioChain{
+		Name:                   ch.Name,
+		OriginTx:               originTx,
+		StateControllerKeyPair: rwutil.WriteToBytes(ch.StateControllerKeyPair),
+		OriginatorPrivateKey:   rwutil.WriteToBytes(ch.OriginatorPrivateKey),
+	}})
+}
+
+func (ch *Chain) recordBlock(reqs []isc.Request, timestamp time.Time, entropy hashing.HashValue) {
+	if ch.Env.recorder == nil {
+		return
+	}
+	rec := &ScenarioBlock{
+		ChainID:            ch.ChainID.Bytes(),
+		Requests:           make([][]byte, len(reqs)),
+		Timestamp:          timestamp,
+		Entropy:            entropy.Bytes(),
+		ValidatorFeeTarget: ch.ValidatorFeeTarget.Bytes(),
+		L1Commitment:       ch.GetL1Commitment().Bytes(),
+	}
+	for i, req := range reqs {
+		rec.Requests[i] = req.Bytes()
+	}
+	ch.Env.recordStep(&ScenarioStep{Block: rec})
+}
+
+func (ch *Chain) setStateController(addr iotago.Address, kp *cryptolib.KeyPair) {
+	ch.StateControllerAddress = addr
+	ch.StateControllerKeyPair = kp
+	ch.Env.recordStep(&ScenarioStep{StateController: &ScenarioStateController{
+		ChainID:                ch.ChainID.Bytes(),
+		StateControllerKeyPair: rwutil.WriteToBytes(kp),
+	}})
+}
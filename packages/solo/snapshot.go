@@ -60,6 +60,8 @@ func (env *Solo) TakeSnapshot() *Snapshot {
 
 // LoadSnapshot restores the Solo environment from the given snapshot
 func (env *Solo) RestoreSnapshot(snapshot *Snapshot) {
+	require.Nil(env.T, env.recorder, "a snapshot cannot be restored while recording a scenario")
+
 	env.chainsMutex.Lock()
 	defer env.chainsMutex.Unlock()
 
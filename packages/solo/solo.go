@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/samber/lo"
@@ -70,6 +71,9 @@ type Solo struct {
 	seed                            cryptolib.Seed
 	publisher                       *publisher.Publisher
 	ctx                             context.Context
+	// recorder is not nil while a scenario is being recorded
+	recorder        *scenarioRecorder
+	batchLoopPaused atomic.Bool
 }
 
 // data to be persisted in the snapshot
@@ -209,6 +213,9 @@ func New(t Context, initOptions ...*InitOptions) *Solo {
 func (env *Solo) batchLoop() {
 	for {
 		time.Sleep(50 * time.Millisecond)
+		if env.batchLoopPaused.Load() {
+			continue
+		}
 		chains := func() []*Chain {
 			env.chainsMutex.Lock()
 			defer env.chainsMutex.Unlock()
@@ -271,7 +278,7 @@ func (env *Solo) deployChain(
 	if chainOriginator == nil {
 		chainOriginator = env.NewKeyPairFromIndex(-1000 + len(env.chains)) // making new originator for each new chain
 		originatorAddr := chainOriginator.GetPublicKey().AsEd25519Address()
-		_, err := env.utxoDB.GetFundsFromFaucet(originatorAddr)
+		_, err := env.GetFundsFromFaucet(originatorAddr)
 		require.NoError(env.T, err)
 	}
 
@@ -367,6 +374,7 @@ func (env *Solo) NewChainExt(
 	env.chainsMutex.Lock()
 	defer env.chainsMutex.Unlock()
 	ch := env.addChain(chData)
+	ch.recordDeployment(originTx)
 
 	ch.log.Infof("chain '%s' deployed. Chain ID: %s", ch.Name, ch.ChainID.String())
 	return ch, originTx
@@ -393,7 +401,11 @@ func (env *Solo) addChain(chData chainData) *Chain {
 // AddToLedger adds (synchronously confirms) transaction to the UTXODB ledger. Return error if it is
 // invalid or double spend
 func (env *Solo) AddToLedger(tx *iotago.Transaction) error {
-	return env.utxoDB.AddToLedger(tx)
+	if err := env.utxoDB.AddToLedger(tx); err != nil {
+		return err
+	}
+	env.recordStep(&ScenarioStep{L1Tx: tx})
+	return nil
 }
 
 // RequestsForChain parses the transaction and returns all requests contained in it which have chainID as the target
@@ -31,7 +31,7 @@ func (env *Solo) NewKeyPairWithFunds(seed ...*cryptolib.Seed) (*cryptolib.KeyPai
 	env.ledgerMutex.Lock()
 	defer env.ledgerMutex.Unlock()
 
-	_, err := env.utxoDB.GetFundsFromFaucet(addr)
+	_, err := env.GetFundsFromFaucet(addr)
 	require.NoError(env.T, err)
 	env.AssertL1BaseTokens(addr, utxodb.FundsFromFaucetAmount)
 
@@ -39,7 +39,12 @@ func (env *Solo) NewKeyPairWithFunds(seed ...*cryptolib.Seed) (*cryptolib.KeyPai
 }
 
 func (env *Solo) GetFundsFromFaucet(target iotago.Address, amount ...uint64) (*iotago.Transaction, error) {
-	return env.utxoDB.GetFundsFromFaucet(target, amount...)
+	tx, err := env.utxoDB.GetFundsFromFaucet(target, amount...)
+	if err != nil {
+		return nil, err
+	}
+	env.recordStep(&ScenarioStep{L1Tx: tx})
+	return tx, nil
 }
 
 // NewSignatureSchemeAndPubKey generates new ed25519 signature scheme
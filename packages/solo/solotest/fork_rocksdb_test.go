@@ -0,0 +1,50 @@
+//go:build rocksdb
+
+package solo_test
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	hivedb "github.com/iotaledger/hive.go/kvstore/database"
+	"github.com/iotaledger/wasp/packages/database"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/state"
+)
+
+func TestForkChainFromDB(t *testing.T) {
+	env := solo.New(t)
+	ch := env.NewChain()
+	user, _ := env.NewKeyPairWithFunds()
+	ch.MustDepositBaseTokensToL2(10*isc.Million, user)
+	block, err := ch.Store().LatestBlock()
+	require.NoError(t, err)
+
+	// a chain state database of a node, with the same content as the chain
+	dbDir := filepath.Join(t.TempDir(), ch.ChainID.String())
+	db, err := database.DatabaseWithDefaultSettings(dbDir, true, hivedb.EngineRocksDB, false, database.AllowedEnginesStorage...)
+	require.NoError(t, err)
+	nodeStore := state.NewStoreWithUniqueWriteMutex(db.KVStore())
+	r, w := io.Pipe()
+	go func() {
+		w.CloseWithError(ch.Store().TakeSnapshot(block.TrieRoot(), w))
+	}()
+	require.NoError(t, nodeStore.RestoreSnapshot(block.TrieRoot(), r))
+	require.NoError(t, nodeStore.SetLatest(block.TrieRoot()))
+	require.NoError(t, db.KVStore().Flush())
+	require.NoError(t, db.KVStore().Close())
+
+	forkEnv := solo.New(t)
+	fork := forkEnv.ForkChainFromDB("fork", ch.ChainID, dbDir, -1)
+	require.Equal(t, ch.LatestBlockIndex(), fork.LatestBlockIndex())
+	require.Equal(t, ch.L2BaseTokens(isc.NewAgentID(user.Address())), fork.L2BaseTokens(isc.NewAgentID(user.Address())))
+
+	_, err = forkEnv.L1Ledger().GetFundsFromFaucet(user.Address())
+	require.NoError(t, err)
+	require.NoError(t, fork.Withdraw(isc.NewAssetsBaseTokens(1*isc.Million), user))
+	require.Equal(t, ch.LatestBlockIndex()+1, fork.LatestBlockIndex())
+}
@@ -0,0 +1,68 @@
+package solo_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_snapshots"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+)
+
+func TestForkChainFromSnapshot(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	ch := env.NewChain()
+	owner := ch.OriginatorPrivateKey
+
+	// native tokens, a foundry and an NFT are held by the chain on L1
+	sn, nativeTokenID, err := ch.NewFoundryParams(10000).CreateFoundry()
+	require.NoError(t, err)
+	require.NoError(t, ch.MintTokens(sn, 1000, owner))
+	nft, _, err := env.MintNFTL1(owner, ch.OriginatorAddress, []byte("foobar"))
+	require.NoError(t, err)
+	_, err = ch.PostRequestSync(
+		solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).
+			WithNFT(nft).
+			AddBaseTokens(10*isc.Million).
+			WithMaxAffordableGasBudget(),
+		owner)
+	require.NoError(t, err)
+
+	// the snapshot, as written by the snapshot manager of a node
+	latestState, err := ch.Store().LatestState()
+	require.NoError(t, err)
+	block, err := ch.Store().LatestBlock()
+	require.NoError(t, err)
+	fileName := filepath.Join(t.TempDir(), "snapshot")
+	f, err := os.Create(fileName)
+	require.NoError(t, err)
+	snapshotInfo := sm_snapshots.NewSnapshotInfo(latestState.BlockIndex(), block.L1Commitment())
+	require.NoError(t, sm_snapshots.WriteSnapshot(ch.Store(), snapshotInfo, f))
+	require.NoError(t, f.Close())
+
+	forkEnv := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	fork := forkEnv.ForkChainFromSnapshot("fork", ch.ChainID, fileName)
+	require.Equal(t, ch.LatestBlockIndex(), fork.LatestBlockIndex())
+	require.Equal(t, ch.L2TotalAssets(), fork.L2TotalAssets())
+	fork.AssertL2NativeTokens(isc.NewAgentID(owner.Address()), nativeTokenID, 1000)
+	require.Equal(t, []iotago.NFTID{nft.ID}, fork.L2NFTs(isc.NewAgentID(owner.Address())))
+
+	// the requests consuming the chain outputs are valid on the local L1
+	_, err = forkEnv.L1Ledger().GetFundsFromFaucet(owner.Address())
+	require.NoError(t, err)
+	require.NoError(t, fork.MintTokens(sn, 500, owner))
+	fork.AssertL2NativeTokens(isc.NewAgentID(owner.Address()), nativeTokenID, 1500)
+	require.NoError(t, fork.Withdraw(isc.NewEmptyAssets().AddNativeTokens(nativeTokenID, 700).AddNFTs(nft.ID), owner))
+	forkEnv.AssertL1NativeTokens(owner.Address(), nativeTokenID, 700)
+	require.Len(t, forkEnv.L1NFTs(owner.Address()), 1)
+	fork.AssertL2NativeTokens(isc.NewAgentID(owner.Address()), nativeTokenID, 800)
+	require.Equal(t, ch.LatestBlockIndex()+2, fork.LatestBlockIndex())
+
+	// the original chain is not affected
+	ch.AssertL2NativeTokens(isc.NewAgentID(owner.Address()), nativeTokenID, 1000)
+}
@@ -0,0 +1,121 @@
+package solo_test
+
+import (
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/evm/evmutil"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/solo"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+func TestScenarioRecordAndReplay(t *testing.T) {
+	env := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	env.RecordScenario()
+
+	ch := env.NewChain()
+	ch2, _ := env.NewChainExt(nil, 0, "chain2")
+	user, userAddr := env.NewKeyPairWithFunds()
+
+	sn, nativeTokenID, err := ch.NewFoundryParams(1000).CreateFoundry()
+	require.NoError(t, err)
+	require.NoError(t, ch.MintTokens(sn, 100, ch.OriginatorPrivateKey))
+	nft, _, err := env.MintNFTL1(user, userAddr, []byte("foobar"))
+	require.NoError(t, err)
+	ch.MustDepositNFT(nft, isc.NewAgentID(userAddr), user)
+	env.AdvanceClockBy(5 * time.Minute)
+	env.AdvanceClockBy(0)
+
+	// off-ledger requests, on both chains
+	_, err = ch.PostRequestOffLedger(
+		solo.NewCallParams(accounts.Contract.Name, accounts.FuncTransferAllowanceTo.Name, accounts.ParamAgentID, isc.NewAgentID(userAddr)).
+			AddAllowanceNativeTokens(nativeTokenID, 10).
+			WithGasBudget(gas.LimitsDefault.MinGasPerRequest*10),
+		ch.OriginatorPrivateKey,
+	)
+	require.NoError(t, err)
+	ch2.MustDepositBaseTokensToL2(10*isc.Million, user)
+
+	// an EVM transaction
+	ethKey, _ := ch.NewEthereumAccountWithL2Funds()
+	_, someEthAddr := solo.NewEthereumAccount()
+	tx, err := types.SignTx(
+		types.NewTransaction(0, someEthAddr, big.NewInt(0), 100_000, big.NewInt(0), nil),
+		evmutil.Signer(big.NewInt(int64(evm.DefaultChainID))),
+		ethKey,
+	)
+	require.NoError(t, err)
+	_, err = ch.PostEthereumTransaction(tx)
+	require.NoError(t, err)
+
+	// a request processed asynchronously by the batch loop
+	reqTx, _, err := ch.RequestFromParamsToLedger(
+		solo.NewCallParams(accounts.Contract.Name, accounts.FuncDeposit.Name).AddBaseTokens(isc.Million).WithMaxAffordableGasBudget(),
+		user,
+	)
+	require.NoError(t, err)
+	env.EnqueueRequests(reqTx)
+	require.True(t, ch.WaitUntilMempoolIsEmpty())
+
+	scenarioFile := filepath.Join(t.TempDir(), "scenario.json")
+	env.SaveScenario(env.Scenario(), scenarioFile)
+
+	replayEnv := solo.New(t, &solo.InitOptions{AutoAdjustStorageDeposit: true})
+	replayEnv.ReplayScenario(replayEnv.LoadScenario(scenarioFile))
+	require.Equal(t, env.GetDBHash(), replayEnv.GetDBHash())
+
+	replayed := replayEnv.GetChainByName(ch.Name)
+	require.Equal(t, ch.ChainID, replayed.ChainID)
+	require.Equal(t, ch.LatestBlockIndex(), replayed.LatestBlockIndex())
+	replayed.AssertL2NativeTokens(isc.NewAgentID(userAddr), nativeTokenID, 10)
+	require.True(t, replayed.HasL2NFT(isc.NewAgentID(userAddr), &nft.ID))
+	require.Equal(t, env.GlobalTime(), replayEnv.GlobalTime())
+
+	// the replayed chains keep working
+	replayEnv.AssertL1BaseTokens(userAddr, env.L1BaseTokens(userAddr))
+	replayed.MustDepositBaseTokensToL2(isc.Million, user)
+}
+
+func TestScenarioReplayMismatch(t *testing.T) {
+	env := solo.New(t)
+	env.RecordScenario()
+	ch := env.NewChain()
+	ch.MustDepositBaseTokensToL2(isc.Million, nil)
+	scenario := env.Scenario()
+
+	// a tampered block is detected when replayed
+	last := scenario.Steps[len(scenario.Steps)-1]
+	require.NotNil(t, last.Block)
+	last.Block.Timestamp = last.Block.Timestamp.Add(time.Second)
+
+	replayT := &mockT{T: t}
+	func() {
+		defer func() { _ = recover() }()
+		solo.New(replayT).ReplayScenario(scenario)
+	}()
+	require.True(t, replayT.failed)
+}
+
+// mockT records the failure of the assertions instead of failing the test
+type mockT struct {
+	*testing.T
+	failed bool
+}
+
+func (m *mockT) Errorf(format string, args ...interface{}) {
+	m.Logf(format, args...)
+	m.failed = true
+}
+
+func (m *mockT) FailNow() {
+	m.failed = true
+	panic("FailNow")
+}
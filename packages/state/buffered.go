@@ -25,8 +25,9 @@ func newBufferedKVStore(db kvstore.KVStore) *bufferedKVStore {
 	}
 }
 
-func (*bufferedKVStore) Batched() (kvstore.BatchedMutations, error) {
-	panic("should no be called")
+// Batched returns the mutations, which are written to the buffer on commit.
+func (b *bufferedKVStore) Batched() (kvstore.BatchedMutations, error) {
+	return &bufferedBatch{store: b, muts: buffered.NewMutations()}, nil
 }
 
 func (*bufferedKVStore) Clear() error {
@@ -90,3 +91,34 @@ func (*bufferedKVStore) WithRealm(realm []byte) (kvstore.KVStore, error) {
 func (*bufferedKVStore) WithExtendedRealm(realm []byte) (kvstore.KVStore, error) {
 	panic("should no be called")
 }
+
+type bufferedBatch struct {
+	store *bufferedKVStore
+	muts  *buffered.Mutations
+}
+
+var _ kvstore.BatchedMutations = &bufferedBatch{}
+
+func (b *bufferedBatch) Set(key []byte, value []byte) error {
+	b.muts.Set(kv.Key(key), value)
+	return nil
+}
+
+func (b *bufferedBatch) Delete(key []byte) error {
+	b.muts.Del(kv.Key(key))
+	return nil
+}
+
+func (b *bufferedBatch) Cancel() {
+	b.muts = buffered.NewMutations()
+}
+
+func (b *bufferedBatch) Commit() error {
+	for k, v := range b.muts.Sets {
+		b.store.muts.Set(k, v)
+	}
+	for k := range b.muts.Dels {
+		b.store.muts.Del(k)
+	}
+	return nil
+}
@@ -38,6 +38,16 @@ func keyLargestPrunedBlockIndex() []byte {
 	return []byte{chaindb.PrefixLargestPrunedBlockIndex}
 }
 
+// The total number of node references still to be released by the incremental
+// trie pruning is stored under the queue prefix itself.
+func keyTriePruningBacklog() []byte {
+	return []byte{chaindb.PrefixTriePruningQueue}
+}
+
+func keyTriePruningQueue(node trie.Hash) []byte {
+	return append(keyTriePruningBacklog(), node.Bytes()...)
+}
+
 func mustNoErr(err error) {
 	if err != nil {
 		panic(err)
@@ -49,6 +59,7 @@ func mustNoErr(err error) {
 // - The trie storage, under the prefixTrie partition. This includes one trie root for each state index.
 // - One block per trie root, under the prefixBlockByTrieRoot partition.
 // - The trie root that is considered 'latest' in the chain, under prefixLatestTrieRoot
+// - The trie nodes still to be pruned, under prefixTriePruningQueue
 type storeDB struct {
 	kvstore.KVStore
 }
@@ -88,6 +99,86 @@ func (db *storeDB) setLargestPrunedBlockIndex(blockIndex uint32) {
 	db.mustSet(keyLargestPrunedBlockIndex(), codec.EncodeUint32(blockIndex))
 }
 
+func (db *storeDB) updateLargestPrunedBlockIndex(blockIndex uint32) {
+	largestPrunedBlockIndex, err := db.largestPrunedBlockIndex()
+	if errors.Is(err, ErrNoBlocksPruned) || (err == nil && blockIndex > largestPrunedBlockIndex) {
+		db.setLargestPrunedBlockIndex(blockIndex)
+	} else if err != nil {
+		panic(err) // should not happen: no other error can be returned from `largestPrunedBlockIndex`
+	}
+}
+
+// The trie pruning queue contains the trie nodes, which references are still
+// to be released by the incremental pruning (see trie.PruneNodes), together
+// with the number of the pending releases of each node.
+
+func (db *storeDB) triePruningBacklog() uint64 {
+	b := db.mustGetOrNil(keyTriePruningBacklog())
+	if b == nil {
+		return 0
+	}
+	return codec.MustDecodeUint64(b)
+}
+
+func (db *storeDB) setTriePruningBacklog(backlog uint64) {
+	if backlog == 0 {
+		db.mustDel(keyTriePruningBacklog())
+	} else {
+		db.mustSet(keyTriePruningBacklog(), codec.EncodeUint64(backlog))
+	}
+}
+
+func (db *storeDB) triePruningQueueCount(node trie.Hash) uint32 {
+	b := db.mustGetOrNil(keyTriePruningQueue(node))
+	if b == nil {
+		return 0
+	}
+	return codec.MustDecodeUint32(b)
+}
+
+func (db *storeDB) setTriePruningQueueCount(node trie.Hash, count uint32) {
+	if count == 0 {
+		db.mustDel(keyTriePruningQueue(node))
+	} else {
+		db.mustSet(keyTriePruningQueue(node), codec.EncodeUint32(count))
+	}
+}
+
+func (db *storeDB) addToTriePruningQueue(nodes []trie.Hash) {
+	for _, node := range nodes {
+		db.setTriePruningQueueCount(node, db.triePruningQueueCount(node)+1)
+	}
+	db.setTriePruningBacklog(db.triePruningBacklog() + uint64(len(nodes)))
+}
+
+func (db *storeDB) removeFromTriePruningQueue(nodes []trie.Hash) {
+	for _, node := range nodes {
+		db.setTriePruningQueueCount(node, db.triePruningQueueCount(node)-1)
+	}
+	db.setTriePruningBacklog(db.triePruningBacklog() - uint64(len(nodes)))
+}
+
+// peekTriePruningQueue returns at most `limit` node references from the queue
+// (a node is repeated as many times, as many references of it are returned).
+// It uses iteration, thus it must not be called on a buffered DB.
+func (db *storeDB) peekTriePruningQueue(limit int) []trie.Hash {
+	var nodes []trie.Hash
+	prefixLen := len(keyTriePruningBacklog())
+	err := db.Iterate(keyTriePruningBacklog(), func(key kvstore.Key, value kvstore.Value) bool {
+		if len(key) == prefixLen {
+			return true // the backlog
+		}
+		node, err := trie.HashFromBytes(key[prefixLen:])
+		mustNoErr(err)
+		for count := codec.MustDecodeUint32(value); count > 0 && len(nodes) < limit; count-- {
+			nodes = append(nodes, node)
+		}
+		return len(nodes) < limit
+	})
+	mustNoErr(err)
+	return nodes
+}
+
 func (db *storeDB) isEmpty() bool {
 	empty := true
 	err := db.Iterate(keyBlockByTrieRootNoTrieRoot(), func(kvstore.Key, kvstore.Value) bool {
@@ -118,6 +209,11 @@ func trieReader(trieStore trie.KVStore, root trie.Hash) (*trie.TrieReader, error
 	return trie.NewTrieReader(trieStore, root)
 }
 
+func (db *storeDB) hasTrieRoot(root trie.Hash) bool {
+	_, err := db.trieReader(root)
+	return err == nil
+}
+
 func (db *storeDB) hasBlock(root trie.Hash) bool {
 	return db.mustHas(keyBlockByTrieRoot(root))
 }
@@ -175,14 +271,40 @@ func (db *storeDB) mustGet(key []byte) []byte {
 	return v
 }
 
+func (db *storeDB) mustGetOrNil(key []byte) []byte {
+	if !db.mustHas(key) {
+		return nil
+	}
+	return db.mustGet(key)
+}
+
 func (db *storeDB) buffered() (*bufferedKVStore, *storeDB) {
 	buf := newBufferedKVStore(db)
 	return buf, &storeDB{buf}
 }
 
+// discardSnapshot deletes the block and the trie nodes with the given root
+// restored from an incomplete or invalid snapshot. The trie nodes that were
+// in the store before are kept.
+func (db *storeDB) discardSnapshot(root trie.Hash) {
+	db.pruneBlock(root)
+	// if the root node is missing, no node was restored
+	_, _ = trie.Prune(trieStore(db), root)
+}
+
 // increment when changing the snapshot format
 const snapshotVersion = 0
 
+func (db *storeDB) diffStates(oldRoot, newRoot trie.Hash, prefix, fromKey []byte, limit int) ([]*trie.KeyDiff, []byte, error) {
+	for _, root := range []trie.Hash{oldRoot, newRoot} {
+		if !db.hasBlock(root) {
+			return nil, nil, fmt.Errorf("%w %s", ErrTrieRootNotFound, root)
+		}
+	}
+	diff, nextKey := trie.DiffKeys(trieStore(db), oldRoot, newRoot, prefix, fromKey, limit)
+	return diff, nextKey, nil
+}
+
 func (db *storeDB) takeSnapshot(root trie.Hash, w io.Writer) error {
 	block, err := db.readBlock(root)
 	if err != nil {
@@ -16,6 +16,7 @@ import (
 
 	"github.com/iotaledger/hive.go/kvstore"
 	"github.com/iotaledger/hive.go/kvstore/mapdb"
+	"github.com/iotaledger/wasp/packages/chaindb"
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/isc/coreutil"
 	"github.com/iotaledger/wasp/packages/kv"
@@ -253,6 +254,72 @@ func TestReplay(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestOverlayStore(t *testing.T) {
+	db := mapdb.NewMapDB()
+	cs := mustChainStore{initializedStore(db)}
+	d := cs.NewStateDraft(time.Now(), cs.LatestBlock().L1Commitment())
+	d.Set("k", []byte("a"))
+	block1 := cs.Commit(d)
+
+	overlay, err := state.NewOverlayStore(cs.Store)
+	require.NoError(t, err)
+	ocs := mustChainStore{overlay}
+	d = ocs.NewStateDraft(time.Now(), block1.L1Commitment())
+	d.Set("k", []byte("b"))
+	block2 := ocs.Commit(d)
+	require.EqualValues(t, []byte("b"), ocs.StateByTrieRoot(block2.TrieRoot()).Get("k"))
+	ocs.checkTrie(block2.TrieRoot())
+
+	// the committed block is only in the overlay
+	require.True(t, overlay.HasTrieRoot(block1.TrieRoot()))
+	require.True(t, overlay.HasTrieRoot(block2.TrieRoot()))
+	require.False(t, cs.HasTrieRoot(block2.TrieRoot()))
+	require.EqualValues(t, []byte("a"), cs.StateByTrieRoot(block1.TrieRoot()).Get("k"))
+}
+
+func TestDiffStates(t *testing.T) {
+	db := mapdb.NewMapDB()
+	cs := mustChainStore{initializedStore(db)}
+
+	d := cs.NewStateDraft(time.Now(), cs.LatestBlock().L1Commitment())
+	d.Set("a1", []byte("x"))
+	d.Set("a2", []byte("y"))
+	d.Set("b1", []byte("z"))
+	block1 := cs.Commit(d)
+
+	d = cs.NewStateDraft(time.Now(), block1.L1Commitment())
+	d.Set("a1", []byte("xx"))
+	d.Del("a2")
+	d.Set("a3", []byte("w"))
+	block2 := cs.Commit(d)
+
+	diff, nextKey, err := cs.DiffStates(block1.TrieRoot(), block2.TrieRoot(), "a", "", 0)
+	require.NoError(t, err)
+	require.Empty(t, nextKey)
+	require.Equal(t, []*trie.KeyDiff{
+		{Key: []byte("a1"), OldValue: []byte("x"), NewValue: []byte("xx")},
+		{Key: []byte("a2"), OldValue: []byte("y")},
+		{Key: []byte("a3"), NewValue: []byte("w")},
+	}, diff)
+
+	diff, nextKey, err = cs.DiffStates(block1.TrieRoot(), block2.TrieRoot(), "a", "a2", 1)
+	require.NoError(t, err)
+	require.Equal(t, []*trie.KeyDiff{{Key: []byte("a2"), OldValue: []byte("y")}}, diff)
+	require.EqualValues(t, "a3", nextKey)
+
+	// the block index, the timestamp and the previous L1 commitment are updated too
+	diff, _, err = cs.DiffStates(block1.TrieRoot(), block2.TrieRoot(), "", "", 0)
+	require.NoError(t, err)
+	require.Len(t, diff, 6)
+
+	diff, _, err = cs.DiffStates(block1.TrieRoot(), block1.TrieRoot(), "", "", 0)
+	require.NoError(t, err)
+	require.Empty(t, diff)
+
+	_, _, err = cs.DiffStates(block1.TrieRoot(), trie.Hash{}, "", "", 0)
+	require.ErrorIs(t, err, state.ErrTrieRootNotFound)
+}
+
 func TestProof(t *testing.T) {
 	db := mapdb.NewMapDB()
 	cs := mustChainStore{initializedStore(db)}
@@ -461,6 +528,49 @@ func TestPruning2(t *testing.T) {
 	}
 }
 
+func TestIncrementalPruning(t *testing.T) {
+	r := newRandomState(t)
+	var trieRoots []trie.Hash
+	for i := 1; i <= 20; i++ {
+		block := r.commitNewBlock(r.cs.LatestBlock(), time.Unix(int64(i), 0))
+		trieRoots = append(trieRoots, block.TrieRoot())
+	}
+
+	// the reference DB is pruned synchronously
+	rRef := newRandomState(t)
+	for i := 1; i <= 20; i++ {
+		rRef.commitNewBlock(rRef.cs.LatestBlock(), time.Unix(int64(i), 0))
+	}
+	for _, trieRoot := range trieRoots[:10] {
+		_, err := rRef.cs.Prune(trieRoot)
+		require.NoError(t, err)
+	}
+
+	for _, trieRoot := range trieRoots[:10] {
+		err := r.cs.SchedulePrune(trieRoot)
+		require.NoError(t, err)
+		// the state is not available immediately, but its nodes are still in the DB
+		require.False(t, r.cs.HasTrieRoot(trieRoot))
+	}
+	require.EqualValues(t, 10, r.cs.TriePruningBacklog())
+
+	// the pruning is resumed by the new store on the same DB, in small batches
+	cs := mustChainStore{state.NewStoreWithUniqueWriteMutex(r.db)}
+	batches := 0
+	for cs.TriePruningBacklog() > 0 {
+		_, err := cs.PruneBatch(3)
+		require.NoError(t, err)
+		batches++
+		if batches%10 == 0 {
+			for _, trieRoot := range trieRoots[10:] {
+				cs.checkTrie(trieRoot)
+			}
+		}
+	}
+	require.Greater(t, batches, 10)
+	require.EqualValues(t, toMap(rRef.db), toMap(r.db))
+}
+
 func makeRandomDB(t *testing.T, nBlocks int) (mustChainStore, kvstore.KVStore) {
 	db := mapdb.NewMapDB()
 	cs := mustChainStore{initializedStore(db)}
@@ -554,6 +664,27 @@ func TestRestoreSnapshotNonEmptyDB(t *testing.T) {
 	require.EqualValues(t, addLargestPrunedBlockIndex(dbCopy, 10), dbCopy2)
 }
 
+func TestRestoreSnapshotFailureKeepsExistingTrie(t *testing.T) {
+	cs, db := makeRandomDB(t, 10)
+	trieRoot := cs.LatestBlock().TrieRoot()
+	snapshot := new(bytes.Buffer)
+	err := cs.TakeSnapshot(trieRoot, snapshot)
+	require.NoError(t, err)
+	truncated := snapshot.Bytes()[:snapshot.Len()/2]
+
+	// restoring a known block is a no-op, even if the snapshot is invalid
+	err = cs.RestoreSnapshot(trieRoot, bytes.NewReader(truncated))
+	require.NoError(t, err)
+
+	// the trie is in the store, but its block is not: a failed restore must
+	// not prune the trie
+	err = db.Delete(append([]byte{chaindb.PrefixBlockByTrieRoot}, trieRoot.Bytes()...))
+	require.NoError(t, err)
+	err = cs.RestoreSnapshot(trieRoot, bytes.NewReader(truncated))
+	require.Error(t, err)
+	require.NoError(t, trie.CheckTrie(trie.NewHiveKVStoreAdapter(db, []byte{chaindb.PrefixTrie}), trieRoot))
+}
+
 func TestPrunedSnapshot(t *testing.T) {
 	r := newRandomState(t)
 	for i := 1; i <= 20; i++ {
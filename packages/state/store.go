@@ -5,6 +5,7 @@ package state
 
 import (
 	"errors"
+	"fmt"
 	"io"
 	"sync"
 	"time"
@@ -12,6 +13,7 @@ import (
 	lru "github.com/hashicorp/golang-lru/v2"
 
 	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/buffered"
 	"github.com/iotaledger/wasp/packages/metrics"
 	"github.com/iotaledger/wasp/packages/trie"
@@ -56,6 +58,17 @@ func NewStoreWithMetrics(db kvstore.KVStore, writeMutex *sync.Mutex, metrics *me
 	}
 }
 
+// NewOverlayStore returns a store, which reads the states of the given store,
+// but keeps the committed blocks in memory, so that the given store is never
+// modified. The overlay must not be pruned.
+func NewOverlayStore(s Store) (Store, error) {
+	st, ok := s.(*store)
+	if !ok {
+		return nil, fmt.Errorf("cannot create an overlay of %T", s)
+	}
+	return NewStoreWithUniqueWriteMutex(newBufferedKVStore(st.db)), nil
+}
+
 func (s *store) blockByTrieRoot(root trie.Hash) (Block, error) {
 	return s.db.readBlock(root)
 }
@@ -171,38 +184,70 @@ func (s *store) Commit(d StateDraft) Block {
 	return block
 }
 
+// constPruneBatchSize is the number of trie node references released in a
+// single batch, when the trie is pruned synchronously (see Prune).
+const constPruneBatchSize = 1000
+
 func (s *store) Prune(trieRoot trie.Hash) (trie.PruneStats, error) {
+	stats := trie.PruneStats{}
+	if err := s.SchedulePrune(trieRoot); err != nil {
+		return stats, err
+	}
+	for s.TriePruningBacklog() > 0 {
+		batchStats, err := s.PruneBatch(constPruneBatchSize)
+		if err != nil {
+			return stats, err
+		}
+		stats.Add(batchStats)
+	}
+	return stats, nil
+}
+
+func (s *store) SchedulePrune(trieRoot trie.Hash) error {
 	s.writeMutex.Lock()
 	defer s.writeMutex.Unlock()
 
 	start := time.Now()
 	state, err := s.StateByTrieRoot(trieRoot)
 	if err != nil {
-		return trie.PruneStats{}, err
+		return err
 	}
-	blockIndex := state.BlockIndex()
 	buf, bufDB := s.db.buffered()
-	stats, err := trie.Prune(trieStore(bufDB), trieRoot)
-	if err != nil {
-		return trie.PruneStats{}, err
-	}
-	s.db.pruneBlock(trieRoot)
+	bufDB.pruneBlock(trieRoot)
+	bufDB.addToTriePruningQueue([]trie.Hash{trieRoot})
+	bufDB.updateLargestPrunedBlockIndex(state.BlockIndex())
 	s.db.commitToDB(buf.muts)
 	s.stateCache.Remove(trieRoot)
-	largestPrunedBlockIndex, err := s.db.largestPrunedBlockIndex()
-	if errors.Is(err, ErrNoBlocksPruned) {
-		s.db.setLargestPrunedBlockIndex(blockIndex)
-	} else if err != nil {
-		panic(err) // should not happen: no other error can be returned from `largestPrunedBlockIndex`
-	} else if blockIndex > largestPrunedBlockIndex {
-		s.db.setLargestPrunedBlockIndex(blockIndex)
+	if s.metrics != nil {
+		s.metrics.BlockPruned(time.Since(start), s.db.triePruningBacklog())
+	}
+	return nil
+}
+
+func (s *store) PruneBatch(maxNodes int) (trie.PruneStats, error) {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	start := time.Now()
+	nodes := s.db.peekTriePruningQueue(maxNodes)
+	if len(nodes) == 0 {
+		return trie.PruneStats{}, nil
 	}
+	buf, bufDB := s.db.buffered()
+	bufDB.removeFromTriePruningQueue(nodes)
+	stats, children := trie.PruneNodes(trieStore(bufDB), nodes)
+	bufDB.addToTriePruningQueue(children)
+	s.db.commitToDB(buf.muts)
 	if s.metrics != nil {
-		s.metrics.BlockPruned(time.Since(start), stats.DeletedNodes, stats.DeletedValues)
+		s.metrics.TrieNodesPruned(time.Since(start), stats.DeletedNodes, stats.DeletedValues, s.db.triePruningBacklog())
 	}
 	return stats, nil
 }
 
+func (s *store) TriePruningBacklog() uint64 {
+	return s.db.triePruningBacklog()
+}
+
 func (s *store) LargestPrunedBlockIndex() (uint32, error) {
 	return s.db.largestPrunedBlockIndex()
 }
@@ -251,17 +296,37 @@ func (s *store) LatestTrieRoot() (trie.Hash, error) {
 	return s.db.latestTrieRoot()
 }
 
+func (s *store) DiffStates(oldRoot, newRoot trie.Hash, prefix, fromKey kv.Key, limit int) ([]*trie.KeyDiff, kv.Key, error) {
+	diff, nextKey, err := s.db.diffStates(oldRoot, newRoot, []byte(prefix), []byte(fromKey), limit)
+	return diff, kv.Key(nextKey), err
+}
+
 func (s *store) TakeSnapshot(root trie.Hash, w io.Writer) error {
 	return s.db.takeSnapshot(root, w)
 }
 
 func (s *store) RestoreSnapshot(root trie.Hash, r io.Reader) error {
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
 	if s.db.hasBlock(root) {
 		return nil
 	}
+	// the trie nodes that were already in the store must survive a failed restore
+	hadTrieRoot := s.db.hasTrieRoot(root)
 
-	s.writeMutex.Lock()
-	defer s.writeMutex.Unlock()
-
-	return s.db.restoreSnapshot(root, r)
+	err := s.db.restoreSnapshot(root, r)
+	if err == nil {
+		// the snapshot may be truncated at a node boundary
+		err = trie.CheckTrie(trieStore(s.db), root)
+	}
+	if err != nil {
+		if hadTrieRoot {
+			s.db.pruneBlock(root)
+		} else {
+			s.db.discardSnapshot(root)
+		}
+		return err
+	}
+	return nil
 }
@@ -72,12 +72,31 @@ type Store interface {
 	// made to the DB.
 	ExtractBlock(StateDraft) Block
 
-	// Prune deletes the trie with the given root from the DB
+	// Prune deletes the trie with the given root from the DB. The trie is
+	// deleted in batches (see PruneBatch), so that the store is not locked for
+	// the whole operation.
 	Prune(trie.Hash) (trie.PruneStats, error)
+	// SchedulePrune deletes the block with the given trie root from the DB and
+	// schedules its trie to be deleted by the subsequent calls to PruneBatch.
+	// The state becomes unavailable immediately. The schedule is persisted in
+	// the DB, so pruning resumes after restart.
+	SchedulePrune(trie.Hash) error
+	// PruneBatch deletes the tries scheduled for pruning, releasing at most
+	// the given number of trie node references.
+	PruneBatch(maxNodes int) (trie.PruneStats, error)
+	// TriePruningBacklog returns the number of trie node references still to
+	// be released by PruneBatch.
+	TriePruningBacklog() uint64
 	// LargestPrunedBlockIndex returns the largest index of block, which was pruned.
 	// An error is returned if no blocks were pruned.
 	LargestPrunedBlockIndex() (uint32, error)
 
+	// DiffStates returns the keys with the given prefix, the values of which
+	// differ between the states with the given trie roots, sorted by key and
+	// starting at fromKey. If limit > 0, at most limit keys are returned
+	// along with the key, from which the next page starts ("" if none).
+	DiffStates(oldRoot, newRoot trie.Hash, prefix, fromKey kv.Key, limit int) ([]*trie.KeyDiff, kv.Key, error)
+
 	// TakeSnapshot takes a snapshot of the block and trie at the given trie root.
 	TakeSnapshot(trie.Hash, io.Writer) error
 
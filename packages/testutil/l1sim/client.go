@@ -0,0 +1,74 @@
+package l1sim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/l1connection"
+)
+
+const defaultClientTimeout = 1 * time.Minute
+
+// client is an l1connection.Client working directly on the ledger, e.g. for
+// the cluster tool, which hosts the simulator for its nodes.
+type client struct {
+	ledger *Ledger
+}
+
+var _ l1connection.Client = &client{}
+
+// NewClient creates an L1 client for this ledger.
+func (l *Ledger) NewClient() l1connection.Client {
+	return &client{ledger: l}
+}
+
+func (c *client) RequestFunds(addr iotago.Address, timeout ...time.Duration) error {
+	_, err := c.ledger.UtxoDB().GetFundsFromFaucet(addr)
+	return err
+}
+
+func (c *client) PostTxAndWaitUntilConfirmation(tx *iotago.Transaction, timeout ...time.Duration) (iotago.BlockID, error) {
+	t := defaultClientTimeout
+	if len(timeout) > 0 {
+		t = timeout[0]
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), t)
+	defer cancel()
+
+	done := make(chan bool, 1)
+	if err := c.ledger.PostTransaction(ctx, tx, func(_ *iotago.Transaction, confirmed bool) {
+		done <- confirmed
+	}); err != nil {
+		return iotago.BlockID{}, err
+	}
+	select {
+	case confirmed := <-done:
+		if !confirmed {
+			return iotago.BlockID{}, errors.New("transaction was rejected as conflicting")
+		}
+		// There are no blocks in the simulated ledger.
+		return iotago.BlockID{}, nil
+	case <-ctx.Done():
+		return iotago.BlockID{}, fmt.Errorf("transaction was not confirmed: %w", ctx.Err())
+	}
+}
+
+func (c *client) OutputMap(myAddress iotago.Address, timeout ...time.Duration) (iotago.OutputSet, error) {
+	outputs, _ := c.ledger.UtxoDB().GetUnspentOutputs(myAddress)
+	return outputs, nil
+}
+
+func (c *client) GetAliasOutput(aliasID iotago.AliasID, timeout ...time.Duration) (iotago.OutputID, iotago.Output, error) {
+	outputID, ao, ok := c.ledger.UtxoDB().GetAliasOutput(aliasID)
+	if !ok {
+		return iotago.OutputID{}, nil, fmt.Errorf("alias output %v not found", aliasID.ToHex())
+	}
+	return outputID, ao, nil
+}
+
+func (c *client) Health(timeout ...time.Duration) (bool, error) {
+	return true, nil
+}
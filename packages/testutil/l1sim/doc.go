@@ -0,0 +1,15 @@
+// Package l1sim provides an in-process L1 ledger simulator, that can be
+// used by the wasp nodes instead of the Hornet node (see privtangle) when
+// running a committee locally or in tests.
+//
+// The Ledger keeps the UTXO ledger in an utxodb.UtxoDB and confirms the
+// attached transactions by issuing milestones, either periodically or on
+// demand. Each node gets its own chain.NodeConnection via Ledger.NewNodeConn,
+// which delivers the alias outputs, the requests and the milestones to the
+// attached chains in the same order as the real node connection does.
+// Reattachments and conflicting transactions can be simulated as well.
+//
+// The nodes running in other processes (e.g. in a cluster test) connect to the
+// ledger served by Server, see the l1simclient package and the "l1sim.url"
+// node parameter.
+package l1sim
@@ -0,0 +1,404 @@
+package l1sim
+
+import (
+	"context"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/testutil/utxodb"
+	"github.com/iotaledger/wasp/packages/util"
+)
+
+type LedgerOptions struct {
+	// MilestoneInterval is the period of the milestones issued by Run.
+	// If it is 0, the milestones are only issued by calling IssueMilestone.
+	MilestoneInterval time.Duration
+	// ConfirmationDelay is the number of milestones a transaction has to wait
+	// after being attached before it can be confirmed; 0 means the next one.
+	ConfirmationDelay uint32
+	// OrphanRate is the probability of an attached transaction not being
+	// referenced by the milestone, so that it has to be reattached.
+	OrphanRate float64
+	// Seed of the randomness used for orphaning, random if 0.
+	Seed int64
+}
+
+func DefaultLedgerOptions() *LedgerOptions {
+	return &LedgerOptions{
+		MilestoneInterval: 1 * time.Second,
+	}
+}
+
+type pendingTx struct {
+	ctx        context.Context
+	tx         *iotago.Transaction
+	txID       iotago.TransactionID
+	callback   chain.TxPostHandler
+	attachedAt uint32 // milestone index
+}
+
+// attachment is a chain attached to the ledger by one of the node connections.
+type attachment struct {
+	chainID         isc.ChainID
+	recvRequest     chain.RequestOutputHandler
+	recvAliasOutput chain.AliasOutputHandler
+	recvMilestone   chain.MilestoneHandler
+}
+
+// Ledger simulates the L1 network: the attached transactions are added to
+// the UtxoDB when a milestone is issued and the attached chains are notified
+// about the milestones and the outputs created for them.
+//
+// The milestone timestamps follow the wall clock: the logical time of the
+// UtxoDB is advanced to the current time (if it is behind) on each milestone.
+type Ledger struct {
+	log    *logger.Logger
+	utxoDB *utxodb.UtxoDB
+	opts   *LedgerOptions
+	rnd    *rand.Rand
+
+	// notifyMutex is held while notifying the chains, so that the
+	// notifications of consecutive milestones are not interleaved.
+	notifyMutex sync.Mutex
+
+	mutex              sync.Mutex
+	milestoneIndex     uint32
+	milestoneTimestamp time.Time
+	pending            []*pendingTx
+	orphanNext         int
+	reattachments      int
+	attachments        []*attachment
+}
+
+func NewLedger(log *logger.Logger, utxoDB *utxodb.UtxoDB, opts *LedgerOptions) *Ledger {
+	if opts == nil {
+		opts = DefaultLedgerOptions()
+	}
+	var rnd *rand.Rand
+	if opts.Seed != 0 {
+		rnd = util.NewPseudoRand(opts.Seed)
+	} else {
+		rnd = util.NewPseudoRand()
+	}
+	return &Ledger{
+		log:                log,
+		utxoDB:             utxoDB,
+		opts:               opts,
+		rnd:                rnd,
+		milestoneTimestamp: utxoDB.GlobalTime(),
+	}
+}
+
+// UtxoDB returns the underlying ledger, e.g. to request funds from the faucet.
+func (l *Ledger) UtxoDB() *utxodb.UtxoDB {
+	return l.utxoDB
+}
+
+// Run issues the milestones periodically until the context is canceled.
+func (l *Ledger) Run(ctx context.Context) {
+	if l.opts.MilestoneInterval == 0 {
+		<-ctx.Done()
+		return
+	}
+	ticker := time.NewTicker(l.opts.MilestoneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.IssueMilestone()
+		}
+	}
+}
+
+// NewNodeConn creates a node connection to this ledger for a single wasp node.
+func (l *Ledger) NewNodeConn(log *logger.Logger) chain.NodeConnection {
+	return newNodeConn(l, log)
+}
+
+// PostTransaction attaches the transaction to the ledger. The callback (if
+// not nil) is called once the transaction is confirmed or rejected as
+// conflicting, unless the context is canceled first. Orphaned transactions
+// are reattached until the context is canceled.
+func (l *Ledger) PostTransaction(ctx context.Context, tx *iotago.Transaction, callback chain.TxPostHandler) error {
+	txID, err := tx.ID()
+	if err != nil {
+		return err
+	}
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.pending = append(l.pending, &pendingTx{
+		ctx:        ctx,
+		tx:         tx,
+		txID:       txID,
+		callback:   callback,
+		attachedAt: l.milestoneIndex,
+	})
+	return nil
+}
+
+// OrphanNext makes the next n transactions, which would otherwise be
+// confirmed, orphaned, so that they have to be reattached.
+func (l *Ledger) OrphanNext(n int) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.orphanNext += n
+}
+
+// Reattachments returns the number of reattachments of orphaned transactions.
+func (l *Ledger) Reattachments() int {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.reattachments
+}
+
+// MilestoneIndex returns the index of the latest milestone.
+func (l *Ledger) MilestoneIndex() uint32 {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	return l.milestoneIndex
+}
+
+// IssueMilestone confirms the pending transactions (those not waiting for
+// their inputs or the confirmation delay, and not orphaned) and notifies the
+// attached chains and the transaction publishers. It returns the index of the
+// new milestone. It must not be called from the notification callbacks.
+func (l *Ledger) IssueMilestone() uint32 {
+	l.notifyMutex.Lock()
+	defer l.notifyMutex.Unlock()
+
+	index, notify := l.issueMilestone()
+	for _, f := range notify {
+		f()
+	}
+	return index
+}
+
+func (l *Ledger) issueMilestone() (uint32, []func()) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.milestoneIndex++
+	if d := time.Since(l.utxoDB.GlobalTime()); d > 0 {
+		l.utxoDB.AdvanceClockBy(d)
+	}
+
+	var confirmed []*iotago.Transaction
+	var txResults []func()
+	result := func(p *pendingTx, ok bool) {
+		if p.callback != nil {
+			txResults = append(txResults, func() { p.callback(p.tx, ok) })
+		}
+	}
+	stillPending := make([]*pendingTx, 0, len(l.pending))
+	for _, p := range l.pending {
+		if p.ctx.Err() != nil {
+			continue
+		}
+		if l.milestoneIndex <= p.attachedAt+l.opts.ConfirmationDelay {
+			stillPending = append(stillPending, p)
+			continue
+		}
+		if _, ok := l.utxoDB.GetTransaction(p.txID); ok {
+			// Already confirmed, e.g. published by several nodes.
+			result(p, true)
+			continue
+		}
+		if !l.inputsKnown(p.tx) {
+			// The transaction producing the inputs is not confirmed yet.
+			stillPending = append(stillPending, p)
+			continue
+		}
+		if l.orphan() {
+			l.log.Debugf("Milestone %v: TX %v orphaned, reattaching.", l.milestoneIndex, p.txID.ToHex())
+			l.reattachments++
+			p.attachedAt = l.milestoneIndex
+			stillPending = append(stillPending, p)
+			continue
+		}
+		if err := l.utxoDB.AddToLedger(p.tx); err != nil {
+			l.log.Debugf("Milestone %v: TX %v conflicting: %v", l.milestoneIndex, p.txID.ToHex(), err)
+			result(p, false)
+			continue
+		}
+		confirmed = append(confirmed, p.tx)
+		result(p, true)
+	}
+	l.pending = stillPending
+	l.milestoneTimestamp = l.utxoDB.GlobalTime()
+	l.log.Debugf("Milestone %v issued at %v, %v TXes confirmed.", l.milestoneIndex, l.milestoneTimestamp, len(confirmed))
+
+	notify := l.chainNotifications(l.milestoneTimestamp, confirmed)
+	return l.milestoneIndex, append(notify, txResults...)
+}
+
+func (l *Ledger) inputsKnown(tx *iotago.Transaction) bool {
+	for _, input := range tx.Essence.Inputs {
+		utxoInput, ok := input.(*iotago.UTXOInput)
+		if !ok || l.utxoDB.GetOutput(utxoInput.ID()) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *Ledger) orphan() bool {
+	if l.orphanNext > 0 {
+		l.orphanNext--
+		return true
+	}
+	return l.opts.OrphanRate > 0 && l.rnd.Float64() < l.opts.OrphanRate
+}
+
+// chainNotifications prepares the notifications of the attached chains about
+// a milestone: the timestamp first, then the alias outputs of the chain in the
+// order of the transactions (marked as consumed, if consumed in the same
+// milestone), and then the new requests not consumed in the same milestone.
+func (l *Ledger) chainNotifications(timestamp time.Time, confirmed []*iotago.Transaction) []func() {
+	consumedBy := make(map[iotago.OutputID]iotago.TransactionID)
+	for _, tx := range confirmed {
+		txID, err := tx.ID()
+		if err != nil {
+			panic(err)
+		}
+		for _, input := range tx.Essence.Inputs {
+			consumedBy[input.(*iotago.UTXOInput).ID()] = txID
+		}
+	}
+
+	type chainOutputs struct {
+		aliasOutputs []*isc.OutputInfo
+		requests     []*isc.OutputInfo
+	}
+	outputs := make(map[isc.ChainID]*chainOutputs)
+	for _, a := range l.attachments {
+		outputs[a.chainID] = &chainOutputs{}
+	}
+	for _, tx := range confirmed {
+		txID, err := tx.ID()
+		if err != nil {
+			panic(err)
+		}
+		for i, output := range tx.Essence.Outputs {
+			outputID := iotago.OutputIDFromTransactionIDAndIndex(txID, uint16(i))
+			if chainID, ok := aliasOutputChainID(output, outputID); ok {
+				if co, ok := outputs[chainID]; ok {
+					co.aliasOutputs = append(co.aliasOutputs, isc.NewOutputInfo(outputID, output, consumedBy[outputID]))
+				}
+				continue
+			}
+			chainID, ok := requestChainID(output)
+			if !ok {
+				continue
+			}
+			if co, ok := outputs[chainID]; ok {
+				if _, consumed := consumedBy[outputID]; !consumed {
+					co.requests = append(co.requests, isc.NewOutputInfo(outputID, output, iotago.TransactionID{}))
+				}
+			}
+		}
+	}
+
+	notify := make([]func(), 0, len(l.attachments))
+	for _, a := range l.attachments {
+		a := a
+		co := outputs[a.chainID]
+		notify = append(notify, func() {
+			a.recvMilestone(timestamp)
+			for _, ao := range co.aliasOutputs {
+				a.recvAliasOutput(ao)
+			}
+			for _, req := range co.requests {
+				a.recvRequest(req)
+			}
+		})
+	}
+	return notify
+}
+
+// attach registers the chain and notifies it about the current milestone, its
+// unspent alias output and the unspent requests.
+func (l *Ledger) attach(a *attachment) {
+	l.notifyMutex.Lock()
+	defer l.notifyMutex.Unlock()
+
+	l.mutex.Lock()
+	l.attachments = append(l.attachments, a)
+	timestamp := l.milestoneTimestamp
+	outputs, outputIDs := l.utxoDB.GetUnspentOutputs(a.chainID.AsAddress())
+	l.mutex.Unlock()
+
+	sort.Slice(outputIDs, func(i, j int) bool {
+		return outputIDs[i].ToHex() < outputIDs[j].ToHex()
+	})
+	var aliasOutput *isc.OutputInfo
+	var requests []*isc.OutputInfo
+	for _, outputID := range outputIDs {
+		output := outputs[outputID]
+		if chainID, ok := aliasOutputChainID(output, outputID); ok {
+			if chainID.Equals(a.chainID) {
+				aliasOutput = isc.NewOutputInfo(outputID, output, iotago.TransactionID{})
+			}
+			continue
+		}
+		if chainID, ok := requestChainID(output); ok && chainID.Equals(a.chainID) {
+			requests = append(requests, isc.NewOutputInfo(outputID, output, iotago.TransactionID{}))
+		}
+	}
+
+	a.recvMilestone(timestamp)
+	if aliasOutput == nil {
+		l.log.Warnf("Chain %v attached, but its alias output is not in the ledger.", a.chainID)
+	} else {
+		a.recvAliasOutput(aliasOutput)
+	}
+	for _, req := range requests {
+		a.recvRequest(req)
+	}
+}
+
+func (l *Ledger) detach(a *attachment) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	for i := range l.attachments {
+		if l.attachments[i] == a {
+			l.attachments = append(l.attachments[:i], l.attachments[i+1:]...)
+			return
+		}
+	}
+}
+
+// aliasOutputChainID returns the chain ID, if the output is an alias output.
+func aliasOutputChainID(output iotago.Output, outputID iotago.OutputID) (isc.ChainID, bool) {
+	ao, ok := output.(*iotago.AliasOutput)
+	if !ok {
+		return isc.ChainID{}, false
+	}
+	aliasID := ao.AliasID
+	if aliasID.Empty() {
+		aliasID = iotago.AliasIDFromOutputID(outputID)
+	}
+	return isc.ChainIDFromAliasID(aliasID), true
+}
+
+// requestChainID returns the chain ID, if the output is owned by a chain and
+// should be processed as a request by it (same as in the nodeconn package,
+// outputs with the storage deposit return condition are ignored).
+func requestChainID(output iotago.Output) (isc.ChainID, bool) {
+	unlockConditions := output.UnlockConditionSet()
+	if unlockConditions.HasStorageDepositReturnCondition() {
+		return isc.ChainID{}, false
+	}
+	addressUnlock := unlockConditions.Address()
+	if addressUnlock == nil || addressUnlock.Address.Type() != iotago.AddressAlias {
+		return isc.ChainID{}, false
+	}
+	return isc.ChainIDFromAliasID(addressUnlock.Address.(*iotago.AliasAddress).AliasID()), true
+}
@@ -0,0 +1,266 @@
+package l1sim_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/testutil/l1sim"
+	"github.com/iotaledger/wasp/packages/testutil/testchain"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/testutil/utxodb"
+	"github.com/iotaledger/wasp/packages/transaction"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/gas"
+)
+
+type testChain struct {
+	mutex        sync.Mutex
+	milestones   []time.Time
+	aliasOutputs []*isc.OutputInfo
+	requests     []*isc.OutputInfo
+	connected    bool
+}
+
+func (tc *testChain) attach(ctx context.Context, nc chain.NodeConnection, chainID isc.ChainID) {
+	nc.AttachChain(ctx, chainID,
+		func(oi *isc.OutputInfo) { tc.mutex.Lock(); tc.requests = append(tc.requests, oi); tc.mutex.Unlock() },
+		func(oi *isc.OutputInfo) {
+			tc.mutex.Lock()
+			tc.aliasOutputs = append(tc.aliasOutputs, oi)
+			tc.mutex.Unlock()
+		},
+		func(ts time.Time) { tc.mutex.Lock(); tc.milestones = append(tc.milestones, ts); tc.mutex.Unlock() },
+		func() { tc.mutex.Lock(); tc.connected = true; tc.mutex.Unlock() },
+		func() { tc.mutex.Lock(); tc.connected = false; tc.mutex.Unlock() },
+	)
+}
+
+type testEnv struct {
+	t        *testing.T
+	ctx      context.Context
+	ledger   *l1sim.Ledger
+	governor *cryptolib.KeyPair
+	chainID  isc.ChainID
+	originAO *isc.AliasOutputWithID
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	utxoDB := utxodb.New(utxodb.DefaultInitParams())
+	governor := cryptolib.NewKeyPair()
+	_, err := utxoDB.GetFundsFromFaucet(governor.Address())
+	require.NoError(t, err)
+	_, originAO, chainID := testchain.NewTestChainLedger(t, utxoDB, governor).MakeTxChainOrigin(governor.Address())
+	ledger := l1sim.NewLedger(testlogger.NewLogger(t), utxoDB, &l1sim.LedgerOptions{})
+	return &testEnv{t: t, ctx: ctx, ledger: ledger, governor: governor, chainID: chainID, originAO: originAO}
+}
+
+func (te *testEnv) requestTx() *iotago.Transaction {
+	outs, outIDs := te.ledger.UtxoDB().GetUnspentOutputs(te.governor.Address())
+	tx, err := transaction.NewRequestTransaction(transaction.NewRequestTransactionParams{
+		SenderKeyPair:    te.governor,
+		SenderAddress:    te.governor.Address(),
+		UnspentOutputs:   outs,
+		UnspentOutputIDs: outIDs,
+		Request: &isc.RequestParameters{
+			TargetAddress: te.chainID.AsAddress(),
+			Assets:        isc.NewAssetsBaseTokens(1 * isc.Million),
+			Metadata: &isc.SendMetadata{
+				TargetContract: accounts.Contract.Hname(),
+				EntryPoint:     accounts.FuncDeposit.Hname(),
+				GasBudget:      gas.LimitsDefault.MinGasPerRequest,
+			},
+		},
+	})
+	require.NoError(te.t, err)
+	return tx
+}
+
+// rotateTx produces the next alias output of the chain, consuming the given one.
+func (te *testEnv) rotateTx(ao *isc.AliasOutputWithID, stateController ...iotago.Address) (*iotago.Transaction, *isc.AliasOutputWithID) {
+	var nextStateController iotago.Address = te.governor.Address()
+	if len(stateController) > 0 {
+		nextStateController = stateController[0]
+	}
+	tx, err := transaction.NewRotateChainStateControllerTx(te.chainID.AsAliasID(), nextStateController, ao.OutputID(), ao.GetAliasOutput(), te.governor)
+	require.NoError(te.t, err)
+	_, nextAO, err := transaction.GetAnchorFromTransaction(tx)
+	require.NoError(te.t, err)
+	txID, err := tx.ID()
+	require.NoError(te.t, err)
+	return tx, isc.NewAliasOutputWithID(nextAO, iotago.OutputIDFromTransactionIDAndIndex(txID, 0))
+}
+
+type txResults struct {
+	mutex   sync.Mutex
+	results map[iotago.TransactionID]bool
+}
+
+func (r *txResults) callback(tx *iotago.Transaction, confirmed bool) {
+	txID, _ := tx.ID()
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.results[txID] = confirmed
+}
+
+func (r *txResults) get(t *testing.T, tx *iotago.Transaction) (bool, bool) {
+	txID, err := tx.ID()
+	require.NoError(t, err)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	confirmed, ok := r.results[txID]
+	return confirmed, ok
+}
+
+func TestAttachAndPublish(t *testing.T) {
+	te := newTestEnv(t)
+	nc := te.ledger.NewNodeConn(testlogger.NewLogger(t))
+	results := &txResults{results: map[iotago.TransactionID]bool{}}
+
+	// not attached yet
+	reqTx := te.requestTx()
+	require.Error(t, nc.PublishTX(te.ctx, te.chainID, reqTx, results.callback))
+
+	tc := &testChain{}
+	attachCtx, detach := context.WithCancel(te.ctx)
+	tc.attach(attachCtx, nc, te.chainID)
+	require.True(t, tc.connected)
+	require.Len(t, tc.milestones, 1)
+	require.Len(t, tc.aliasOutputs, 1)
+	require.Equal(t, te.originAO.OutputID(), tc.aliasOutputs[0].OutputID)
+	require.False(t, tc.aliasOutputs[0].Consumed())
+	require.Empty(t, tc.requests)
+
+	// a request and two chained state transitions in a single milestone
+	tx1, ao1 := te.rotateTx(te.originAO)
+	tx2, ao2 := te.rotateTx(ao1)
+	require.NoError(t, nc.PublishTX(te.ctx, te.chainID, reqTx, results.callback))
+	require.NoError(t, nc.PublishTX(te.ctx, te.chainID, tx1, results.callback))
+	require.NoError(t, nc.PublishTX(te.ctx, te.chainID, tx2, results.callback))
+	require.EqualValues(t, 1, te.ledger.IssueMilestone())
+
+	for _, tx := range []*iotago.Transaction{reqTx, tx1, tx2} {
+		confirmed, ok := results.get(t, tx)
+		require.True(t, ok)
+		require.True(t, confirmed)
+	}
+	require.Len(t, tc.milestones, 2)
+	require.False(t, tc.milestones[1].Before(tc.milestones[0]))
+	require.Len(t, tc.aliasOutputs, 3)
+	tx2ID, err := tx2.ID()
+	require.NoError(t, err)
+	require.Equal(t, ao1.OutputID(), tc.aliasOutputs[1].OutputID)
+	require.Equal(t, tx2ID, tc.aliasOutputs[1].TransactionIDSpent)
+	require.Equal(t, ao2.OutputID(), tc.aliasOutputs[2].OutputID)
+	require.False(t, tc.aliasOutputs[2].Consumed())
+	require.Len(t, tc.requests, 1)
+	require.Equal(t, reqTx.Essence.Outputs[0], tc.requests[0].Output)
+
+	// a conflicting transaction, consuming an already consumed alias output
+	conflictingTx, _ := te.rotateTx(ao1, cryptolib.NewKeyPair().Address())
+	require.NoError(t, nc.PublishTX(te.ctx, te.chainID, conflictingTx, results.callback))
+	te.ledger.IssueMilestone()
+	confirmed, ok := results.get(t, conflictingTx)
+	require.True(t, ok)
+	require.False(t, confirmed)
+	require.Len(t, tc.aliasOutputs, 3)
+
+	// another node attaching the chain gets the latest state
+	tc2 := &testChain{}
+	tc2.attach(te.ctx, te.ledger.NewNodeConn(testlogger.NewLogger(t)), te.chainID)
+	require.Len(t, tc2.aliasOutputs, 1)
+	require.Equal(t, ao2.OutputID(), tc2.aliasOutputs[0].OutputID)
+	require.Len(t, tc2.requests, 1)
+
+	detach()
+	require.Eventually(t, func() bool {
+		tc.mutex.Lock()
+		defer tc.mutex.Unlock()
+		return !tc.connected
+	}, 5*time.Second, 10*time.Millisecond)
+	te.ledger.IssueMilestone()
+	require.Len(t, tc.milestones, 3) // not notified after detaching
+	require.Len(t, tc2.milestones, 2)
+}
+
+func TestReattachment(t *testing.T) {
+	te := newTestEnv(t)
+	nc := te.ledger.NewNodeConn(testlogger.NewLogger(t))
+	tc := &testChain{}
+	tc.attach(te.ctx, nc, te.chainID)
+	results := &txResults{results: map[iotago.TransactionID]bool{}}
+
+	tx1, ao1 := te.rotateTx(te.originAO)
+	tx2, _ := te.rotateTx(ao1)
+	te.ledger.OrphanNext(1)
+	require.NoError(t, nc.PublishTX(te.ctx, te.chainID, tx1, results.callback))
+	require.NoError(t, nc.PublishTX(te.ctx, te.chainID, tx2, results.callback))
+
+	// tx1 is orphaned, tx2 waits for its input
+	te.ledger.IssueMilestone()
+	require.Equal(t, 1, te.ledger.Reattachments())
+	_, ok := results.get(t, tx1)
+	require.False(t, ok)
+	_, ok = results.get(t, tx2)
+	require.False(t, ok)
+	require.Len(t, tc.aliasOutputs, 1)
+
+	te.ledger.IssueMilestone()
+	for _, tx := range []*iotago.Transaction{tx1, tx2} {
+		confirmed, ok := results.get(t, tx)
+		require.True(t, ok)
+		require.True(t, confirmed)
+	}
+	require.Len(t, tc.aliasOutputs, 3)
+
+	// a canceled publication is not reported
+	ctx, cancel := context.WithCancel(te.ctx)
+	reqTx := te.requestTx()
+	te.ledger.OrphanNext(1)
+	require.NoError(t, nc.PublishTX(ctx, te.chainID, reqTx, results.callback))
+	te.ledger.IssueMilestone()
+	cancel()
+	te.ledger.IssueMilestone()
+	_, ok = results.get(t, reqTx)
+	require.False(t, ok)
+	require.Empty(t, tc.requests)
+}
+
+func TestConfirmationDelay(t *testing.T) {
+	utxoDB := utxodb.New(utxodb.DefaultInitParams())
+	ledger := l1sim.NewLedger(testlogger.NewLogger(t), utxoDB, &l1sim.LedgerOptions{ConfirmationDelay: 2})
+	kp := cryptolib.NewKeyPair()
+	_, err := utxoDB.GetFundsFromFaucet(kp.Address())
+	require.NoError(t, err)
+
+	results := &txResults{results: map[iotago.TransactionID]bool{}}
+	outs, outIDs := utxoDB.GetUnspentOutputs(kp.Address())
+	transferTx, err := transaction.NewTransferTransaction(transaction.NewTransferTransactionParams{
+		FungibleTokens:   isc.NewAssetsBaseTokens(1 * isc.Million),
+		SenderAddress:    kp.Address(),
+		SenderKeyPair:    kp,
+		TargetAddress:    kp.Address(),
+		UnspentOutputs:   outs,
+		UnspentOutputIDs: outIDs,
+	})
+	require.NoError(t, err)
+	require.NoError(t, ledger.PostTransaction(context.Background(), transferTx, results.callback))
+	for i := 0; i < 2; i++ {
+		ledger.IssueMilestone()
+		_, ok := results.get(t, transferTx)
+		require.False(t, ok)
+	}
+	ledger.IssueMilestone()
+	confirmed, ok := results.get(t, transferTx)
+	require.True(t, ok)
+	require.True(t, confirmed)
+}
@@ -0,0 +1,108 @@
+package l1sim
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/iotaledger/hive.go/logger"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/chain"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/parameters"
+)
+
+// nodeConn is the connection of a single wasp node to the simulated ledger.
+type nodeConn struct {
+	log    *logger.Logger
+	ledger *Ledger
+
+	chainsLock sync.RWMutex
+	chains     map[isc.ChainID]int // number of attachments of the chain
+}
+
+var _ chain.NodeConnection = &nodeConn{}
+
+func newNodeConn(ledger *Ledger, log *logger.Logger) *nodeConn {
+	return &nodeConn{
+		log:    log,
+		ledger: ledger,
+		chains: make(map[isc.ChainID]int),
+	}
+}
+
+func (nc *nodeConn) PublishTX(
+	ctx context.Context,
+	chainID isc.ChainID,
+	tx *iotago.Transaction,
+	callback chain.TxPostHandler,
+) error {
+	nc.chainsLock.RLock()
+	_, attached := nc.chains[chainID]
+	nc.chainsLock.RUnlock()
+	if !attached {
+		return fmt.Errorf("chain %v is not connected", chainID.String())
+	}
+	return nc.ledger.PostTransaction(ctx, tx, callback)
+}
+
+func (nc *nodeConn) AttachChain(
+	ctx context.Context,
+	chainID isc.ChainID,
+	recvRequestCB chain.RequestOutputHandler,
+	recvAliasOutput chain.AliasOutputHandler,
+	recvMilestone chain.MilestoneHandler,
+	onChainConnect func(),
+	onChainDisconnect func(),
+) {
+	a := &attachment{
+		chainID:         chainID,
+		recvRequest:     recvRequestCB,
+		recvAliasOutput: recvAliasOutput,
+		recvMilestone:   recvMilestone,
+	}
+	nc.chainsLock.Lock()
+	nc.chains[chainID]++
+	nc.chainsLock.Unlock()
+
+	nc.ledger.attach(a)
+	nc.log.Infof("Chain %v attached to the simulated L1.", chainID)
+	if onChainConnect != nil {
+		onChainConnect()
+	}
+
+	go func() {
+		<-ctx.Done()
+		nc.ledger.detach(a)
+		nc.chainsLock.Lock()
+		if nc.chains[chainID]--; nc.chains[chainID] == 0 {
+			delete(nc.chains, chainID)
+		}
+		nc.chainsLock.Unlock()
+		nc.log.Infof("Chain %v detached from the simulated L1.", chainID)
+		if onChainDisconnect != nil {
+			onChainDisconnect()
+		}
+	}()
+}
+
+func (nc *nodeConn) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return nil
+}
+
+func (nc *nodeConn) WaitUntilInitiallySynced(context.Context) error {
+	return nil
+}
+
+func (nc *nodeConn) GetBech32HRP() iotago.NetworkPrefix {
+	return nc.GetL1ProtocolParams().Bech32HRP
+}
+
+func (nc *nodeConn) GetL1Params() *parameters.L1Params {
+	return parameters.L1()
+}
+
+func (nc *nodeConn) GetL1ProtocolParams() *iotago.ProtocolParameters {
+	return parameters.L1().Protocol
+}
@@ -0,0 +1,148 @@
+package l1sim
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/hive.go/serializer/v2"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/l1simclient"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/util/pipe"
+)
+
+// Server serves the ledger over HTTP to the wasp nodes running in other
+// processes, which connect to it with l1simclient.New.
+type Server struct {
+	ledger *Ledger
+	log    *logger.Logger
+	mux    *http.ServeMux
+}
+
+var _ http.Handler = &Server{}
+
+func NewServer(ledger *Ledger, log *logger.Logger) *Server {
+	s := &Server{
+		ledger: ledger,
+		log:    log,
+		mux:    http.NewServeMux(),
+	}
+	s.mux.HandleFunc(l1simclient.PathInfo, s.handleInfo)
+	s.mux.HandleFunc(l1simclient.PathTx, s.handleTx)
+	s.mux.HandleFunc(l1simclient.PathAttach, s.handleAttach)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, parameters.L1())
+}
+
+func (s *Server) handleTx(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	txBytes, err := io.ReadAll(io.LimitReader(r.Body, int64(parameters.L1().MaxPayloadSize)))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tx := &iotago.Transaction{}
+	if _, err = tx.Deserialize(txBytes, serializer.DeSeriModePerformValidation, parameters.L1().Protocol); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// The request context is canceled if the node gives up on the
+	// transaction, which stops its reattachments as well.
+	done := make(chan bool, 1)
+	if err = s.ledger.PostTransaction(r.Context(), tx, func(_ *iotago.Transaction, confirmed bool) {
+		done <- confirmed
+	}); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	select {
+	case confirmed := <-done:
+		writeJSON(w, &l1simclient.TxResult{Confirmed: confirmed})
+	case <-r.Context().Done():
+	}
+}
+
+// handleAttach streams the notifications of the chain until the node
+// disconnects. The notifications are queued, so that a slow node does not
+// block the milestones.
+func (s *Server) handleAttach(w http.ResponseWriter, r *http.Request) {
+	chainIDBytes, err := iotago.DecodeHex(r.URL.Query().Get(l1simclient.ParamChainID))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	chainID, err := isc.ChainIDFromBytes(chainIDBytes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	events := pipe.NewInfinitePipe[*l1simclient.Event]()
+	defer events.Discard()
+	outputEvent := func(eventType l1simclient.EventType) func(*isc.OutputInfo) {
+		return func(oi *isc.OutputInfo) {
+			event, err := l1simclient.NewOutputEvent(eventType, oi)
+			if err != nil {
+				s.log.Errorf("Cannot encode output %v for chain %v: %v", oi.OutputID.ToHex(), chainID, err)
+				return
+			}
+			events.TryAdd(event, s.log.Warnf)
+		}
+	}
+	a := &attachment{
+		chainID:         chainID,
+		recvRequest:     outputEvent(l1simclient.EventRequest),
+		recvAliasOutput: outputEvent(l1simclient.EventAliasOutput),
+		recvMilestone: func(timestamp time.Time) {
+			events.TryAdd(l1simclient.NewMilestoneEvent(timestamp), s.log.Warnf)
+		},
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	s.ledger.attach(a)
+	defer s.ledger.detach(a)
+	s.log.Infof("Chain %v attached to the simulated L1 from %v.", chainID, r.RemoteAddr)
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			s.log.Infof("Chain %v detached from the simulated L1 from %v.", chainID, r.RemoteAddr)
+			return
+		case event := <-events.Out():
+			if err := encoder.Encode(event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
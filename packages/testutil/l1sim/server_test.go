@@ -0,0 +1,90 @@
+package l1sim_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/l1simclient"
+	"github.com/iotaledger/wasp/packages/testutil/l1sim"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+)
+
+func TestServer(t *testing.T) {
+	te := newTestEnv(t)
+	srv := httptest.NewServer(l1sim.NewServer(te.ledger, testlogger.NewLogger(t)))
+	t.Cleanup(srv.Close)
+
+	nc, err := l1simclient.New(te.ctx, srv.URL, testlogger.NewLogger(t))
+	require.NoError(t, err)
+
+	tc := &testChain{}
+	attachCtx, detach := context.WithCancel(te.ctx)
+	tc.attach(attachCtx, nc, te.chainID)
+	require.Eventually(t, func() bool {
+		tc.mutex.Lock()
+		defer tc.mutex.Unlock()
+		return len(tc.milestones) == 1 && len(tc.aliasOutputs) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, te.originAO.OutputID(), tc.aliasOutputs[0].OutputID)
+	require.Equal(t, te.originAO.GetAliasOutput(), tc.aliasOutputs[0].Output)
+
+	results := &txResults{results: map[iotago.TransactionID]bool{}}
+	reqTx := te.requestTx()
+	tx1, ao1 := te.rotateTx(te.originAO)
+	require.NoError(t, nc.PublishTX(te.ctx, te.chainID, reqTx, results.callback))
+	require.NoError(t, nc.PublishTX(te.ctx, te.chainID, tx1, results.callback))
+	// the transactions are posted asynchronously
+	require.Eventually(t, func() bool {
+		te.ledger.IssueMilestone()
+		_, ok1 := results.get(t, reqTx)
+		_, ok2 := results.get(t, tx1)
+		return ok1 && ok2
+	}, 5*time.Second, 10*time.Millisecond)
+	for _, tx := range []*iotago.Transaction{reqTx, tx1} {
+		confirmed, _ := results.get(t, tx)
+		require.True(t, confirmed)
+	}
+	require.Eventually(t, func() bool {
+		tc.mutex.Lock()
+		defer tc.mutex.Unlock()
+		return len(tc.aliasOutputs) == 2 && len(tc.requests) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, ao1.OutputID(), tc.aliasOutputs[1].OutputID)
+	require.False(t, tc.aliasOutputs[1].Consumed())
+	require.Equal(t, reqTx.Essence.Outputs[0], tc.requests[0].Output)
+
+	detach()
+	require.Eventually(t, func() bool {
+		tc.mutex.Lock()
+		defer tc.mutex.Unlock()
+		return !tc.connected
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestClient(t *testing.T) {
+	te := newTestEnv(t)
+	client := te.ledger.NewClient()
+	go func() {
+		for te.ctx.Err() == nil {
+			te.ledger.IssueMilestone()
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	outputID, ao, err := client.GetAliasOutput(te.chainID.AsAliasID())
+	require.NoError(t, err)
+	require.Equal(t, te.originAO.OutputID(), outputID)
+	require.Equal(t, te.originAO.GetAliasOutput(), ao)
+
+	tx1, ao1 := te.rotateTx(te.originAO)
+	_, err = client.PostTxAndWaitUntilConfirmation(tx1)
+	require.NoError(t, err)
+	outputID, _, err = client.GetAliasOutput(te.chainID.AsAliasID())
+	require.NoError(t, err)
+	require.Equal(t, ao1.OutputID(), outputID)
+}
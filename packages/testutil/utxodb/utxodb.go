@@ -305,6 +305,56 @@ func (u *UtxoDB) AddToLedger(tx *iotago.Transaction) error {
 	return nil
 }
 
+// AddForeignOutputs adds outputs created outside of this ledger (e.g. the
+// outputs of a chain forked from another network) to the ledger as unspent,
+// keeping their original output IDs. The outputs are not validated and the
+// supply is increased by their amounts. Each group of outputs sharing a
+// transaction ID is stored as a placeholder transaction with that ID, so the
+// transaction ID must not be known to the ledger yet.
+func (u *UtxoDB) AddForeignOutputs(outputs iotago.OutputSet) error {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+
+	byTx := make(map[iotago.TransactionID][]iotago.OutputID)
+	for outputID := range outputs {
+		txID := outputID.TransactionID()
+		if _, ok := u.transactions[txID]; ok {
+			return fmt.Errorf("AddForeignOutputs: transaction %s already exists", txID.ToHex())
+		}
+		byTx[txID] = append(byTx[txID], outputID)
+	}
+	for txID, outputIDs := range byTx {
+		maxIndex := uint16(0)
+		for _, outputID := range outputIDs {
+			if outputID.Index() > maxIndex {
+				maxIndex = outputID.Index()
+			}
+		}
+		// the outputs not being added are just placeholders, they are never unspent
+		txOutputs := make(iotago.Outputs, maxIndex+1)
+		for i := range txOutputs {
+			txOutputs[i] = &iotago.BasicOutput{
+				Conditions: iotago.UnlockConditions{
+					&iotago.AddressUnlockCondition{Address: genesisAddress},
+				},
+			}
+		}
+		for _, outputID := range outputIDs {
+			txOutputs[outputID.Index()] = outputs[outputID]
+			u.utxo[outputID] = struct{}{}
+			u.supply += outputs[outputID].Deposit()
+		}
+		u.transactions[txID] = &iotago.Transaction{
+			Essence: &iotago.TransactionEssence{
+				NetworkID: parameters.L1().Protocol.NetworkID(),
+				Outputs:   txOutputs,
+			},
+		}
+	}
+	u.checkLedgerBalance()
+	return nil
+}
+
 // GetTransaction retrieves value transaction by its hash (ID).
 func (u *UtxoDB) GetTransaction(txID iotago.TransactionID) (*iotago.Transaction, bool) {
 	u.mutex.RLock()
@@ -388,6 +438,23 @@ func (u *UtxoDB) GetAliasOutputs(addr iotago.Address) map[iotago.OutputID]*iotag
 	return ret
 }
 
+// GetAliasOutput finds the unspent alias output with the given alias ID.
+func (u *UtxoDB) GetAliasOutput(aliasID iotago.AliasID) (iotago.OutputID, *iotago.AliasOutput, bool) {
+	u.mutex.RLock()
+	defer u.mutex.RUnlock()
+
+	for outputID := range u.utxo {
+		ao, ok := u.getOutput(outputID).(*iotago.AliasOutput)
+		if !ok {
+			continue
+		}
+		if ao.AliasID == aliasID || (ao.AliasID.Empty() && iotago.AliasIDFromOutputID(outputID) == aliasID) {
+			return outputID, ao, true
+		}
+	}
+	return iotago.OutputID{}, nil, false
+}
+
 func (u *UtxoDB) GetAddressNFTs(addr iotago.Address) map[iotago.OutputID]*iotago.NFTOutput {
 	outs := u.getUnspentOutputs(addr)
 	ret := make(map[iotago.OutputID]*iotago.NFTOutput)
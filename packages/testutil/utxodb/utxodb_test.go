@@ -125,3 +125,45 @@ func TestGetOutput(t *testing.T) {
 	outidFail := iotago.OutputIDFromTransactionIDAndIndex(txID, 5)
 	require.Nil(t, u.GetOutput(outidFail))
 }
+
+func TestAddForeignOutputs(t *testing.T) {
+	keyPair := cryptolib.NewKeyPair()
+	addr := keyPair.GetPublicKey().AsEd25519Address()
+	signer := iotago.NewInMemoryAddressSigner(keyPair.GetPrivateKey().AddressKeysForEd25519Address(addr))
+
+	u := New()
+	supply := u.Supply()
+
+	foreignID := iotago.OutputIDFromTransactionIDAndIndex(tpkg.Rand32ByteArray(), 2)
+	foreignOutput := &iotago.BasicOutput{
+		Amount: FundsFromFaucetAmount,
+		Conditions: iotago.UnlockConditions{
+			&iotago.AddressUnlockCondition{Address: addr},
+		},
+	}
+	err := u.AddForeignOutputs(iotago.OutputSet{foreignID: foreignOutput})
+	require.NoError(t, err)
+	require.EqualValues(t, supply+FundsFromFaucetAmount, u.Supply())
+	require.Equal(t, foreignOutput, u.GetOutput(foreignID))
+	_, ids := u.GetUnspentOutputs(addr)
+	require.Equal(t, iotago.OutputIDs{foreignID}, ids)
+
+	require.Error(t, u.AddForeignOutputs(iotago.OutputSet{foreignID: foreignOutput}))
+
+	spend, err := builder.NewTransactionBuilder(tpkg.TestNetworkID).
+		AddInput(&builder.TxInput{
+			UnlockTarget: addr,
+			Input:        foreignOutput,
+			InputID:      foreignID,
+		}).
+		AddOutput(&iotago.BasicOutput{
+			Amount: FundsFromFaucetAmount,
+			Conditions: iotago.UnlockConditions{
+				&iotago.AddressUnlockCondition{Address: tpkg.RandEd25519Address()},
+			},
+		}).
+		Build(parameters.L1().Protocol, signer)
+	require.NoError(t, err)
+	require.NoError(t, u.AddToLedger(spend))
+	require.Zero(t, u.GetAddressBalanceBaseTokens(addr))
+}
@@ -1,75 +1,273 @@
 package trie
 
-import "bytes"
+import (
+	"bytes"
+)
 
 // Diff computes the difference between two given trie roots, returning the collections
 // of nodes that are exclusive to each trie.
 func Diff(store KVStore, root1, root2 Hash) (onlyOn1, onlyOn2 map[Hash]*NodeData) {
-	type nodeData struct {
-		*NodeData
-		key []byte
+	onlyOn1 = make(map[Hash]*NodeData)
+	onlyOn2 = make(map[Hash]*NodeData)
+	diffNodes(store, root1, root2, nil,
+		func(_ []byte, n *NodeData) { onlyOn1[n.Commitment] = n },
+		func(_ []byte, n *NodeData) { onlyOn2[n.Commitment] = n },
+		nil,
+	)
+	return onlyOn1, onlyOn2
+}
+
+// KeyDiff is a key, the value of which differs between two tries. OldValue is
+// nil if the key was added, NewValue is nil if the key was deleted.
+type KeyDiff struct {
+	Key      []byte
+	OldValue []byte
+	NewValue []byte
+}
+
+// DiffKeys computes the key-level difference between two given trie roots:
+// the keys with the given prefix, which were added, deleted or updated in
+// root2 compared to root1. The result is sorted by key and starts at fromKey
+// (if not nil). If limit > 0, at most limit keys are returned and nextKey is
+// the key, from which the next page starts, or nil if there are no more keys.
+// Only the subtrees, which differ between the two tries and may contain keys
+// at or after fromKey, are visited, and the walk stops as soon as the next
+// page is known to exist.
+func DiffKeys(store KVStore, root1, root2 Hash, prefix, fromKey []byte, limit int) (diff []*KeyDiff, nextKey []byte) {
+	unpackedPrefix := unpackBytes(prefix)
+	unpackedFromKey := unpackBytes(fromKey)
+	skip := func(nodeKey []byte, n *NodeData) bool {
+		path := concat(nodeKey, n.PathExtension)
+		if !bytes.HasPrefix(path, unpackedPrefix) && !bytes.HasPrefix(unpackedPrefix, path) {
+			return true
+		}
+		// all the keys of the subtree start with the path
+		return bytes.Compare(path, unpackedFromKey) < 0 && !bytes.HasPrefix(unpackedFromKey, path)
 	}
 
-	iterateTrie := func(tr *TrieReader) (*nodeData, func(IterateNodesAction) *nodeData) {
-		nodes := make(chan *nodeData, 1)
-		actions := make(chan IterateNodesAction, 1)
-
-		go func() {
-			defer close(nodes)
-			tr.IterateNodes(func(nodeKey []byte, node *NodeData, depth int) IterateNodesAction {
-				nodes <- &nodeData{NodeData: node, key: nodeKey}
-				action := <-actions
-				return action
-			})
-		}()
-
-		firstNode := <-nodes
-		next := func(a IterateNodesAction) *nodeData {
-			actions <- a
-			node, ok := <-nodes
-			if !ok {
-				actions <- IterateStop
-				return nil
+	// The terminals of each trie are found in key order, but the two tries
+	// are not walked in the order of the terminal keys. A key is settled, when
+	// it is before the next node of both tries, as the keys of the following
+	// nodes can only be larger.
+	var pending1, pending2 []*diffTerminal
+	collect := func(pending *[]*diffTerminal) func(nodeKey []byte, n *NodeData) {
+		return func(nodeKey []byte, n *NodeData) {
+			if n.Terminal == nil {
+				return
+			}
+			path := concat(nodeKey, n.PathExtension)
+			key, err := packUnpackedBytes(path)
+			assertNoError(err)
+			if !bytes.HasPrefix(key, prefix) || bytes.Compare(key, fromKey) < 0 {
+				return
+			}
+			*pending = append(*pending, &diffTerminal{path: path, key: key, terminal: n.Terminal})
+		}
+	}
+	var settled []*diffTerminalPair
+	// settle moves the pending keys before bound (all of them, if not
+	// bounded) to settled, if they differ
+	settle := func(bound []byte, bounded bool) {
+		for len(pending1) > 0 || len(pending2) > 0 {
+			var t1, t2 *diffTerminal
+			if len(pending1) > 0 {
+				t1 = pending1[0]
+			}
+			if len(pending2) > 0 {
+				t2 = pending2[0]
+			}
+			switch {
+			case t1 != nil && t2 != nil && bytes.Equal(t1.path, t2.path):
+				if bounded && bytes.Compare(t1.path, bound) >= 0 {
+					return
+				}
+				pending1, pending2 = pending1[1:], pending2[1:]
+				if !t1.terminal.Equals(t2.terminal) {
+					settled = append(settled, &diffTerminalPair{key: t1.key, terminal1: t1.terminal, terminal2: t2.terminal})
+				}
+			case t2 == nil || (t1 != nil && bytes.Compare(t1.path, t2.path) < 0):
+				if bounded && bytes.Compare(t1.path, bound) >= 0 {
+					return
+				}
+				pending1 = pending1[1:]
+				settled = append(settled, &diffTerminalPair{key: t1.key, terminal1: t1.terminal})
+			default:
+				if bounded && bytes.Compare(t2.path, bound) >= 0 {
+					return
+				}
+				pending2 = pending2[1:]
+				settled = append(settled, &diffTerminalPair{key: t2.key, terminal2: t2.terminal})
 			}
-			return node
 		}
-		return firstNode, next
 	}
+	// nextNodeKey returns the key of the next node, or false if there are no
+	// more nodes with the prefix
+	nextNodeKey := func(next *diffNode) ([]byte, bool) {
+		if next == nil {
+			return nil, false
+		}
+		if !bytes.HasPrefix(next.key, unpackedPrefix) && bytes.Compare(next.key, unpackedPrefix) > 0 {
+			return nil, false
+		}
+		return next.key, true
+	}
+	stop := func(next1, next2 *diffNode) bool {
+		key1, ok1 := nextNodeKey(next1)
+		key2, ok2 := nextNodeKey(next2)
+		if !ok1 && !ok2 {
+			return true
+		}
+		bound := key1
+		if !ok1 || (ok2 && bytes.Compare(key2, key1) < 0) {
+			bound = key2
+		}
+		settle(bound, true)
+		return limit > 0 && len(settled) > limit
+	}
+	diffNodes(store, root1, root2, skip, collect(&pending1), collect(&pending2), stop)
+	settle(nil, false)
 
+	if limit > 0 && len(settled) > limit {
+		nextKey = settled[limit].key
+		settled = settled[:limit]
+	}
+
+	// the values are fetched only for the keys returned
+	ns := openNodeStore(store)
+	value := func(t *Tcommitment) []byte {
+		if t == nil {
+			return nil
+		}
+		if v, inTheCommitment := t.ExtractValue(); inTheCommitment {
+			return v
+		}
+		v := ns.valueStore.Get(t.Bytes())
+		assertf(len(v) > 0, "can't fetch value, data commitment: %s", t)
+		return v
+	}
+	diff = make([]*KeyDiff, len(settled))
+	for i, p := range settled {
+		diff[i] = &KeyDiff{Key: p.key, OldValue: value(p.terminal1), NewValue: value(p.terminal2)}
+	}
+	return diff, nextKey
+}
+
+// diffTerminal is a terminal found by DiffKeys in one of the tries.
+type diffTerminal struct {
+	path     []byte // unpacked key
+	key      []byte
+	terminal *Tcommitment
+}
+
+// diffTerminalPair is a key, which differs between the two tries, with its
+// terminal in each of them (nil if the key is missing).
+type diffTerminalPair struct {
+	key       []byte
+	terminal1 *Tcommitment
+	terminal2 *Tcommitment
+}
+
+type diffNode struct {
+	*NodeData
+	key []byte
+}
+
+func iterateTrieNodes(tr *TrieReader) (*diffNode, func(IterateNodesAction) *diffNode) {
+	nodes := make(chan *diffNode, 1)
+	actions := make(chan IterateNodesAction, 1)
+
+	go func() {
+		defer close(nodes)
+		tr.IterateNodes(func(nodeKey []byte, node *NodeData, depth int) IterateNodesAction {
+			nodes <- &diffNode{NodeData: node, key: nodeKey}
+			action := <-actions
+			return action
+		})
+	}()
+
+	firstNode := <-nodes
+	next := func(a IterateNodesAction) *diffNode {
+		actions <- a
+		node, ok := <-nodes
+		if !ok {
+			actions <- IterateStop
+			return nil
+		}
+		return node
+	}
+	return firstNode, next
+}
+
+// diffNodes iterates both tries in order and calls onlyOn1 / onlyOn2 for
+// each node, which is exclusive to the corresponding trie. The subtrees of the
+// nodes, for which skip (if not nil) returns true, are not visited. Before
+// each step, stop (if not nil) is called with the next node of each trie (nil
+// if there are no more nodes); the iteration ends if it returns true.
+func diffNodes(
+	store KVStore,
+	root1, root2 Hash,
+	skip func(nodeKey []byte, n *NodeData) bool,
+	onlyOn1, onlyOn2 func(nodeKey []byte, n *NodeData),
+	stop func(next1, next2 *diffNode) bool,
+) {
 	tr1, err := NewTrieReader(store, root1)
 	mustNoErr(err)
 	tr2, err := NewTrieReader(store, root2)
 	mustNoErr(err)
-	current1, next1 := iterateTrie(tr1)
-	current2, next2 := iterateTrie(tr2)
+	current1, next1 := iterateTrieNodes(tr1)
+	current2, next2 := iterateTrieNodes(tr2)
 
-	onlyOn1 = make(map[Hash]*NodeData)
-	onlyOn2 = make(map[Hash]*NodeData)
+	visit := func(current *diffNode, next func(IterateNodesAction) *diffNode, onlyOn func([]byte, *NodeData)) *diffNode {
+		if skip != nil && skip(current.key, current.NodeData) {
+			return next(IterateSkipSubtree)
+		}
+		onlyOn(current.key, current.NodeData)
+		return next(IterateContinue)
+	}
 
 	// This is similar to the 'merge' function in mergeSort.
 	// We iterate both tries in order, advancing the iterator of the smallest
 	// node between the two.
 
+	stopped := func() bool {
+		if stop == nil || !stop(current1, current2) {
+			return false
+		}
+		// let the iterators end
+		if current1 != nil {
+			next1(IterateStop)
+		}
+		if current2 != nil {
+			next2(IterateStop)
+		}
+		return true
+	}
+
 	for current1 != nil && current2 != nil {
-		if current1.Commitment == current2.Commitment {
+		if stopped() {
+			return
+		}
+		// the same subtree can be found under different keys (e.g. two
+		// leaves with the same value), so the keys must match too
+		if current1.Commitment == current2.Commitment && bytes.Equal(current1.key, current2.key) {
 			current1 = next1(IterateSkipSubtree)
 			current2 = next2(IterateSkipSubtree)
 		} else if bytes.Compare(current1.key, current2.key) < 0 {
-			// TODO: can a node be "moved" (i.e. changed key but same commitment)?
-			onlyOn1[current1.Commitment] = current1.NodeData
-			current1 = next1(IterateContinue)
+			current1 = visit(current1, next1, onlyOn1)
 		} else {
-			onlyOn2[current2.Commitment] = current2.NodeData
-			current2 = next2(IterateContinue)
+			current2 = visit(current2, next2, onlyOn2)
 		}
 	}
 	for current1 != nil {
-		onlyOn1[current1.Commitment] = current1.NodeData
-		current1 = next1(IterateContinue)
+		if stopped() {
+			return
+		}
+		current1 = visit(current1, next1, onlyOn1)
 	}
 	for current2 != nil {
-		onlyOn2[current2.Commitment] = current2.NodeData
-		current2 = next2(IterateContinue)
+		if stopped() {
+			return
+		}
+		current2 = visit(current2, next2, onlyOn2)
 	}
-	return onlyOn1, onlyOn2
 }
@@ -1,5 +1,11 @@
 package trie
 
+import (
+	"io"
+
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+)
+
 // MerkleProof is a proof of inclusion or absence
 type MerkleProof struct {
 	Key  []byte
@@ -54,3 +60,75 @@ func (tr *TrieReader) MerkleProof(key []byte) *MerkleProof {
 	}
 	return ret
 }
+
+func MerkleProofFromBytes(data []byte) (*MerkleProof, error) {
+	return rwutil.ReadFromBytes(data, new(MerkleProof))
+}
+
+func (p *MerkleProof) Bytes() []byte {
+	return rwutil.WriteToBytes(p)
+}
+
+func (p *MerkleProof) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	p.Key = rr.ReadBytes()
+	size := rr.ReadSize16()
+	p.Path = make([]*MerkleProofElement, size)
+	for i := range p.Path {
+		p.Path[i] = new(MerkleProofElement)
+		rr.Read(p.Path[i])
+	}
+	return rr.Err
+}
+
+func (p *MerkleProof) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteBytes(p.Key)
+	ww.WriteSize16(len(p.Path))
+	for _, elem := range p.Path {
+		ww.Write(elem)
+	}
+	return ww.Err
+}
+
+func (e *MerkleProofElement) Read(r io.Reader) error {
+	rr := rwutil.NewReader(r)
+	e.PathExtension = rr.ReadBytes()
+	if len(e.PathExtension) == 0 {
+		e.PathExtension = nil
+	}
+	flags := rr.ReadUint16()
+	for i := 0; i < NumChildren; i++ {
+		e.Children[i] = nil
+		if flags&(1<<i) != 0 {
+			e.Children[i] = new(Hash)
+			rr.Read(e.Children[i])
+		}
+	}
+	e.Terminal = rr.ReadBytes()
+	if len(e.Terminal) == 0 {
+		e.Terminal = nil
+	}
+	e.ChildIndex = int(rr.ReadUint8())
+	return rr.Err
+}
+
+func (e *MerkleProofElement) Write(w io.Writer) error {
+	ww := rwutil.NewWriter(w)
+	ww.WriteBytes(e.PathExtension)
+	flags := uint16(0)
+	for i, child := range e.Children {
+		if child != nil {
+			flags |= 1 << i
+		}
+	}
+	ww.WriteUint16(flags)
+	for _, child := range e.Children {
+		if child != nil {
+			ww.Write(child)
+		}
+	}
+	ww.WriteBytes(e.Terminal)
+	ww.WriteUint8(uint8(e.ChildIndex))
+	return ww.Err
+}
@@ -43,6 +43,11 @@ func (p *MerkleProof) MustKeyWithTerminal() ([]byte, []byte) {
 	panic("wrong lastElem.ChildIndex")
 }
 
+// IsForKey checks if the proof is about the given key
+func (p *MerkleProof) IsForKey(key []byte) bool {
+	return bytes.Equal(p.Key, unpackBytes(key))
+}
+
 // IsProofOfAbsence checks if it is proof of absence. MerkleProof that the trie commits to something else in the place
 // where it would commit to the key if it would be present
 func (p *MerkleProof) IsProofOfAbsence() bool {
@@ -5,47 +5,67 @@ type PruneStats struct {
 	DeletedValues uint
 }
 
-func Prune(store KVStore, trieRoot Hash) (PruneStats, error) {
-	refcounts := newRefcounts(store)
+func (s *PruneStats) Add(other PruneStats) {
+	s.DeletedNodes += other.DeletedNodes
+	s.DeletedValues += other.DeletedValues
+}
 
+func Prune(store KVStore, trieRoot Hash) (PruneStats, error) {
 	stats := PruneStats{}
 
-	tr, err := NewTrieReader(store, trieRoot)
+	_, err := NewTrieReader(store, trieRoot)
 	if err != nil {
 		return stats, err
 	}
 
-	var deletedNodes []Hash
-	var deletedValues [][]byte
+	nodes := []Hash{trieRoot}
+	for len(nodes) > 0 {
+		var stepStats PruneStats
+		stepStats, nodes = PruneNodes(store, nodes)
+		stats.Add(stepStats)
+	}
+	return stats, nil
+}
+
+// PruneNodes performs a single step of the incremental pruning: it releases
+// one reference to each of the given nodes (a node may be listed more than
+// once). The nodes that are not referenced anymore are deleted; their children
+// are returned and must be passed to the subsequent calls of PruneNodes, until
+// no nodes are left.
+//
+// To prune a trie, start with its root. Other tries may be committed between
+// the steps, as the refcounts are updated in each step.
+func PruneNodes(store KVStore, nodes []Hash) (PruneStats, []Hash) {
+	refcounts := newRefcounts(store)
+	ns := &nodeStore{
+		trieStore:  makeReaderPartition(store, partitionTrieNodes),
+		valueStore: makeReaderPartition(store, partitionValues),
+	}
+	triePartition := makeWriterPartition(store, partitionTrieNodes)
+	valuePartition := makeWriterPartition(store, partitionValues)
 
-	tr.IterateNodes(func(nodeKey []byte, n *NodeData, depth int) IterateNodesAction {
-		if refcounts.GetNode(n.Commitment) == 0 {
+	stats := PruneStats{}
+	var children []Hash
+	for _, commitment := range nodes {
+		if refcounts.GetNode(commitment) == 0 {
 			// node already deleted
-			return IterateSkipSubtree
+			continue
 		}
+		n := ns.MustFetchNodeData(commitment)
 		deleteNode, deleteValue := refcounts.Dec(n)
 		if deleteValue {
-			deletedValues = append(deletedValues, n.Terminal.Bytes())
+			valuePartition.Del(n.Terminal.Bytes())
+			stats.DeletedValues++
 		}
 		if deleteNode {
-			deletedNodes = append(deletedNodes, n.Commitment)
+			triePartition.Del(commitment[:])
+			stats.DeletedNodes++
 			// node deleted => decrease refcount of children
-			return IterateContinue
+			n.iterateChildren(func(_ byte, child Hash) bool {
+				children = append(children, child)
+				return true
+			})
 		}
-		// node not deleted => do not decrease refcount of children
-		return IterateSkipSubtree
-	})
-
-	triePartition := makeWriterPartition(store, partitionTrieNodes)
-	for _, hash := range deletedNodes {
-		triePartition.Del(hash[:])
-	}
-	valuePartition := makeWriterPartition(store, partitionValues)
-	for _, key := range deletedValues {
-		valuePartition.Del(key)
 	}
-	return PruneStats{
-		DeletedNodes:  uint(len(deletedNodes)),
-		DeletedValues: uint(len(deletedValues)),
-	}, nil
+	return stats, children
 }
@@ -1,6 +1,7 @@
 package trie
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/iotaledger/wasp/packages/util/rwutil"
@@ -99,3 +100,30 @@ func RestoreSnapshot(r io.Reader, store KVStore) error {
 	}
 	return rr.Err
 }
+
+// CheckTrie checks that all the nodes and values of the trie with the given
+// root are in the store, e.g. after restoring a snapshot, which may be
+// incomplete.
+func CheckTrie(store KVStore, root Hash) error {
+	ns := &nodeStore{
+		trieStore:  makeReaderPartition(store, partitionTrieNodes),
+		valueStore: makeReaderPartition(store, partitionValues),
+	}
+	nodes := []Hash{root}
+	for len(nodes) > 0 {
+		commitment := nodes[len(nodes)-1]
+		nodes = nodes[:len(nodes)-1]
+		n, ok := ns.FetchNodeData(commitment)
+		if !ok {
+			return fmt.Errorf("trie node %s is missing", commitment)
+		}
+		if n.Terminal != nil && !n.Terminal.IsValue && !ns.valueStore.Has(n.Terminal.Bytes()) {
+			return fmt.Errorf("value of trie node %s is missing", commitment)
+		}
+		n.iterateChildren(func(_ byte, child Hash) bool {
+			nodes = append(nodes, child)
+			return true
+		})
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package test
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/trie"
+	"github.com/iotaledger/wasp/packages/util"
+)
+
+func TestDiffKeys(t *testing.T) {
+	store := NewInMemoryKVStore()
+	root0 := trie.MustInitRoot(store)
+
+	rnd := util.NewPseudoRand(1)
+	longValue := strings.Repeat("v", 100) // not stored in the terminal commitment
+	update := func(root trie.Hash, f func(tr *trie.TrieUpdatable)) trie.Hash {
+		tr, err := trie.NewTrieUpdatable(store, root)
+		require.NoError(t, err)
+		f(tr)
+		ret, _ := tr.Commit(store)
+		return ret
+	}
+	root1 := update(root0, func(tr *trie.TrieUpdatable) {
+		for _, key := range genRnd3() {
+			if key == "" {
+				continue
+			}
+			switch rnd.Intn(3) {
+			case 0:
+				tr.Update([]byte(key), []byte(key))
+			case 1:
+				tr.Update([]byte(key), []byte(longValue))
+			default:
+				tr.Update([]byte(key), []byte("same"))
+			}
+		}
+	})
+	root2 := update(root1, func(tr *trie.TrieUpdatable) {
+		for _, key := range genRnd3() {
+			if key == "" {
+				continue
+			}
+			switch rnd.Intn(10) {
+			case 0:
+				tr.Update([]byte(key), nil)
+			case 1:
+				tr.Update([]byte(key), []byte(key+"-updated"))
+			case 2:
+				tr.Update([]byte(key+"-new"), []byte("same"))
+			case 3:
+				tr.Update([]byte(key+"-new"), []byte(longValue+"-new"))
+			}
+		}
+	})
+
+	readAll := func(root trie.Hash) map[string][]byte {
+		tr, err := trie.NewTrieReader(store, root)
+		require.NoError(t, err)
+		ret := make(map[string][]byte)
+		tr.Iterate(func(k []byte, v []byte) bool {
+			ret[string(k)] = v
+			return true
+		})
+		return ret
+	}
+	expectedDiff := func(prefix []byte) []*trie.KeyDiff {
+		state1 := readAll(root1)
+		state2 := readAll(root2)
+		var ret []*trie.KeyDiff
+		for k, v1 := range state1 {
+			if v2 := state2[k]; !bytes.Equal(v1, v2) && bytes.HasPrefix([]byte(k), prefix) {
+				ret = append(ret, &trie.KeyDiff{Key: []byte(k), OldValue: v1, NewValue: v2})
+			}
+		}
+		for k, v2 := range state2 {
+			if _, ok := state1[k]; !ok && bytes.HasPrefix([]byte(k), prefix) {
+				ret = append(ret, &trie.KeyDiff{Key: []byte(k), NewValue: v2})
+			}
+		}
+		return ret
+	}
+
+	expectedSorted := func(diff []*trie.KeyDiff) []*trie.KeyDiff {
+		sort.Slice(diff, func(i, j int) bool { return bytes.Compare(diff[i].Key, diff[j].Key) < 0 })
+		return diff
+	}
+
+	diff, nextKey := trie.DiffKeys(store, root1, root1, nil, nil, 0)
+	require.Empty(t, diff)
+	require.Nil(t, nextKey)
+	for _, prefix := range []string{"", "a", "ab", "abc", "zzzzzz"} {
+		expected := expectedDiff([]byte(prefix))
+		diff, nextKey := trie.DiffKeys(store, root1, root2, []byte(prefix), nil, 0)
+		require.Nil(t, nextKey)
+		require.ElementsMatch(t, expected, diff, "prefix %q", prefix)
+		for i := 1; i < len(diff); i++ {
+			require.Negative(t, bytes.Compare(diff[i-1].Key, diff[i].Key))
+		}
+		if prefix == "" {
+			require.NotEmpty(t, diff)
+		}
+	}
+
+	// the pages, each starting at the next key of the previous one, make up
+	// the whole diff
+	diff, _ = trie.DiffKeys(store, root1, root2, nil, nil, 0)
+	for _, prefix := range []string{"", "a"} {
+		var pages []*trie.KeyDiff
+		var fromKey []byte
+		for {
+			page, nextKey := trie.DiffKeys(store, root1, root2, []byte(prefix), fromKey, 7)
+			require.LessOrEqual(t, len(page), 7)
+			pages = append(pages, page...)
+			if nextKey == nil {
+				break
+			}
+			require.Len(t, page, 7)
+			fromKey = nextKey
+		}
+		require.Equal(t, expectedSorted(expectedDiff([]byte(prefix))), pages, "prefix %q", prefix)
+	}
+	page, nextKey := trie.DiffKeys(store, root1, root2, nil, diff[len(diff)/2].Key, 1)
+	require.Equal(t, diff[len(diff)/2:len(diff)/2+1], page)
+	require.Equal(t, diff[len(diff)/2+1].Key, nextKey)
+
+	// a page is found without walking the whole diff
+	counter := &countingKVStore{KVStore: store}
+	trie.DiffKeys(counter, root1, root2, nil, nil, 1)
+	firstPageReads := counter.reads
+	counter.reads = 0
+	trie.DiffKeys(counter, root1, root2, nil, nil, 0)
+	require.Less(t, 10*firstPageReads, counter.reads)
+
+	// the diff in the opposite direction swaps the values
+	reverseDiff, _ := trie.DiffKeys(store, root2, root1, nil, nil, 0)
+	require.Len(t, reverseDiff, len(diff))
+	for i := range diff {
+		require.Equal(t, diff[i].Key, reverseDiff[i].Key)
+		require.Equal(t, diff[i].OldValue, reverseDiff[i].NewValue)
+		require.Equal(t, diff[i].NewValue, reverseDiff[i].OldValue)
+	}
+}
+
+type countingKVStore struct {
+	trie.KVStore
+	reads int
+}
+
+func (s *countingKVStore) Get(key []byte) []byte {
+	s.reads++
+	return s.KVStore.Get(key)
+}
@@ -30,6 +30,9 @@ func TestProofScenariosBlake2b(t *testing.T) {
 				p := trr.MerkleProof([]byte(k))
 				err = p.Validate(root.Bytes())
 				require.NoError(t, err)
+				p2, err := trie.MerkleProofFromBytes(p.Bytes())
+				require.NoError(t, err)
+				require.EqualValues(t, p, p2)
 				if len(v) > 0 {
 					cID := trie.CommitToData([]byte(v))
 					err = p.ValidateWithTerminal(root.Bytes(), cID.Bytes())
@@ -0,0 +1,130 @@
+package users
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/iotaledger/hive.go/lo"
+)
+
+// APIKeyPrefix is the prefix of all API keys, used to tell them apart from JWTs.
+const APIKeyPrefix = "wasp_"
+
+const (
+	apiKeyIDLength     = 8
+	apiKeySecretLength = 32
+)
+
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// APIKey is a long-lived named key of a user, that can be used instead of a JWT.
+// Only the hash of the secret part of the key is stored.
+type APIKey struct {
+	Name        string
+	ID          string
+	SecretHash  []byte
+	Permissions map[string]struct{}
+	CreatedAt   time.Time
+	// ExpiresAt is zero, if the key doesn't expire.
+	ExpiresAt time.Time
+}
+
+func NewAPIKey(name, id, secretHashHex string, permissions map[string]struct{}, createdAt, expiresAt time.Time) (*APIKey, error) {
+	if name == "" {
+		return nil, errors.New("api key name must not be empty")
+	}
+
+	if len(id) != 2*apiKeyIDLength {
+		return nil, fmt.Errorf("api key id must be %d (hex encoded) in length", 2*apiKeyIDLength)
+	}
+
+	if len(secretHashHex) != 64 {
+		return nil, errors.New("api key secret hash must be 64 (hex encoded sha256 hash) in length")
+	}
+
+	secretHash, err := hex.DecodeString(secretHashHex)
+	if err != nil {
+		return nil, errors.New("api key secret hash must be hex encoded")
+	}
+
+	return &APIKey{
+		Name:        name,
+		ID:          id,
+		SecretHash:  secretHash,
+		Permissions: permissions,
+		CreatedAt:   createdAt,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// generateAPIKey returns a new random API key ("wasp_<id>_<secret>") and its ID and secret hash.
+func generateAPIKey() (key, id string, secretHash []byte, err error) {
+	idBytes := make([]byte, apiKeyIDLength)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", "", nil, err
+	}
+	secret := make([]byte, apiKeySecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", nil, err
+	}
+
+	id = hex.EncodeToString(idBytes)
+	secretHex := hex.EncodeToString(secret)
+	hash := sha256.Sum256([]byte(secretHex))
+
+	return APIKeyPrefix + id + "_" + secretHex, id, hash[:], nil
+}
+
+// parseAPIKey splits an API key into its ID and secret.
+func parseAPIKey(key string) (id, secret string, err error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return "", "", ErrInvalidAPIKey
+	}
+
+	id, secret, ok := strings.Cut(key[len(APIKeyPrefix):], "_")
+	if !ok || len(id) != 2*apiKeyIDLength || len(secret) != 2*apiKeySecretLength {
+		return "", "", ErrInvalidAPIKey
+	}
+
+	return id, secret, nil
+}
+
+// IsAPIKey checks whether the given string looks like an API key.
+func IsAPIKey(key string) bool {
+	_, _, err := parseAPIKey(key)
+	return err == nil
+}
+
+// verifySecret checks the secret against the stored hash in constant time.
+func (k *APIKey) verifySecret(secret string) bool {
+	hash := sha256.Sum256([]byte(secret))
+	return subtle.ConstantTimeCompare(hash[:], k.SecretHash) == 1
+}
+
+// IsExpired checks whether the key is expired at the given time.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// Clone returns a copy of an API key.
+func (k *APIKey) Clone() *APIKey {
+	return &APIKey{
+		Name:        k.Name,
+		ID:          k.ID,
+		SecretHash:  lo.CopySlice(k.SecretHash),
+		Permissions: copyPermissions(k.Permissions),
+		CreatedAt:   k.CreatedAt,
+		ExpiresAt:   k.ExpiresAt,
+	}
+}
+
+// PermissionsSlice returns the permissions of the API key as a slice.
+func (k *APIKey) PermissionsSlice() []string {
+	return permissionsSlice(k.Permissions)
+}
@@ -3,6 +3,7 @@ package users
 import (
 	"encoding/hex"
 	"errors"
+	"time"
 
 	"github.com/iotaledger/hive.go/lo"
 	"github.com/iotaledger/wasp/packages/onchangemap"
@@ -14,6 +15,13 @@ type User struct {
 	PasswordHash []byte
 	PasswordSalt []byte
 	Permissions  map[string]struct{}
+	// APIKeys are the API keys of the user by name.
+	APIKeys map[string]*APIKey
+	// TokensNotBefore revokes all tokens of the user issued until then.
+	TokensNotBefore time.Time
+	// RevokedTokens are the IDs of the single revoked tokens of the user with
+	// their expiry time (zero if they don't expire), after which they can be forgotten.
+	RevokedTokens map[string]time.Time
 }
 
 func NewUser(username, passwordHashHex, passwordSaltHex string, permissions map[string]struct{}) (*User, error) {
@@ -54,26 +62,59 @@ func (u *User) ID() util.ComparableString {
 
 // Clone returns a copy of a user.
 func (u *User) Clone() onchangemap.Item[string, util.ComparableString] {
-	permissionsCopy := make(map[string]struct{}, len(u.Permissions))
-	for k := range u.Permissions {
-		permissionsCopy[k] = struct{}{}
+	apiKeysCopy := make(map[string]*APIKey, len(u.APIKeys))
+	for name, key := range u.APIKeys {
+		apiKeysCopy[name] = key.Clone()
+	}
+
+	revokedTokensCopy := make(map[string]time.Time, len(u.RevokedTokens))
+	for id, expiresAt := range u.RevokedTokens {
+		revokedTokensCopy[id] = expiresAt
 	}
 
 	return &User{
-		Name:         u.Name,
-		PasswordHash: lo.CopySlice(u.PasswordHash),
-		PasswordSalt: lo.CopySlice(u.PasswordSalt),
-		Permissions:  permissionsCopy,
+		Name:            u.Name,
+		PasswordHash:    lo.CopySlice(u.PasswordHash),
+		PasswordSalt:    lo.CopySlice(u.PasswordSalt),
+		Permissions:     copyPermissions(u.Permissions),
+		APIKeys:         apiKeysCopy,
+		TokensNotBefore: u.TokensNotBefore,
+		RevokedTokens:   revokedTokensCopy,
 	}
 }
 
+// TokenIssueTime returns the issue time for a new token of the user, which is
+// now, unless all tokens of the user were revoked during the current second.
+// In that case it is the start of the next second, as the issue time of tokens
+// has a precision of seconds and the token would be considered revoked.
+func (u *User) TokenIssueTime() time.Time {
+	now := time.Now()
+	if now.Before(u.TokensNotBefore) {
+		return u.TokensNotBefore
+	}
+	return now
+}
+
 // PermissionsSlice returns the permissions of the user as a slice.
 func (u *User) PermissionsSlice() []string {
-	permissions := make([]string, 0, len(u.Permissions))
+	return permissionsSlice(u.Permissions)
+}
+
+func copyPermissions(permissions map[string]struct{}) map[string]struct{} {
+	permissionsCopy := make(map[string]struct{}, len(permissions))
+	for k := range permissions {
+		permissionsCopy[k] = struct{}{}
+	}
+
+	return permissionsCopy
+}
+
+func permissionsSlice(permissions map[string]struct{}) []string {
+	ret := make([]string, 0, len(permissions))
 
-	for k := range u.Permissions {
-		permissions = append(permissions, k)
+	for k := range permissions {
+		ret = append(ret, k)
 	}
 
-	return permissions
+	return ret
 }
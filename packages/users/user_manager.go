@@ -4,21 +4,35 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	"golang.org/x/exp/maps"
-	"golang.org/x/exp/slices"
 
 	"github.com/iotaledger/hive.go/web/basicauth"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
 	"github.com/iotaledger/wasp/packages/onchangemap"
 	"github.com/iotaledger/wasp/packages/util"
 )
 
-var AllowedPermissions = []string{"read", "write"}
+// ErrTokenRevoked is returned when a token is used after it was revoked.
+var ErrTokenRevoked = errors.New("token was revoked")
 
 // UserManager handles the list of users that are stored in the user config.
 // It calls a function if the list changed.
 type UserManager struct {
 	onChangeMap *onchangemap.OnChangeMap[string, util.ComparableString, *User]
+	// mutex serializes the changes of single users that are based on their
+	// current state, so that none of them is lost.
+	mutex sync.Mutex
+	// usedTokens holds the IDs of the used single-use tokens (see UseToken)
+	// of each user, with their expiry time. They are only kept in memory, so
+	// that the user config isn't rewritten on every token refresh.
+	usedTokensMutex sync.RWMutex
+	usedTokens      map[string]map[string]time.Time
+	// singleUseTokensNotBefore rejects the single-use tokens issued before the
+	// user manager was created, since it doesn't know which of them were used.
+	singleUseTokensNotBefore time.Time
 }
 
 // NewUserManager creates a new user manager.
@@ -27,11 +41,16 @@ func NewUserManager(storeCallback func([]*User) error) *UserManager {
 		onChangeMap: onchangemap.NewOnChangeMap(
 			onchangemap.WithChangedCallback[string, util.ComparableString](storeCallback),
 		),
+		// the issue time of tokens has a precision of seconds
+		singleUseTokensNotBefore: time.Now().Truncate(time.Second),
 	}
 }
 
+// isPermissionAllowed checks whether the permission is a valid global, per capability
+// or per chain permission (see the permissions package).
 func isPermissionAllowed(permission string) bool {
-	return slices.Contains(AllowedPermissions, permission)
+	_, _, _, err := permissions.Parse(permission)
+	return err == nil
 }
 
 func (m *UserManager) SanitizePermissions(permissions map[string]struct{}) map[string]struct{} {
@@ -80,6 +99,9 @@ func (m *UserManager) ModifyUser(user *User) error {
 
 // ChangeUserPassword changes the password of a user.
 func (m *UserManager) ChangeUserPassword(name string, passwordHash, passwordSalt []byte) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	user, err := m.User(name)
 	if err != nil {
 		return fmt.Errorf("unable to change password for user \"%s\": user does not exist", name)
@@ -97,6 +119,9 @@ func (m *UserManager) ChangeUserPassword(name string, passwordHash, passwordSalt
 
 // ChangeUserPermissions changes the permissions of a user.
 func (m *UserManager) ChangeUserPermissions(name string, permissions map[string]struct{}) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	user, err := m.User(name)
 	if err != nil {
 		return fmt.Errorf("unable to change permissions for user \"%s\": user does not exist", name)
@@ -111,8 +136,243 @@ func (m *UserManager) ChangeUserPermissions(name string, permissions map[string]
 	return nil
 }
 
+// AddAPIKey adds a new API key with the given permissions to a user and returns
+// the key. The key can't be retrieved later on, only its hash is stored.
+// The permissions must be granted to the user. A zero expiresAt means the key doesn't expire.
+func (m *UserManager) AddAPIKey(name, keyName string, keyPermissions map[string]struct{}, expiresAt time.Time) (string, *APIKey, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	user, err := m.User(name)
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to add api key for user \"%s\": user does not exist", name)
+	}
+
+	if keyName == "" {
+		return "", nil, fmt.Errorf("unable to add api key for user \"%s\": name must not be empty", name)
+	}
+
+	if _, exists := user.APIKeys[keyName]; exists {
+		return "", nil, fmt.Errorf("unable to add api key for user \"%s\": api key \"%s\" already exists", name, keyName)
+	}
+
+	for permission := range keyPermissions {
+		if !isPermissionAllowed(permission) {
+			return "", nil, fmt.Errorf("unable to add api key for user \"%s\": invalid permission \"%s\"", name, permission)
+		}
+		if !permissions.IsGranted(user.Permissions, permission, "") {
+			return "", nil, fmt.Errorf("unable to add api key for user \"%s\": permission \"%s\" is not granted to the user", name, permission)
+		}
+	}
+
+	key, id, secretHash, err := generateAPIKey()
+	if err != nil {
+		return "", nil, fmt.Errorf("unable to add api key for user \"%s\": %w", name, err)
+	}
+
+	apiKey := &APIKey{
+		Name:        keyName,
+		ID:          id,
+		SecretHash:  secretHash,
+		Permissions: copyPermissions(keyPermissions),
+		CreatedAt:   time.Now().Truncate(time.Second),
+		ExpiresAt:   expiresAt,
+	}
+	if user.APIKeys == nil {
+		user.APIKeys = make(map[string]*APIKey)
+	}
+	user.APIKeys[keyName] = apiKey
+
+	if err := m.ModifyUser(user); err != nil {
+		return "", nil, fmt.Errorf("unable to add api key for user \"%s\": %w", name, err)
+	}
+
+	return key, apiKey.Clone(), nil
+}
+
+// RemoveAPIKey removes an API key of a user.
+func (m *UserManager) RemoveAPIKey(name, keyName string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	user, err := m.User(name)
+	if err != nil {
+		return fmt.Errorf("unable to remove api key of user \"%s\": user does not exist", name)
+	}
+
+	if _, exists := user.APIKeys[keyName]; !exists {
+		return fmt.Errorf("unable to remove api key of user \"%s\": api key \"%s\" does not exist", name, keyName)
+	}
+	delete(user.APIKeys, keyName)
+
+	if err := m.ModifyUser(user); err != nil {
+		return fmt.Errorf("unable to remove api key of user \"%s\": %w", name, err)
+	}
+
+	return nil
+}
+
+// AuthenticateAPIKey returns the user and the API key the given key belongs to,
+// if the key is valid and not expired.
+func (m *UserManager) AuthenticateAPIKey(key string) (*User, *APIKey, error) {
+	id, secret, err := parseAPIKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, user := range m.Users() {
+		for _, apiKey := range user.APIKeys {
+			if apiKey.ID != id {
+				continue
+			}
+			if !apiKey.verifySecret(secret) || apiKey.IsExpired(time.Now()) {
+				return nil, nil, ErrInvalidAPIKey
+			}
+			return user, apiKey, nil
+		}
+	}
+
+	return nil, nil, ErrInvalidAPIKey
+}
+
+// RevokeToken revokes a single token of a user by its ID. The expiry time of
+// the token (zero if it doesn't expire) is used to forget the revocation once
+// the token is expired anyway.
+func (m *UserManager) RevokeToken(name, tokenID string, expiresAt time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.revokeToken(name, tokenID, expiresAt)
+}
+
+// UseToken marks a single-use token of a user (i.e. a refresh token) as used,
+// so that it can't be used again. It fails with ErrTokenRevoked if the token was
+// revoked or used already, which is checked in the same step.
+// The used tokens are not stored in the user config, so the single-use tokens
+// issued before the user manager was created (i.e. before a restart of the
+// node) are rejected, as they might have been used already.
+func (m *UserManager) UseToken(name, tokenID string, issuedAt, expiresAt time.Time) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if issuedAt.Before(m.singleUseTokensNotBefore) || m.IsTokenRevoked(name, tokenID, issuedAt) {
+		return ErrTokenRevoked
+	}
+
+	if tokenID == "" {
+		return fmt.Errorf("unable to use token of user \"%s\": token has no id", name)
+	}
+
+	m.usedTokensMutex.Lock()
+	defer m.usedTokensMutex.Unlock()
+
+	now := time.Now()
+	for userName, userTokens := range m.usedTokens {
+		for id, tokenExpiresAt := range userTokens {
+			if !tokenExpiresAt.IsZero() && !now.Before(tokenExpiresAt) {
+				delete(userTokens, id)
+			}
+		}
+		if len(userTokens) == 0 {
+			delete(m.usedTokens, userName)
+		}
+	}
+
+	if m.usedTokens == nil {
+		m.usedTokens = make(map[string]map[string]time.Time)
+	}
+	if m.usedTokens[name] == nil {
+		m.usedTokens[name] = make(map[string]time.Time)
+	}
+	m.usedTokens[name][tokenID] = expiresAt
+
+	return nil
+}
+
+func (m *UserManager) forgetUsedTokens(name string) {
+	m.usedTokensMutex.Lock()
+	defer m.usedTokensMutex.Unlock()
+
+	delete(m.usedTokens, name)
+}
+
+func (m *UserManager) revokeToken(name, tokenID string, expiresAt time.Time) error {
+	user, err := m.User(name)
+	if err != nil {
+		return fmt.Errorf("unable to revoke token of user \"%s\": user does not exist", name)
+	}
+
+	if tokenID == "" {
+		return fmt.Errorf("unable to revoke token of user \"%s\": token has no id", name)
+	}
+
+	now := time.Now()
+	revokedTokens := make(map[string]time.Time, len(user.RevokedTokens)+1)
+	for id, tokenExpiresAt := range user.RevokedTokens {
+		if tokenExpiresAt.IsZero() || now.Before(tokenExpiresAt) {
+			revokedTokens[id] = tokenExpiresAt
+		}
+	}
+	revokedTokens[tokenID] = expiresAt
+	user.RevokedTokens = revokedTokens
+
+	if err := m.ModifyUser(user); err != nil {
+		return fmt.Errorf("unable to revoke token of user \"%s\": %w", name, err)
+	}
+
+	return nil
+}
+
+// RevokeAllTokens revokes all tokens issued to a user until now.
+// The API keys of the user are not affected.
+func (m *UserManager) RevokeAllTokens(name string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	user, err := m.User(name)
+	if err != nil {
+		return fmt.Errorf("unable to revoke tokens of user \"%s\": user does not exist", name)
+	}
+
+	// the issue time of tokens has a precision of seconds, so the tokens issued
+	// during the rest of this second must be rejected too (see User.TokenIssueTime)
+	user.TokensNotBefore = time.Now().Truncate(time.Second).Add(time.Second)
+	// the single revocations are covered now
+	user.RevokedTokens = nil
+
+	if err := m.ModifyUser(user); err != nil {
+		return fmt.Errorf("unable to revoke tokens of user \"%s\": %w", name, err)
+	}
+	m.forgetUsedTokens(name)
+
+	return nil
+}
+
+// IsTokenRevoked checks whether the token of a user with the given ID and issue time was revoked.
+func (m *UserManager) IsTokenRevoked(name, tokenID string, issuedAt time.Time) bool {
+	user, err := m.User(name)
+	if err != nil {
+		return true
+	}
+
+	if issuedAt.Before(user.TokensNotBefore) {
+		return true
+	}
+
+	if _, revoked := user.RevokedTokens[tokenID]; revoked {
+		return true
+	}
+
+	m.usedTokensMutex.RLock()
+	defer m.usedTokensMutex.RUnlock()
+
+	_, used := m.usedTokens[name][tokenID]
+	return used
+}
+
 // RemoveUser removes a user from the user manager.
 func (m *UserManager) RemoveUser(name string) error {
+	m.forgetUsedTokens(name)
 	return m.onChangeMap.Delete(util.ComparableString(name))
 }
 
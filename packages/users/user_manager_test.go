@@ -0,0 +1,165 @@
+package users_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/users"
+)
+
+func newTestUserManager(t *testing.T, permissions ...string) *users.UserManager {
+	userManager := users.NewUserManager(func([]*users.User) error { return nil })
+	userPermissions := map[string]struct{}{}
+	for _, permission := range permissions {
+		userPermissions[permission] = struct{}{}
+	}
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "wasp",
+		Permissions: userPermissions,
+	}))
+	return userManager
+}
+
+func TestAddAPIKeyPermissions(t *testing.T) {
+	userManager := newTestUserManager(t, "chains:write", "node:read")
+
+	_, _, err := userManager.AddAPIKey("wasp", "ci", map[string]struct{}{"unknown": {}}, time.Time{})
+	require.Error(t, err, "invalid permission")
+	_, _, err = userManager.AddAPIKey("wasp", "ci", map[string]struct{}{"write": {}}, time.Time{})
+	require.Error(t, err, "permission not granted to the user")
+	_, _, err = userManager.AddAPIKey("wasp", "", map[string]struct{}{"node:read": {}}, time.Time{})
+	require.Error(t, err, "empty name")
+	_, _, err = userManager.AddAPIKey("unknown", "ci", map[string]struct{}{"node:read": {}}, time.Time{})
+	require.Error(t, err, "unknown user")
+
+	// a read permission is implied by the write permission of the user
+	key, apiKey, err := userManager.AddAPIKey("wasp", "ci", map[string]struct{}{"chains:read": {}, "node:read": {}}, time.Time{})
+	require.NoError(t, err)
+	require.True(t, users.IsAPIKey(key))
+	require.Equal(t, map[string]struct{}{"chains:read": {}, "node:read": {}}, apiKey.Permissions)
+
+	_, _, err = userManager.AddAPIKey("wasp", "ci", map[string]struct{}{"node:read": {}}, time.Time{})
+	require.Error(t, err, "duplicate name")
+
+	user, err := userManager.User("wasp")
+	require.NoError(t, err)
+	require.Len(t, user.APIKeys, 1)
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	userManager := newTestUserManager(t, "node:read")
+
+	key, _, err := userManager.AddAPIKey("wasp", "valid", map[string]struct{}{"node:read": {}}, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	user, apiKey, err := userManager.AuthenticateAPIKey(key)
+	require.NoError(t, err)
+	require.Equal(t, "wasp", user.Name)
+	require.Equal(t, "valid", apiKey.Name)
+
+	expiredKey, _, err := userManager.AddAPIKey("wasp", "expired", map[string]struct{}{"node:read": {}}, time.Now().Add(-time.Second))
+	require.NoError(t, err)
+	_, _, err = userManager.AuthenticateAPIKey(expiredKey)
+	require.ErrorIs(t, err, users.ErrInvalidAPIKey)
+
+	// a wrong secret for an existing key
+	_, _, err = userManager.AuthenticateAPIKey(key[:len(key)-1] + "x")
+	require.ErrorIs(t, err, users.ErrInvalidAPIKey)
+	_, _, err = userManager.AuthenticateAPIKey("no api key")
+	require.ErrorIs(t, err, users.ErrInvalidAPIKey)
+
+	// removed keys can't be used anymore
+	require.NoError(t, userManager.RemoveAPIKey("wasp", "valid"))
+	_, _, err = userManager.AuthenticateAPIKey(key)
+	require.ErrorIs(t, err, users.ErrInvalidAPIKey)
+}
+
+func TestRevokeTokenPrunesExpiredRevocations(t *testing.T) {
+	userManager := newTestUserManager(t)
+	now := time.Now()
+
+	require.NoError(t, userManager.RevokeToken("wasp", "expired", now.Add(-time.Second)))
+	require.True(t, userManager.IsTokenRevoked("wasp", "expired", now))
+	require.NoError(t, userManager.RevokeToken("wasp", "forever", time.Time{}))
+	require.Error(t, userManager.RevokeToken("wasp", "", now.Add(time.Hour)), "token without id")
+
+	require.NoError(t, userManager.RevokeToken("wasp", "valid", now.Add(time.Hour)))
+	user, err := userManager.User("wasp")
+	require.NoError(t, err)
+	// the expired revocation is forgotten by the next one
+	require.Equal(t, map[string]time.Time{
+		"forever": {},
+		"valid":   now.Add(time.Hour),
+	}, user.RevokedTokens)
+	require.True(t, userManager.IsTokenRevoked("wasp", "valid", now))
+	require.False(t, userManager.IsTokenRevoked("wasp", "other", now))
+}
+
+func TestRevokeAllTokens(t *testing.T) {
+	userManager := newTestUserManager(t)
+	require.NoError(t, userManager.RevokeToken("wasp", "single", time.Now().Add(time.Hour)))
+
+	require.NoError(t, userManager.RevokeAllTokens("wasp"))
+	user, err := userManager.User("wasp")
+	require.NoError(t, err)
+	require.Empty(t, user.RevokedTokens)
+
+	// the tokens issued until the end of the current second are revoked,
+	// since the issue time of tokens has a precision of seconds
+	require.True(t, user.TokensNotBefore.After(time.Now()))
+	require.Zero(t, user.TokensNotBefore.Nanosecond())
+	require.True(t, userManager.IsTokenRevoked("wasp", "any", time.Now().Truncate(time.Second)))
+
+	// so new tokens are issued at the start of the next second
+	issueTime := user.TokenIssueTime()
+	require.Equal(t, user.TokensNotBefore, issueTime)
+	require.False(t, userManager.IsTokenRevoked("wasp", "new", issueTime))
+
+	require.Error(t, userManager.RevokeAllTokens("unknown"))
+	require.True(t, userManager.IsTokenRevoked("unknown", "any", time.Now()))
+}
+
+func TestUseToken(t *testing.T) {
+	storeCalls := 0
+	userManager := users.NewUserManager(func([]*users.User) error {
+		storeCalls++
+		return nil
+	})
+	require.NoError(t, userManager.AddUser(&users.User{Name: "wasp"}))
+	userManager.EnableStoreOnChange()
+	now := time.Now()
+
+	require.NoError(t, userManager.UseToken("wasp", "refresh", now, now.Add(time.Hour)))
+	require.ErrorIs(t, userManager.UseToken("wasp", "refresh", now, now.Add(time.Hour)), users.ErrTokenRevoked)
+	require.True(t, userManager.IsTokenRevoked("wasp", "refresh", now))
+	require.NoError(t, userManager.UseToken("wasp", "other", now, now.Add(time.Hour)))
+
+	// revoked tokens can't be used
+	require.NoError(t, userManager.RevokeToken("wasp", "revoked", now.Add(time.Hour)))
+	require.ErrorIs(t, userManager.UseToken("wasp", "revoked", now, now.Add(time.Hour)), users.ErrTokenRevoked)
+
+	require.ErrorIs(t, userManager.UseToken("unknown", "refresh", now, now.Add(time.Hour)), users.ErrTokenRevoked)
+	require.Error(t, userManager.UseToken("wasp", "", now, now.Add(time.Hour)))
+
+	// the used tokens are not stored in the user config, only the revoked one is
+	require.Equal(t, 1, storeCalls)
+	user, err := userManager.User("wasp")
+	require.NoError(t, err)
+	require.Len(t, user.RevokedTokens, 1)
+}
+
+func TestUseTokenAfterRestart(t *testing.T) {
+	userManager := newTestUserManager(t)
+	user, err := userManager.User("wasp")
+	require.NoError(t, err)
+
+	// the user manager of the restarted node doesn't know the tokens used before
+	restarted := users.NewUserManager(func([]*users.User) error { return nil })
+	require.NoError(t, restarted.AddUser(user))
+	issuedBefore := time.Now().Add(-time.Second)
+	require.ErrorIs(t, restarted.UseToken("wasp", "old", issuedBefore, issuedBefore.Add(time.Hour)), users.ErrTokenRevoked)
+
+	issuedAfter := user.TokenIssueTime()
+	require.NoError(t, restarted.UseToken("wasp", "new", issuedAfter, issuedAfter.Add(time.Hour)))
+}
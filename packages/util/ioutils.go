@@ -1,7 +1,10 @@
 package util
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"path"
 
@@ -34,3 +37,24 @@ func CreateDirectoryForFilePath(filePath string, perm os.FileMode) error {
 
 	return nil
 }
+
+// PeekRequestBody reads up to limit+1 bytes of the request body without
+// consuming them, so more than limit bytes are returned if the body is larger.
+func PeekRequestBody(req *http.Request, limit int) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(req.Body, int64(limit)+1))
+	// the handler still gets the whole body, the part that was read first
+	req.Body = readCloser{
+		Reader: io.MultiReader(bytes.NewReader(body), req.Body),
+		Closer: req.Body,
+	}
+	return body, err
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
@@ -7,7 +7,13 @@ import (
 )
 
 func baseTokensKey(accountKey kv.Key) kv.Key {
-	return prefixBaseTokens + accountKey
+	return PrefixBaseTokens + accountKey
+}
+
+// BaseTokensKey returns the key in the accounts partition where the base
+// tokens balance of the account is stored.
+func BaseTokensKey(agentID isc.AgentID, chainID isc.ChainID) kv.Key {
+	return baseTokensKey(accountKey(agentID, chainID))
 }
 
 func getBaseTokens(state kv.KVStoreReader, accountKey kv.Key) uint64 {
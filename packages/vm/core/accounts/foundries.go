@@ -9,7 +9,7 @@ import (
 )
 
 func newFoundriesArray(state kv.KVStore) *collections.Array {
-	return collections.NewArray(state, keyNewFoundries)
+	return collections.NewArray(state, KeyNewFoundries)
 }
 
 func accountFoundriesMap(state kv.KVStore, agentID isc.AgentID) *collections.Map {
@@ -21,11 +21,11 @@ func accountFoundriesMapR(state kv.KVStoreReader, agentID isc.AgentID) *collecti
 }
 
 func AllFoundriesMap(state kv.KVStore) *collections.Map {
-	return collections.NewMap(state, keyFoundryOutputRecords)
+	return collections.NewMap(state, KeyFoundryOutputRecords)
 }
 
 func allFoundriesMapR(state kv.KVStoreReader) *collections.ImmutableMap {
-	return collections.NewMapReadOnly(state, keyFoundryOutputRecords)
+	return collections.NewMapReadOnly(state, KeyFoundryOutputRecords)
 }
 
 // SaveFoundryOutput stores foundry output into the map of all foundry outputs (compressed form)
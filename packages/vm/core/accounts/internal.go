@@ -26,13 +26,13 @@ var (
 )
 
 const (
-	// keyAllAccounts stores a map of <agentID> => true
+	// KeyAllAccounts stores a map of <agentID> => true
 	// where sum = baseTokens + native tokens + nfts
-	keyAllAccounts = "a"
+	KeyAllAccounts = "a"
 
-	// prefixBaseTokens | <accountID> stores the amount of base tokens (big.Int)
-	prefixBaseTokens = "b"
-	// prefixBaseTokens | <accountID> stores a map of <nativeTokenID> => big.Int
+	// PrefixBaseTokens | <accountID> stores the amount of base tokens (big.Int)
+	PrefixBaseTokens = "b"
+	// PrefixBaseTokens | <accountID> stores a map of <nativeTokenID> => big.Int
 	PrefixNativeTokens = "t"
 
 	// l2TotalsAccount is the special <accountID> storing the total fungible tokens
@@ -43,34 +43,34 @@ const (
 	PrefixNFTs = "n"
 	// PrefixNFTsByCollection | <agentID> | <collectionID> stores a map of <nftID> => true
 	PrefixNFTsByCollection = "c"
-	// prefixNewlyMintedNFTs stores a map of <position in minted list> => <newly minted NFT> to be updated when the outputID is known
-	prefixNewlyMintedNFTs = "N"
-	// prefixMintIDMap stores a map of <internal NFTID> => <NFTID> it is updated when the NFTID of newly minted nfts is known
-	prefixMintIDMap = "M"
+	// PrefixNewlyMintedNFTs stores a map of <position in minted list> => <newly minted NFT> to be updated when the outputID is known
+	PrefixNewlyMintedNFTs = "N"
+	// PrefixMintIDMap stores a map of <internal NFTID> => <NFTID> it is updated when the NFTID of newly minted nfts is known
+	PrefixMintIDMap = "M"
 	// PrefixFoundries + <agentID> stores a map of <foundrySN> (uint32) => true
 	PrefixFoundries = "f"
 
 	// noCollection is the special <collectionID> used for storing NFTs that do not belong in a collection
 	noCollection = "-"
 
-	// keyNonce stores a map of <agentID> => nonce (uint64)
-	keyNonce = "m"
-
-	// keyNativeTokenOutputMap stores a map of <nativeTokenID> => nativeTokenOutputRec
-	keyNativeTokenOutputMap = "TO"
-	// keyFoundryOutputRecords stores a map of <foundrySN> => foundryOutputRec
-	keyFoundryOutputRecords = "FO"
-	// keyNFTOutputRecords stores a map of <NFTID> => NFTOutputRec
-	keyNFTOutputRecords = "NO"
-	// keyNFTOwner stores a map of <NFTID> => isc.AgentID
-	keyNFTOwner = "NW"
-
-	// keyNewNativeTokens stores an array of <nativeTokenID>, containing the newly created native tokens that need filling out the OutputID
-	keyNewNativeTokens = "TN"
-	// keyNewFoundries stores an array of <foundrySN>, containing the newly created foundries that need filling out the OutputID
-	keyNewFoundries = "FN"
-	// keyNewNFTs stores an array of <NFTID>, containing the newly created NFTs that need filling out the OutputID
-	keyNewNFTs = "NN"
+	// KeyNonce stores a map of <agentID> => nonce (uint64)
+	KeyNonce = "m"
+
+	// KeyNativeTokenOutputMap stores a map of <nativeTokenID> => nativeTokenOutputRec
+	KeyNativeTokenOutputMap = "TO"
+	// KeyFoundryOutputRecords stores a map of <foundrySN> => foundryOutputRec
+	KeyFoundryOutputRecords = "FO"
+	// KeyNFTOutputRecords stores a map of <NFTID> => NFTOutputRec
+	KeyNFTOutputRecords = "NO"
+	// KeyNFTOwner stores a map of <NFTID> => isc.AgentID
+	KeyNFTOwner = "NW"
+
+	// KeyNewNativeTokens stores an array of <nativeTokenID>, containing the newly created native tokens that need filling out the OutputID
+	KeyNewNativeTokens = "TN"
+	// KeyNewFoundries stores an array of <foundrySN>, containing the newly created foundries that need filling out the OutputID
+	KeyNewFoundries = "FN"
+	// KeyNewNFTs stores an array of <NFTID>, containing the newly created NFTs that need filling out the OutputID
+	KeyNewNFTs = "NN"
 )
 
 func accountKey(agentID isc.AgentID, chainID isc.ChainID) kv.Key {
@@ -82,11 +82,11 @@ func accountKey(agentID isc.AgentID, chainID isc.ChainID) kv.Key {
 }
 
 func allAccountsMap(state kv.KVStore) *collections.Map {
-	return collections.NewMap(state, keyAllAccounts)
+	return collections.NewMap(state, KeyAllAccounts)
 }
 
 func allAccountsMapR(state kv.KVStoreReader) *collections.ImmutableMap {
-	return collections.NewMapReadOnly(state, keyAllAccounts)
+	return collections.NewMapReadOnly(state, KeyAllAccounts)
 }
 
 func accountExists(state kv.KVStoreReader, agentID isc.AgentID, chainID isc.ChainID) bool {
@@ -183,3 +183,31 @@ func UpdateLatestOutputID(state kv.KVStore, anchorTxID iotago.TransactionID, blo
 	updateNFTOutputIDs(state, anchorTxID)
 	updateNewlyMintedNFTOutputIDs(state, anchorTxID, blockIndex)
 }
+
+// ChainOutputs returns the internal outputs of the chain referenced by the
+// accounting: the native token outputs, the foundries and the NFTs. The
+// outputs created in the latest block have an empty transaction ID in their
+// output IDs, it is filled in by UpdateLatestOutputID in the next block.
+func ChainOutputs(state kv.KVStoreReader, chainID isc.ChainID) iotago.OutputSet {
+	ret := make(iotago.OutputSet)
+	nativeTokenOutputMapR(state).IterateKeys(func(key []byte) bool {
+		nativeTokenID, err := isc.NativeTokenIDFromBytes(key)
+		if err != nil {
+			panic(err)
+		}
+		out, outputID := GetNativeTokenOutput(state, nativeTokenID, chainID)
+		ret[outputID] = out
+		return true
+	})
+	allFoundriesMapR(state).IterateKeys(func(key []byte) bool {
+		out, outputID := GetFoundryOutput(state, codec.MustDecodeUint32(key), chainID)
+		ret[outputID] = out
+		return true
+	})
+	nftOutputMapR(state).Iterate(func(_ []byte, data []byte) bool {
+		rec := mustNFTOutputRecFromBytes(data)
+		ret[rec.OutputID] = rec.Output
+		return true
+	})
+	return ret
+}
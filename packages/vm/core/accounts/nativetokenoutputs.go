@@ -9,15 +9,15 @@ import (
 )
 
 func newNativeTokensArray(state kv.KVStore) *collections.Array {
-	return collections.NewArray(state, keyNewNativeTokens)
+	return collections.NewArray(state, KeyNewNativeTokens)
 }
 
 func NativeTokenOutputMap(state kv.KVStore) *collections.Map {
-	return collections.NewMap(state, keyNativeTokenOutputMap)
+	return collections.NewMap(state, KeyNativeTokenOutputMap)
 }
 
 func nativeTokenOutputMapR(state kv.KVStoreReader) *collections.ImmutableMap {
-	return collections.NewMapReadOnly(state, keyNativeTokenOutputMap)
+	return collections.NewMapReadOnly(state, KeyNativeTokenOutputMap)
 }
 
 // SaveNativeTokenOutput map nativeTokenID -> foundryRec
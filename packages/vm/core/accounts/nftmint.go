@@ -56,15 +56,15 @@ func mintedNFTRecordFromBytes(data []byte) *mintedNFTRecord {
 }
 
 func newlyMintedNFTsMap(state kv.KVStore) *collections.Map {
-	return collections.NewMap(state, prefixNewlyMintedNFTs)
+	return collections.NewMap(state, PrefixNewlyMintedNFTs)
 }
 
 func mintIDMap(state kv.KVStore) *collections.Map {
-	return collections.NewMap(state, prefixMintIDMap)
+	return collections.NewMap(state, PrefixMintIDMap)
 }
 
 func mintIDMapR(state kv.KVStoreReader) *collections.ImmutableMap {
-	return collections.NewMapReadOnly(state, prefixMintIDMap)
+	return collections.NewMapReadOnly(state, PrefixMintIDMap)
 }
 
 var (
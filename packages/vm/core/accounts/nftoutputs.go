@@ -7,15 +7,15 @@ import (
 )
 
 func newNFTsArray(state kv.KVStore) *collections.Array {
-	return collections.NewArray(state, keyNewNFTs)
+	return collections.NewArray(state, KeyNewNFTs)
 }
 
 func NFTOutputMap(state kv.KVStore) *collections.Map {
-	return collections.NewMap(state, keyNFTOutputRecords)
+	return collections.NewMap(state, KeyNFTOutputRecords)
 }
 
 func nftOutputMapR(state kv.KVStoreReader) *collections.ImmutableMap {
-	return collections.NewMapReadOnly(state, keyNFTOutputRecords)
+	return collections.NewMapReadOnly(state, KeyNFTOutputRecords)
 }
 
 func SaveNFTOutput(state kv.KVStore, out *iotago.NFTOutput, outputIndex uint16) {
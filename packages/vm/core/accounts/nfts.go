@@ -32,11 +32,11 @@ func accountToNFTsMap(state kv.KVStore, agentID isc.AgentID) *collections.Map {
 }
 
 func NFTToOwnerMap(state kv.KVStore) *collections.Map {
-	return collections.NewMap(state, keyNFTOwner)
+	return collections.NewMap(state, KeyNFTOwner)
 }
 
 func NFTToOwnerMapR(state kv.KVStoreReader) *collections.ImmutableMap {
-	return collections.NewMapReadOnly(state, keyNFTOwner)
+	return collections.NewMapReadOnly(state, KeyNFTOwner)
 }
 
 func nftCollectionKey(issuer iotago.Address) kv.Key {
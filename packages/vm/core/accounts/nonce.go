@@ -9,7 +9,7 @@ import (
 )
 
 func nonceKey(callerAgentID isc.AgentID, chainID isc.ChainID) kv.Key {
-	return keyNonce + accountKey(callerAgentID, chainID)
+	return KeyNonce + accountKey(callerAgentID, chainID)
 }
 
 // Nonce returns the "total request count" for an account (it's the accountNonce that is expected in the next request)
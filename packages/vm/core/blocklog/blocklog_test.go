@@ -44,7 +44,7 @@ func createRequestLookupKeys(blocks uint32, requests uint16) []byte {
 }
 
 func validatePrunedRequestIndexLookupBlock(t *testing.T, partition kv.KVStore, contract kv.Key, prunedBlockIndex uint32) {
-	requestLookup := collections.NewMap(partition, prefixRequestLookupIndex)
+	requestLookup := collections.NewMap(partition, PrefixRequestLookupIndex)
 	requestKeys, err := RequestLookupKeyListFromBytes(requestLookup.GetAt([]byte(contract)))
 	require.NoError(t, err)
 
@@ -63,7 +63,7 @@ func TestPruneRequestIndexLookupTable(t *testing.T) {
 
 	d := dict.Dict{}
 
-	requestIndexLUT := collections.NewMap(d, prefixRequestLookupIndex)
+	requestIndexLUT := collections.NewMap(d, PrefixRequestLookupIndex)
 	requestIndexLUT.SetAt(requestIDDigest0[:], createRequestLookupKeys(maxBlocks, requestsToCreate))
 	requestIndexLUT.SetAt(requestIDDigest1[:], createRequestLookupKeys(maxBlocks, requestsToCreate))
 
@@ -140,7 +140,7 @@ func TestGetEventsInternal(t *testing.T) {
 
 	registry := collections.NewArray(d, PrefixBlockRegistry)
 
-	eventMap := collections.NewMap(d, prefixRequestEvents)
+	eventMap := collections.NewMap(d, PrefixRequestEvents)
 	createEventLookupKeys(registry, eventMap, contractID, maxBlocks, maxRequests, maxEventsPerRequest)
 
 	events := getSmartContractEventsInternal(d, contractID, blockFrom, blockTo)
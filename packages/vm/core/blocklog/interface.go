@@ -47,20 +47,20 @@ const (
 	// Map of request.ID().LookupDigest() => []RequestLookupKey (pruned)
 	//   LookupDigest = reqID[:6] | outputIndex
 	//   RequestLookupKey = blockIndex | requestIndex
-	prefixRequestLookupIndex = "b"
+	PrefixRequestLookupIndex = "b"
 
 	// Map of RequestLookupKey => RequestReceipt (pruned)
 	//   RequestLookupKey = blockIndex | requestIndex
-	prefixRequestReceipts = "c"
+	PrefixRequestReceipts = "c"
 
 	// Map of EventLookupKey => event (pruned)
 	//   EventLookupKey = blockIndex | requestIndex | eventIndex
-	prefixRequestEvents = "d"
+	PrefixRequestEvents = "d"
 
 	// Map of requestID => unprocessableRequestRecord
-	prefixUnprocessableRequests = "u"
+	PrefixUnprocessableRequests = "u"
 
 	// Array of requestID.
 	// Temporary list of unprocessable requests that need updating the outputID field
-	prefixNewUnprocessableRequests = "U"
+	PrefixNewUnprocessableRequests = "U"
 )
@@ -51,15 +51,15 @@ func (rec *unprocessableRequestRecord) Write(w io.Writer) error {
 }
 
 func newUnprocessableRequestsArray(state kv.KVStore) *collections.Array {
-	return collections.NewArray(state, prefixNewUnprocessableRequests)
+	return collections.NewArray(state, PrefixNewUnprocessableRequests)
 }
 
 func unprocessableMap(state kv.KVStore) *collections.Map {
-	return collections.NewMap(state, prefixUnprocessableRequests)
+	return collections.NewMap(state, PrefixUnprocessableRequests)
 }
 
 func unprocessableMapR(state kv.KVStoreReader) *collections.ImmutableMap {
-	return collections.NewMapReadOnly(state, prefixUnprocessableRequests)
+	return collections.NewMapReadOnly(state, PrefixUnprocessableRequests)
 }
 
 // save request reference / address of the sender
@@ -156,7 +156,7 @@ func UnprocessableRequestsAddedInBlock(block state.Block) ([]isc.Request, error)
 
 func HasUnprocessableRequestBeenRemovedInBlock(block state.Block, requestID isc.RequestID) bool {
 	keyBytes := Contract.Hname().Bytes()
-	keyBytes = append(keyBytes, collections.MapElemKey(prefixUnprocessableRequests, requestID.Bytes())...)
+	keyBytes = append(keyBytes, collections.MapElemKey(PrefixUnprocessableRequests, requestID.Bytes())...)
 	_, wasRemoved := block.Mutations().Dels[kv.Key(keyBytes)]
 	return wasRemoved
 }
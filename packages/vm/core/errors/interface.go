@@ -19,5 +19,5 @@ const (
 )
 
 const (
-	prefixErrorTemplateMap = "a"
+	PrefixErrorTemplateMap = "a"
 )
@@ -11,7 +11,7 @@ import (
 )
 
 func errorTemplateKey(contractID isc.Hname) string {
-	return prefixErrorTemplateMap + string(contractID.Bytes())
+	return PrefixErrorTemplateMap + string(contractID.Bytes())
 }
 
 // StateErrorCollectionWriter implements ErrorCollection. Is used for contract internal errors.
@@ -123,6 +123,12 @@ func (bc *BlockchainDB) GetPendingBlockNumber() uint64 {
 	return bc.GetNumber() + 1
 }
 
+// GetPendingTxCount returns the amount of txs added to the pending block so
+// far, which is also the index in the block of the next tx.
+func (bc *BlockchainDB) GetPendingTxCount() uint64 {
+	return uint64(bc.getTxArray(bc.GetPendingBlockNumber()).Len())
+}
+
 func (bc *BlockchainDB) GetPendingHeader(timestamp uint64) *types.Header {
 	return &types.Header{
 		Difficulty: &big.Int{},
@@ -21,6 +21,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	lru "github.com/hashicorp/golang-lru/v2"
 
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/subrealm"
@@ -105,6 +106,12 @@ func StateDBSubrealmR(store kv.KVStoreReader) kv.KVStoreReader {
 	return subrealm.NewReadOnly(store, keyStateDB)
 }
 
+// StateDBKey returns the key in the emulator state where the given StateDB
+// key is stored.
+func StateDBKey(key kv.Key) kv.Key {
+	return keyStateDB + key
+}
+
 func BlockchainDBSubrealm(store kv.KVStore) kv.KVStore {
 	return subrealm.New(store, keyBlockchainDB)
 }
@@ -200,6 +207,18 @@ func coreMsgFromCallMsg(call ethereum.CallMsg, gasEstimateMode bool, statedb *St
 
 // CallContract executes a contract call, without committing changes to the state
 func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool) (*core.ExecutionResult, error) {
+	return e.TraceCall(call, gasEstimateMode, nil, nil)
+}
+
+// TraceCall executes a contract call like CallContract, applying the given
+// state overrides beforehand and capturing the execution with the given
+// tracer. All state changes, including the overrides, are discarded.
+func (e *EVMEmulator) TraceCall(
+	call ethereum.CallMsg,
+	gasEstimateMode bool,
+	stateOverride evmtypes.StateOverride,
+	tracer tracers.Tracer,
+) (*core.ExecutionResult, error) {
 	// Ensure message is initialized properly.
 	if call.Gas == 0 {
 		call.Gas = e.ctx.GasLimits().Call
@@ -216,7 +235,10 @@ func (e *EVMEmulator) CallContract(call ethereum.CallMsg, gasEstimateMode bool)
 	i := statedb.Snapshot()
 	defer statedb.RevertToSnapshot(i)
 
-	return e.applyMessage(coreMsgFromCallMsg(call, gasEstimateMode, statedb), statedb, pendingHeader, nil)
+	if err := statedb.ApplyStateOverride(stateOverride); err != nil {
+		return nil, err
+	}
+	return e.applyMessage(coreMsgFromCallMsg(call, gasEstimateMode, statedb), statedb, pendingHeader, tracer)
 }
 
 func (e *EVMEmulator) applyMessage(
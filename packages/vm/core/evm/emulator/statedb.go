@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"golang.org/x/exp/slices"
 
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/util"
@@ -30,15 +31,19 @@ func accountKey(prefix kv.Key, addr common.Address) kv.Key {
 	return prefix + kv.Key(addr.Bytes())
 }
 
-func accountNonceKey(addr common.Address) kv.Key {
+// AccountNonceKey returns the key of the account nonce in the StateDB subrealm.
+func AccountNonceKey(addr common.Address) kv.Key {
 	return accountKey(keyAccountNonce, addr)
 }
 
-func accountCodeKey(addr common.Address) kv.Key {
+// AccountCodeKey returns the key of the account code in the StateDB subrealm.
+func AccountCodeKey(addr common.Address) kv.Key {
 	return accountKey(keyAccountCode, addr)
 }
 
-func accountStateKey(addr common.Address, hash common.Hash) kv.Key {
+// AccountStateKey returns the key of a storage slot of the account in the
+// StateDB subrealm.
+func AccountStateKey(addr common.Address, hash common.Hash) kv.Key {
 	return accountKey(keyAccountState, addr) + kv.Key(hash[:])
 }
 
@@ -99,7 +104,7 @@ func (s *StateDB) GetBalance(addr common.Address) *big.Int {
 }
 
 func GetNonce(s kv.KVStoreReader, addr common.Address) uint64 {
-	return codec.MustDecodeUint64(s.Get(accountNonceKey(addr)), 0)
+	return codec.MustDecodeUint64(s.Get(AccountNonceKey(addr)), 0)
 }
 
 func (s *StateDB) GetNonce(addr common.Address) uint64 {
@@ -115,7 +120,7 @@ func (s *StateDB) IncNonce(addr common.Address) {
 }
 
 func SetNonce(kv kv.KVStore, addr common.Address, n uint64) {
-	kv.Set(accountNonceKey(addr), codec.EncodeUint64(n))
+	kv.Set(AccountNonceKey(addr), codec.EncodeUint64(n))
 }
 
 func (s *StateDB) SetNonce(addr common.Address, n uint64) {
@@ -128,7 +133,7 @@ func (s *StateDB) GetCodeHash(addr common.Address) common.Hash {
 }
 
 func GetCode(s kv.KVStoreReader, addr common.Address) []byte {
-	return s.Get(accountCodeKey(addr))
+	return s.Get(AccountCodeKey(addr))
 }
 
 func (s *StateDB) GetCode(addr common.Address) []byte {
@@ -137,9 +142,9 @@ func (s *StateDB) GetCode(addr common.Address) []byte {
 
 func SetCode(kv kv.KVStore, addr common.Address, code []byte) {
 	if code == nil {
-		kv.Del(accountCodeKey(addr))
+		kv.Del(AccountCodeKey(addr))
 	} else {
-		kv.Set(accountCodeKey(addr), code)
+		kv.Set(AccountCodeKey(addr), code)
 	}
 }
 
@@ -172,7 +177,7 @@ func (s *StateDB) GetCommittedState(addr common.Address, key common.Hash) common
 }
 
 func GetState(s kv.KVStoreReader, addr common.Address, key common.Hash) common.Hash {
-	return common.BytesToHash(s.Get(accountStateKey(addr, key)))
+	return common.BytesToHash(s.Get(AccountStateKey(addr, key)))
 }
 
 func (s *StateDB) GetState(addr common.Address, key common.Hash) common.Hash {
@@ -180,21 +185,59 @@ func (s *StateDB) GetState(addr common.Address, key common.Hash) common.Hash {
 }
 
 func SetState(kv kv.KVStore, addr common.Address, key, value common.Hash) {
-	kv.Set(accountStateKey(addr, key), value.Bytes())
+	kv.Set(AccountStateKey(addr, key), value.Bytes())
 }
 
 func (s *StateDB) SetState(addr common.Address, key, value common.Hash) {
 	SetState(s.kv, addr, key, value)
 }
 
-func (s *StateDB) Suicide(addr common.Address) bool {
-	if !s.Exist(addr) {
-		return false
+// ApplyStateOverride overrides the fields of the specified accounts.
+func (s *StateDB) ApplyStateOverride(override evmtypes.StateOverride) error {
+	for addr, account := range override {
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("account %s has both 'state' and 'stateDiff'", addr.Hex())
+		}
+		if !s.Exist(addr) {
+			s.CreateAccount(addr)
+		}
+		if account.Nonce != nil {
+			s.SetNonce(addr, uint64(*account.Nonce))
+		}
+		if account.Code != nil {
+			s.SetCode(addr, *account.Code)
+		}
+		if account.Balance != nil {
+			s.setBalance(addr, (*big.Int)(*account.Balance))
+		}
+		if account.State != nil {
+			s.clearState(addr)
+			for key, value := range *account.State {
+				s.SetState(addr, key, value)
+			}
+		}
+		if account.StateDiff != nil {
+			for key, value := range *account.StateDiff {
+				s.SetState(addr, key, value)
+			}
+		}
 	}
+	return nil
+}
 
-	s.kv.Del(accountNonceKey(addr))
-	s.kv.Del(accountCodeKey(addr))
+func (s *StateDB) setBalance(addr common.Address, amount *big.Int) {
+	if amount.Sign() == -1 {
+		panic("unexpected negative amount")
+	}
+	diff := new(big.Int).Sub(amount, s.GetBalance(addr))
+	if diff.Sign() == -1 {
+		s.SubBalance(addr, diff.Neg(diff))
+	} else {
+		s.AddBalance(addr, diff)
+	}
+}
 
+func (s *StateDB) clearState(addr common.Address) {
 	keys := make([]kv.Key, 0)
 	s.kv.IterateKeys(accountKey(keyAccountState, addr), func(key kv.Key) bool {
 		keys = append(keys, key)
@@ -203,6 +246,17 @@ func (s *StateDB) Suicide(addr common.Address) bool {
 	for _, k := range keys {
 		s.kv.Del(k)
 	}
+}
+
+func (s *StateDB) Suicide(addr common.Address) bool {
+	if !s.Exist(addr) {
+		return false
+	}
+
+	s.kv.Del(AccountNonceKey(addr))
+	s.kv.Del(AccountCodeKey(addr))
+
+	s.clearState(addr)
 
 	// for some reason the EVM engine calls AddBalance to the beneficiary address,
 	// but not SubBalance for the suicided address.
@@ -220,7 +274,7 @@ func (s *StateDB) HasSuicided(addr common.Address) bool {
 // Exist reports whether the given account exists in state.
 // Notably this should also return true for suicided accounts.
 func (s *StateDB) Exist(addr common.Address) bool {
-	return s.kv.Has(accountNonceKey(addr))
+	return s.kv.Has(AccountNonceKey(addr))
 }
 
 // Empty returns whether the given account is empty. Empty
@@ -112,7 +112,7 @@ func applyTransaction(ctx isc.Sandbox) dict.Dict {
 	}
 
 	// Execute the tx in the emulator.
-	receipt, result, err := emu.SendTransaction(tx, getTracer(ctx), false)
+	receipt, result, err := emu.SendTransaction(tx, getTracer(ctx, emu), false)
 
 	// Any gas burned by the EVM is converted to ISC gas units and burned as
 	// ISC gas.
@@ -407,7 +407,11 @@ func callContract(ctx isc.Sandbox) dict.Dict {
 	ctx.RequireCaller(isc.NewEthereumAddressAgentID(ctx.ChainID(), callMsg.From))
 
 	emu := createEmulator(ctx)
-	res, err := emu.CallContract(callMsg, ctx.Gas().EstimateGasMode())
+	var stateOverride evmtypes.StateOverride
+	if tracer := ctx.EVMTracer(); tracer != nil {
+		stateOverride = tracer.StateOverride
+	}
+	res, err := emu.TraceCall(callMsg, ctx.Gas().EstimateGasMode(), stateOverride, getTracer(ctx, emu))
 	ctx.RequireNoError(err)
 	ctx.RequireNoError(tryGetRevertError(res))
 
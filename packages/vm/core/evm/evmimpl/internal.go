@@ -30,12 +30,14 @@ func MintBlock(evmPartition kv.KVStore, chainInfo *isc.ChainInfo, blockTimestamp
 	createBlockchainDB(evmPartition, chainInfo).MintBlock(timestamp(blockTimestamp))
 }
 
-func getTracer(ctx isc.Sandbox) tracers.Tracer {
+// getTracer returns the tracer for the EVM tx about to be executed, if it is
+// being traced.
+func getTracer(ctx isc.Sandbox, emu *emulator.EVMEmulator) tracers.Tracer {
 	tracer := ctx.EVMTracer()
 	if tracer == nil {
 		return nil
 	}
-	return tracer.Tracer
+	return tracer.TracerForTx(emu.BlockchainDB().GetPendingTxCount())
 }
 
 func createEmulator(ctx isc.Sandbox) *emulator.EVMEmulator {
@@ -15,6 +15,8 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	vmerrors "github.com/iotaledger/wasp/packages/vm/core/errors"
 	"github.com/iotaledger/wasp/packages/vm/core/errors/coreerrors"
 	"github.com/iotaledger/wasp/packages/vm/core/evm/iscmagic"
 )
@@ -136,3 +138,60 @@ func (h *magicContractHandler) GetNativeTokenScheme(foundrySN uint32) iotago.Sim
 	}
 	return *s
 }
+
+// handler for ISCSandbox::isRequestProcessed
+func (h *magicContractHandler) IsRequestProcessed(requestID iscmagic.ISCRequestID) bool {
+	r := h.callView(blocklog.Contract.Hname(), blocklog.ViewIsRequestProcessed.Hname(), dict.Dict{
+		blocklog.ParamRequestID: codec.EncodeRequestID(h.unwrapRequestID(requestID)),
+	})
+	return codec.MustDecodeBool(r.Get(blocklog.ParamRequestProcessed))
+}
+
+var errRequestReceiptNotFound = coreerrors.Register("request receipt not found").Create()
+
+// handler for ISCSandbox::getRequestReceipt
+func (h *magicContractHandler) GetRequestReceipt(requestID iscmagic.ISCRequestID) iscmagic.ISCRequestReceipt {
+	r := h.callView(blocklog.Contract.Hname(), blocklog.ViewGetRequestReceipt.Hname(), dict.Dict{
+		blocklog.ParamRequestID: codec.EncodeRequestID(h.unwrapRequestID(requestID)),
+	})
+	if r.IsEmpty() {
+		panic(errRequestReceiptNotFound)
+	}
+	rec, err := blocklog.RequestReceiptFromBytes(
+		r.Get(blocklog.ParamRequestRecord),
+		codec.MustDecodeUint32(r.Get(blocklog.ParamBlockIndex)),
+		codec.MustDecodeUint16(r.Get(blocklog.ParamRequestIndex)),
+	)
+	h.ctx.RequireNoError(err)
+	errorMessage := ""
+	if rec.Error != nil {
+		vmError, err := vmerrors.Resolve(rec.Error, func(contractName string, funcName string, params dict.Dict) (dict.Dict, error) {
+			return h.callView(isc.Hn(contractName), isc.Hn(funcName), params), nil
+		})
+		h.ctx.RequireNoError(err)
+		errorMessage = vmError.Error()
+	}
+	return iscmagic.WrapISCRequestReceipt(rec, errorMessage)
+}
+
+// handler for ISCSandbox::getLatestBlockIndex
+func (h *magicContractHandler) GetLatestBlockIndex() uint32 {
+	r := h.callView(blocklog.Contract.Hname(), blocklog.ViewGetBlockInfo.Hname(), nil)
+	return codec.MustDecodeUint32(r.Get(blocklog.ParamBlockIndex))
+}
+
+// handler for ISCSandbox::getBlockInfo
+func (h *magicContractHandler) GetBlockInfo(blockIndex uint32) iscmagic.ISCBlockInfo {
+	r := h.callView(blocklog.Contract.Hname(), blocklog.ViewGetBlockInfo.Hname(), dict.Dict{
+		blocklog.ParamBlockIndex: codec.EncodeUint32(blockIndex),
+	})
+	blockInfo, err := blocklog.BlockInfoFromBytes(r.Get(blocklog.ParamBlockInfo))
+	h.ctx.RequireNoError(err)
+	return iscmagic.WrapISCBlockInfo(blockInfo)
+}
+
+func (h *magicContractHandler) unwrapRequestID(requestID iscmagic.ISCRequestID) isc.RequestID {
+	reqID, err := requestID.Unwrap()
+	h.ctx.RequireNoError(err)
+	return reqID
+}
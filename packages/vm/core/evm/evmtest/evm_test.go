@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/eth/tracers"
@@ -28,6 +29,7 @@ import (
 	"github.com/iotaledger/wasp/contracts/native/inccounter"
 	"github.com/iotaledger/wasp/packages/evm/evmerrors"
 	"github.com/iotaledger/wasp/packages/evm/evmtest"
+	"github.com/iotaledger/wasp/packages/evm/evmtypes"
 	"github.com/iotaledger/wasp/packages/evm/evmutil"
 	"github.com/iotaledger/wasp/packages/evm/jsonrpc"
 	"github.com/iotaledger/wasp/packages/hashing"
@@ -442,6 +444,79 @@ func TestISCTimestamp(t *testing.T) {
 	)
 }
 
+func TestISCRequestReceipt(t *testing.T) {
+	env := initEVM(t)
+	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	sandbox := env.ISCMagicSandbox(ethKey)
+
+	// a successful request
+	env.soloChain.MustDepositBaseTokensToL2(isc.Million, nil)
+	okReceipt := env.soloChain.LastReceipt()
+	okReqID := okReceipt.DeserializedRequest().ID()
+
+	var processed bool
+	require.NoError(t, sandbox.callView("isRequestProcessed", []interface{}{iscmagic.WrapISCRequestID(okReqID)}, &processed))
+	require.True(t, processed)
+
+	var ret struct{ Receipt iscmagic.ISCRequestReceipt }
+	require.NoError(t, sandbox.callView("getRequestReceipt", []interface{}{iscmagic.WrapISCRequestID(okReqID)}, &ret))
+	rec := ret.Receipt
+	require.Equal(t, okReqID, rec.RequestID.MustUnwrap())
+	require.Equal(t, okReceipt.BlockIndex, rec.BlockIndex)
+	require.Equal(t, okReceipt.RequestIndex, rec.RequestIndex)
+	require.Equal(t, okReceipt.GasBurned, rec.GasBurned)
+	require.Equal(t, okReceipt.GasFeeCharged, rec.GasFeeCharged)
+	require.False(t, rec.Failed)
+	require.Empty(t, rec.ErrorMessage)
+
+	// a failed request
+	_, err := env.soloChain.PostRequestSync(
+		solo.NewCallParams(accounts.Contract.Name, accounts.FuncWithdraw.Name).
+			AddAllowanceBaseTokens(1000*isc.Million).
+			WithMaxAffordableGasBudget(),
+		nil,
+	)
+	require.Error(t, err)
+	failedReqID := env.soloChain.LastReceipt().DeserializedRequest().ID()
+	require.NoError(t, sandbox.callView("getRequestReceipt", []interface{}{iscmagic.WrapISCRequestID(failedReqID)}, &ret))
+	rec = ret.Receipt
+	require.True(t, rec.Failed)
+	require.Equal(t, env.soloChain.LastReceipt().ResolvedError, rec.ErrorMessage)
+
+	// a request that was never processed
+	unknownReqID := isc.NewRequestID(iotago.TransactionID{1, 2, 3}, 0)
+	require.NoError(t, sandbox.callView("isRequestProcessed", []interface{}{iscmagic.WrapISCRequestID(unknownReqID)}, &processed))
+	require.False(t, processed)
+	err = sandbox.callView("getRequestReceipt", []interface{}{iscmagic.WrapISCRequestID(unknownReqID)}, &ret)
+	require.ErrorContains(t, err, "request receipt not found")
+}
+
+func TestISCBlockInfo(t *testing.T) {
+	env := initEVM(t)
+	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	sandbox := env.ISCMagicSandbox(ethKey)
+
+	var latest uint32
+	require.NoError(t, sandbox.callView("getLatestBlockIndex", nil, &latest))
+	require.Equal(t, env.soloChain.LatestBlockIndex(), latest)
+
+	expected, err := env.soloChain.GetBlockInfo(latest)
+	require.NoError(t, err)
+	var ret struct{ BlockInfo iscmagic.ISCBlockInfo }
+	require.NoError(t, sandbox.callView("getBlockInfo", []interface{}{latest}, &ret))
+	require.Equal(t, iscmagic.ISCBlockInfo{
+		BlockIndex:            latest,
+		Timestamp:             expected.Timestamp.Unix(),
+		TotalRequests:         expected.TotalRequests,
+		NumSuccessfulRequests: expected.NumSuccessfulRequests,
+		NumOffLedgerRequests:  expected.NumOffLedgerRequests,
+		GasBurned:             expected.GasBurned,
+		GasFeeCharged:         expected.GasFeeCharged,
+	}, ret.BlockInfo)
+
+	require.Error(t, sandbox.callView("getBlockInfo", []interface{}{latest + 100}, &ret))
+}
+
 func TestISCCallView(t *testing.T) {
 	env := initEVM(t)
 	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
@@ -1940,7 +2015,7 @@ func TestTraceTransaction(t *testing.T) {
 	traceLatestTx := func() *jsonrpc.CallFrame {
 		latestBlock, err := env.evmChain.BlockByNumber(nil)
 		require.NoError(t, err)
-		trace, err := env.evmChain.TraceTransaction(latestBlock.Transactions()[0].Hash(), &tracers.TraceConfig{})
+		trace, err := env.evmChain.TraceTransaction(latestBlock.Transactions()[0].Hash(), &tracers.TraceConfig{Tracer: lo.ToPtr("callTracer")})
 		require.NoError(t, err)
 		var ret jsonrpc.CallFrame
 		err = json.Unmarshal(trace.(json.RawMessage), &ret)
@@ -1968,6 +2043,176 @@ func TestTraceTransaction(t *testing.T) {
 	}
 }
 
+func TestTraceTransactionTracers(t *testing.T) {
+	env := initEVM(t)
+	ethKey, ethAddr := env.soloChain.NewEthereumAccountWithL2Funds()
+	storage := env.deployStorageContract(ethKey)
+	res, err := storage.store(43)
+	require.NoError(t, err)
+	txHash := res.tx.Hash()
+
+	trace := func(config *tracers.TraceConfig, v any) {
+		ret, err := env.evmChain.TraceTransaction(txHash, config)
+		require.NoError(t, err)
+		err = json.Unmarshal(ret.(json.RawMessage), v)
+		require.NoError(t, err)
+	}
+
+	type account struct {
+		Nonce   uint64                      `json:"nonce"`
+		Code    hexutil.Bytes               `json:"code"`
+		Storage map[common.Hash]common.Hash `json:"storage"`
+	}
+	slot := common.Hash{}
+
+	t.Run("structLogger", func(t *testing.T) {
+		type structLog struct {
+			Op string `json:"op"`
+		}
+		var ret struct {
+			Gas        uint64      `json:"gas"`
+			Failed     bool        `json:"failed"`
+			StructLogs []structLog `json:"structLogs"`
+		}
+		// the struct logger is the default tracer
+		trace(nil, &ret)
+		require.False(t, ret.Failed)
+		require.NotZero(t, ret.Gas)
+		require.True(t, lo.ContainsBy(ret.StructLogs, func(l structLog) bool {
+			return l.Op == "SSTORE"
+		}))
+	})
+
+	t.Run("prestateTracer", func(t *testing.T) {
+		var ret map[common.Address]account
+		trace(&tracers.TraceConfig{Tracer: lo.ToPtr("prestateTracer")}, &ret)
+		require.Contains(t, ret, ethAddr)
+		require.Contains(t, ret, storage.address)
+		require.NotEmpty(t, ret[storage.address].Code)
+		require.Equal(t, common.BigToHash(big.NewInt(42)), ret[storage.address].Storage[slot])
+	})
+
+	t.Run("prestateTracer diffMode", func(t *testing.T) {
+		var ret struct {
+			Pre  map[common.Address]account `json:"pre"`
+			Post map[common.Address]account `json:"post"`
+		}
+		trace(&tracers.TraceConfig{
+			Tracer:       lo.ToPtr("prestateTracer"),
+			TracerConfig: json.RawMessage(`{"diffMode": true}`),
+		}, &ret)
+		require.Equal(t, common.BigToHash(big.NewInt(42)), ret.Pre[storage.address].Storage[slot])
+		require.Equal(t, common.BigToHash(big.NewInt(43)), ret.Post[storage.address].Storage[slot])
+		require.EqualValues(t, ret.Pre[ethAddr].Nonce+1, ret.Post[ethAddr].Nonce)
+	})
+
+	t.Run("4byteTracer", func(t *testing.T) {
+		var ret map[string]int
+		trace(&tracers.TraceConfig{Tracer: lo.ToPtr("4byteTracer")}, &ret)
+		selector := hexutil.Encode(storage.abi.Methods["store"].ID)
+		require.Equal(t, map[string]int{selector + "-32": 1}, ret)
+	})
+
+	_, err = env.evmChain.TraceTransaction(txHash, &tracers.TraceConfig{Tracer: lo.ToPtr("unknownTracer")})
+	require.ErrorContains(t, err, "unsupported tracer type")
+}
+
+func TestTraceBlock(t *testing.T) {
+	env := initEVM(t)
+	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
+	storage := env.deployStorageContract(ethKey)
+
+	// two txs from different senders in the same block
+	var reqs []isc.Request
+	var senders []common.Address
+	for i := 0; i < 2; i++ {
+		senderKey, senderAddress := env.soloChain.NewEthereumAccountWithL2Funds()
+		tx, err := storage.buildEthTx([]ethCallOptions{{
+			sender:   senderKey,
+			gasLimit: 100_000,
+		}}, "store", uint32(i))
+		require.NoError(t, err)
+		req, err := isc.NewEVMOffLedgerTxRequest(env.soloChain.ChainID, tx)
+		require.NoError(t, err)
+		reqs = append(reqs, req)
+		senders = append(senders, senderAddress)
+	}
+	env.soloChain.RunOffLedgerRequests(reqs)
+
+	block, err := env.evmChain.BlockByNumber(nil)
+	require.NoError(t, err)
+	require.Len(t, block.Transactions(), 2)
+
+	checkResults := func(results []*jsonrpc.TxTraceResult) {
+		require.Len(t, results, 2)
+		for i, res := range results {
+			require.Equal(t, block.Transactions()[i].Hash(), res.TxHash)
+			require.Empty(t, res.Error)
+			var frame jsonrpc.CallFrame
+			err := json.Unmarshal(res.Result.(json.RawMessage), &frame)
+			require.NoError(t, err)
+			require.EqualValues(t, senders[i], common.HexToAddress(frame.From))
+			require.EqualValues(t, storage.address, common.HexToAddress(frame.To))
+		}
+	}
+
+	results, err := env.evmChain.TraceBlockByNumber(block.Number(), &tracers.TraceConfig{Tracer: lo.ToPtr("callTracer")})
+	require.NoError(t, err)
+	checkResults(results)
+
+	results, err = env.evmChain.TraceBlockByHash(block.Hash(), &tracers.TraceConfig{Tracer: lo.ToPtr("callTracer")})
+	require.NoError(t, err)
+	checkResults(results)
+
+	_, err = env.evmChain.TraceBlockByHash(common.Hash{}, nil)
+	require.Error(t, err)
+}
+
+func TestTraceCall(t *testing.T) {
+	env := initEVM(t)
+	ethKey, ethAddr := env.soloChain.NewEthereumAccountWithL2Funds()
+	storage := env.deployStorageContract(ethKey)
+
+	callData, err := storage.abi.Pack("retrieve")
+	require.NoError(t, err)
+	callMsg := ethereum.CallMsg{From: ethAddr, To: &storage.address, Data: callData}
+
+	traceCall := func(config *jsonrpc.TraceCallConfig) *jsonrpc.CallFrame {
+		if config == nil {
+			config = &jsonrpc.TraceCallConfig{}
+		}
+		config.Tracer = lo.ToPtr("callTracer")
+		trace, err := env.evmChain.TraceCall(callMsg, nil, config)
+		require.NoError(t, err)
+		var ret jsonrpc.CallFrame
+		err = json.Unmarshal(trace.(json.RawMessage), &ret)
+		require.NoError(t, err)
+		return &ret
+	}
+	retrieved := func(frame *jsonrpc.CallFrame) uint32 {
+		var n uint32
+		err := storage.abi.UnpackIntoInterface(&n, "retrieve", common.FromHex(frame.Output))
+		require.NoError(t, err)
+		return n
+	}
+
+	frame := traceCall(nil)
+	require.EqualValues(t, ethAddr, common.HexToAddress(frame.From))
+	require.EqualValues(t, 42, retrieved(frame))
+
+	// override the first storage slot (uint32 n)
+	stateDiff := map[common.Hash]common.Hash{{}: common.BigToHash(big.NewInt(1337))}
+	frame = traceCall(&jsonrpc.TraceCallConfig{
+		StateOverrides: &evmtypes.StateOverride{
+			storage.address: {StateDiff: &stateDiff},
+		},
+	})
+	require.EqualValues(t, 1337, retrieved(frame))
+
+	// overrides are discarded
+	require.EqualValues(t, 42, retrieved(traceCall(nil)))
+}
+
 func TestMagicContractExamples(t *testing.T) {
 	env := initEVM(t)
 	ethKey, _ := env.soloChain.NewEthereumAccountWithL2Funds()
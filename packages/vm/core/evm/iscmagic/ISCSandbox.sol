@@ -90,6 +90,30 @@ interface ISCSandbox {
         view
         returns (ISCTokenProperties memory);
 
+    // Check whether the given ISC request has been processed by the chain
+    function isRequestProcessed(ISCRequestID memory requestID)
+        external
+        view
+        returns (bool);
+
+    // Get the receipt of a processed ISC request. Fails if the request has
+    // not been processed (see isRequestProcessed) or its receipt was pruned.
+    function getRequestReceipt(ISCRequestID memory requestID)
+        external
+        view
+        returns (ISCRequestReceipt memory);
+
+    // Get the index of the latest ISC block (not including the block being
+    // produced by the current request)
+    function getLatestBlockIndex() external view returns (uint32);
+
+    // Get information about an ISC block. Fails if the block does not exist
+    // or was pruned.
+    function getBlockInfo(uint32 blockIndex)
+        external
+        view
+        returns (ISCBlockInfo memory);
+
     // Get the ID of a L2-controlled native token, given its foundry serial number
     function getNativeTokenID(uint32 foundrySN)
         external
@@ -135,6 +135,32 @@ struct ISCTokenProperties {
     uint256 totalSupply;
 }
 
+// Receipt of a request processed by the ISC chain
+struct ISCRequestReceipt {
+    ISCRequestID requestID;
+    uint32 blockIndex;
+    uint16 requestIndex;
+    uint64 gasBudget;
+    uint64 gasBurned;
+    uint64 gasFeeCharged;
+    uint64 storageDepositCharged;
+    // true if the request failed; in that case errorMessage is not empty
+    bool failed;
+    string errorMessage;
+}
+
+// Information about an ISC block
+struct ISCBlockInfo {
+    uint32 blockIndex;
+    // seconds since UNIX epoch
+    int64 timestamp;
+    uint16 totalRequests;
+    uint16 numSuccessfulRequests;
+    uint16 numOffLedgerRequests;
+    uint64 gasBurned;
+    uint64 gasFeeCharged;
+}
+
 library ISCTypes {
     function L1AddressType(
         L1Address memory addr
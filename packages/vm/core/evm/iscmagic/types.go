@@ -11,6 +11,7 @@ import (
 	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/dict"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
 )
 
 // ISCChainID matches the type definition in ISCTypes.sol
@@ -382,3 +383,55 @@ type ISCTokenProperties struct {
 	Decimals     uint8
 	TotalSupply  *big.Int
 }
+
+// ISCRequestReceipt matches the struct definition in ISCTypes.sol
+type ISCRequestReceipt struct {
+	RequestID             ISCRequestID
+	BlockIndex            uint32
+	RequestIndex          uint16
+	GasBudget             uint64
+	GasBurned             uint64
+	GasFeeCharged         uint64
+	StorageDepositCharged uint64
+	Failed                bool
+	ErrorMessage          string
+}
+
+// WrapISCRequestReceipt wraps the receipt; errorMessage is the resolved error
+// of the receipt, if any
+func WrapISCRequestReceipt(rec *blocklog.RequestReceipt, errorMessage string) ISCRequestReceipt {
+	return ISCRequestReceipt{
+		RequestID:             WrapISCRequestID(rec.Request.ID()),
+		BlockIndex:            rec.BlockIndex,
+		RequestIndex:          rec.RequestIndex,
+		GasBudget:             rec.GasBudget,
+		GasBurned:             rec.GasBurned,
+		GasFeeCharged:         rec.GasFeeCharged,
+		StorageDepositCharged: rec.SDCharged,
+		Failed:                rec.Error != nil,
+		ErrorMessage:          errorMessage,
+	}
+}
+
+// ISCBlockInfo matches the struct definition in ISCTypes.sol
+type ISCBlockInfo struct {
+	BlockIndex            uint32
+	Timestamp             int64
+	TotalRequests         uint16
+	NumSuccessfulRequests uint16
+	NumOffLedgerRequests  uint16
+	GasBurned             uint64
+	GasFeeCharged         uint64
+}
+
+func WrapISCBlockInfo(bi *blocklog.BlockInfo) ISCBlockInfo {
+	return ISCBlockInfo{
+		BlockIndex:            bi.BlockIndex(),
+		Timestamp:             bi.Timestamp.Unix(),
+		TotalRequests:         bi.TotalRequests,
+		NumSuccessfulRequests: bi.NumSuccessfulRequests,
+		NumOffLedgerRequests:  bi.NumOffLedgerRequests,
+		GasBurned:             bi.GasBurned,
+		GasFeeCharged:         bi.GasFeeCharged,
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/iotaledger/wasp/packages/kv"
 	"github.com/iotaledger/wasp/packages/kv/codec"
 	"github.com/iotaledger/wasp/packages/kv/subrealm"
+	"github.com/iotaledger/wasp/packages/vm/gas"
 )
 
 type StateAccess struct {
@@ -65,3 +66,11 @@ func (sa *StateAccess) ChainOwnerID() isc.AgentID {
 func (sa *StateAccess) GetBlockKeepAmount() int32 {
 	return GetBlockKeepAmount(sa.state)
 }
+
+func (sa *StateAccess) GasLimits() *gas.Limits {
+	return MustGetGasLimits(sa.state)
+}
+
+func (sa *StateAccess) GasFeePolicy() *gas.FeePolicy {
+	return MustGetGasFeePolicy(sa.state)
+}
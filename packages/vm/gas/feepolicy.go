@@ -3,6 +3,7 @@ package gas
 import (
 	"fmt"
 	"io"
+	"math/big"
 
 	"github.com/iotaledger/hive.go/serializer/v2"
 	"github.com/iotaledger/wasp/packages/util"
@@ -70,6 +71,26 @@ func (p *FeePolicy) IsEnoughForMinimumFee(availableTokens uint64) bool {
 	return availableTokens >= p.MinFee()
 }
 
+// EVMGasPrice returns the price of one EVM gas unit in wei (18 decimals),
+// given the amount of decimals of the base token.
+// The special case '0:0' means that requests are free.
+func (p *FeePolicy) EVMGasPrice(baseTokenDecimals uint32) *big.Int {
+	if p.GasPerToken.IsZero() {
+		return big.NewInt(0)
+	}
+
+	decimalsDifference := 18 - baseTokenDecimals
+	price := big.NewInt(10)
+	price.Exp(price, new(big.Int).SetUint64(uint64(decimalsDifference)), nil)
+
+	price.Mul(price, new(big.Int).SetUint64(uint64(p.GasPerToken.B)))
+	price.Div(price, new(big.Int).SetUint64(uint64(p.GasPerToken.A)))
+	price.Mul(price, new(big.Int).SetUint64(uint64(p.EVMGasRatio.A)))
+	price.Div(price, new(big.Int).SetUint64(uint64(p.EVMGasRatio.B)))
+
+	return price
+}
+
 // if GasPerToken is '0:0' then set the GasBudget to MaxGasPerRequest
 func (p *FeePolicy) GasBudgetFromTokens(availableTokens uint64, limits ...*Limits) uint64 {
 	if p.GasPerToken.IsZero() {
@@ -28,8 +28,8 @@ type VMTask struct {
 	ValidatorFeeTarget isc.AgentID
 	// If EstimateGasMode is enabled, gas fee will be calculated but not charged
 	EstimateGasMode bool
-	// If EVMTracer is set, all requests will be executed normally up until the EVM
-	// tx with the given index, which will then be executed with the given tracer.
+	// If EVMTracer is set, all requests will be executed normally, and the EVM
+	// txs selected by the EVMTracer will be executed with the given tracer.
 	EVMTracer            *isc.EVMTracer
 	EnableGasBurnLogging bool // for testing and Solo only
 
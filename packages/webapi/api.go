@@ -19,6 +19,7 @@ import (
 	"github.com/iotaledger/wasp/packages/publisher"
 	"github.com/iotaledger/wasp/packages/registry"
 	userspkg "github.com/iotaledger/wasp/packages/users"
+	"github.com/iotaledger/wasp/packages/webapi/auditlog"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/chain"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/corecontracts"
 	apimetrics "github.com/iotaledger/wasp/packages/webapi/controllers/metrics"
@@ -26,6 +27,7 @@ import (
 	"github.com/iotaledger/wasp/packages/webapi/controllers/requests"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/users"
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+	"github.com/iotaledger/wasp/packages/webapi/ratelimit"
 	"github.com/iotaledger/wasp/packages/webapi/services"
 	"github.com/iotaledger/wasp/packages/webapi/websocket"
 )
@@ -48,7 +50,7 @@ func AddHealthEndpoint(server echoswagger.ApiRoot, chainService interfaces.Chain
 		SetSummary("Returns 200 if the node is healthy.")
 }
 
-func loadControllers(server echoswagger.ApiRoot, mocker *Mocker, controllersToLoad []interfaces.APIController, authMiddleware echo.MiddlewareFunc) {
+func loadControllers(server echoswagger.ApiRoot, mocker *Mocker, controllersToLoad []interfaces.APIController, authMiddleware, auditMiddleware echo.MiddlewareFunc) {
 	for _, controller := range controllersToLoad {
 		group := server.Group(controller.Name(), fmt.Sprintf("/v%d/", APIVersion))
 		controller.RegisterPublic(group, mocker)
@@ -65,6 +67,8 @@ func loadControllers(server echoswagger.ApiRoot, mocker *Mocker, controllersToLo
 			},
 		}
 
+		// the audit log comes first, to record the calls rejected by the authentication too
+		group.EchoGroup().Use(auditMiddleware)
 		if authMiddleware != nil {
 			group.EchoGroup().Use(authMiddleware)
 		}
@@ -93,6 +97,9 @@ func Init(
 	websocketService *websocket.Service,
 	indexDbPath string,
 	pub *publisher.Publisher,
+	rateLimitConfig ratelimit.Config,
+	rateLimitMetrics ratelimit.Metrics,
+	auditLog *auditlog.Log,
 ) {
 	// load mock files to generate correct echo swagger documentation
 	mocker := NewMocker()
@@ -111,11 +118,13 @@ func Init(
 	// --
 
 	authMiddleware := authentication.AddAuthentication(server, userManager, nodeIdentityProvider, authConfig, mocker)
+	// the rate limiter comes after the authentication context, to identify the clients by their user
+	server.Echo().Use(ratelimit.New(rateLimitConfig, rateLimitMetrics, authentication.Username).Middleware())
 
 	controllersToLoad := []interfaces.APIController{
 		chain.NewChainController(logger, chainService, committeeService, evmService, nodeService, offLedgerService, registryService),
 		apimetrics.NewMetricsController(chainService, metricsService),
-		node.NewNodeController(waspVersion, config, dkgService, nodeService, peeringService),
+		node.NewNodeController(waspVersion, config, dkgService, nodeService, peeringService, auditLog),
 		requests.NewRequestsController(chainService, offLedgerService, peeringService),
 		users.NewUsersController(userService),
 		corecontracts.NewCoreContractsController(chainService),
@@ -123,5 +132,5 @@ func Init(
 
 	AddHealthEndpoint(server, chainService, metricsService)
 	addWebSocketEndpoint(server, websocketService)
-	loadControllers(server, mocker, controllersToLoad, authMiddleware)
+	loadControllers(server, mocker, controllersToLoad, authMiddleware, auditLog.Middleware(authentication.Username))
 }
@@ -0,0 +1,75 @@
+package webapi
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pangpanglabs/echoswagger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/authentication"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	userspkg "github.com/iotaledger/wasp/packages/users"
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/auditlog"
+	"github.com/iotaledger/wasp/packages/webapi/controllers/users"
+	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+	"github.com/iotaledger/wasp/packages/webapi/services"
+)
+
+func TestAuditLogRecordsUnauthorizedCalls(t *testing.T) {
+	userManager := userspkg.NewUserManager(func([]*userspkg.User) error { return nil })
+	require.NoError(t, userManager.AddUser(&userspkg.User{
+		Name:        "wasp",
+		Permissions: map[string]struct{}{"write": {}},
+	}))
+	nodeIDKeypair := cryptolib.KeyPairFromSeed(cryptolib.SeedFromBytes([]byte("abc")))
+	_, authMiddleware := authentication.GetJWTAuthMiddleware(
+		authentication.JWTAuthConfiguration{Duration: time.Hour},
+		nodeIDKeypair,
+		userManager,
+	)
+
+	auditLog, err := auditlog.Open(testlogger.NewLogger(t), auditlog.Config{
+		Enabled:  true,
+		FilePath: filepath.Join(t.TempDir(), "audit.jsonl"),
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = auditLog.Close() })
+
+	e := echo.New()
+	e.HTTPErrorHandler = apierrors.HTTPErrorHandler()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("auth", &authentication.AuthContext{})
+			return next(c)
+		}
+	})
+	server := echoswagger.New(e, "/doc", &echoswagger.Info{Title: "Test Wasp API"})
+	mocker := NewMocker()
+	loadControllers(server, mocker, []interfaces.APIController{
+		users.NewUsersController(services.NewUserService(userManager)),
+	}, authMiddleware, auditLog.Middleware(authentication.Username))
+
+	for _, method := range []string{http.MethodGet, http.MethodDelete} {
+		req := httptest.NewRequest(method, "/v1/users/wasp", strings.NewReader(""))
+		res := httptest.NewRecorder()
+		e.ServeHTTP(res, req)
+		require.Equal(t, http.StatusUnauthorized, res.Code)
+	}
+
+	// only the mutating call is recorded
+	entries, err := auditLog.Query(auditlog.Filter{}, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, http.MethodDelete, entries[0].Method)
+	require.Equal(t, "/v1/users/:username", entries[0].Route)
+	require.Equal(t, http.StatusUnauthorized, entries[0].Status)
+	require.Empty(t, entries[0].User)
+}
@@ -15,6 +15,10 @@ func UserNotFoundError(username string) *HTTPError {
 	return NewHTTPError(http.StatusNotFound, fmt.Sprintf("User: %v not found", username), nil)
 }
 
+func APIKeyNotFoundError(username, name string) *HTTPError {
+	return NewHTTPError(http.StatusNotFound, fmt.Sprintf("API key: %v of user: %v not found", name, username), nil)
+}
+
 func UserCanNotBeDeleted(username string, explanation string) *HTTPError {
 	return NewHTTPError(http.StatusBadRequest, fmt.Sprintf("User: %v not be deleted. Reason: %v", username, explanation), nil)
 }
@@ -63,3 +67,15 @@ func ReceiptError(err error) *HTTPError {
 func Timeout(msg string) *HTTPError {
 	return NewHTTPError(http.StatusRequestTimeout, msg, nil)
 }
+
+func TooManyRequestsError() *HTTPError {
+	return NewHTTPError(http.StatusTooManyRequests, "Rate limit exceeded", nil)
+}
+
+func MissingPermissionError(permission string) *HTTPError {
+	return NewHTTPError(http.StatusUnauthorized, fmt.Sprintf("Missing permission: %v", permission), nil)
+}
+
+func AuditLogDisabledError() *HTTPError {
+	return NewHTTPError(http.StatusNotFound, "The audit log is disabled", nil)
+}
@@ -0,0 +1,363 @@
+// Package auditlog keeps an append-only log of the mutating calls to the
+// administrative routes of the web API (who did what, from where, and with
+// which result), stored as JSON lines in a file.
+package auditlog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/util"
+)
+
+const (
+	// MaxLoggedBodySize is the size up to which the request bodies are recorded
+	MaxLoggedBodySize = 64 * 1024
+
+	redacted = "<redacted>"
+)
+
+// SensitiveFields are the (lowercase) names of the JSON fields of the request
+// bodies, whose values are never written to the audit log.
+var SensitiveFields = map[string]bool{
+	"password":     true,
+	"token":        true,
+	"refreshtoken": true,
+	"jwt":          true,
+	"secret":       true,
+	"privatekey":   true,
+}
+
+var ErrDisabled = errors.New("the audit log is disabled")
+
+type Config struct {
+	Enabled  bool   `default:"true" usage:"whether the mutating calls to the administrative routes of the web API are recorded in the audit log"`
+	FilePath string `default:"waspdb/audit.jsonl" usage:"the path of the audit log file (JSON lines)"`
+}
+
+var DefaultConfig = Config{
+	Enabled:  true,
+	FilePath: "waspdb/audit.jsonl",
+}
+
+// Entry is a single record of the audit log
+type Entry struct {
+	Timestamp time.Time `json:"timestamp"`
+	// User is the authenticated user, empty if the call was not authenticated
+	User     string `json:"user"`
+	SourceIP string `json:"sourceIP"`
+	// ForwardedFor is the X-Forwarded-For header of the request, if any
+	ForwardedFor string `json:"forwardedFor,omitempty"`
+	Method       string `json:"method"`
+	// Route is the pattern of the route (e.g. /v1/chains/:chainID/activate)
+	Route string `json:"route"`
+	Path  string `json:"path"`
+	// Params are the path and query parameters of the call
+	Params map[string]string `json:"params,omitempty"`
+	// Body is the request body, with the sensitive fields redacted
+	Body   json.RawMessage `json:"body,omitempty"`
+	Status int             `json:"status"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Filter selects the entries of the audit log. Empty fields match all entries.
+type Filter struct {
+	User   string
+	Method string
+	// Route matches the entries whose route or path contains it
+	Route string
+	Since time.Time
+	Until time.Time
+}
+
+func (f *Filter) Matches(e *Entry) bool {
+	if f.User != "" && e.User != f.User {
+		return false
+	}
+	if f.Method != "" && !strings.EqualFold(e.Method, f.Method) {
+		return false
+	}
+	if f.Route != "" && !strings.Contains(e.Route, f.Route) && !strings.Contains(e.Path, f.Route) {
+		return false
+	}
+	if !f.Since.IsZero() && e.Timestamp.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !e.Timestamp.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Log is the audit log. A nil *Log is a disabled audit log.
+type Log struct {
+	log *logger.Logger
+
+	mutex sync.Mutex
+	file  *os.File
+}
+
+// Open opens (or creates) the audit log configured by the given config.
+// It returns nil, if the audit log is disabled.
+func Open(log *logger.Logger, config Config) (*Log, error) {
+	if !config.Enabled {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(config.FilePath), 0o700); err != nil {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(config.FilePath, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Log{
+		log:  log,
+		file: file,
+	}, nil
+}
+
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.file.Close()
+}
+
+// Append writes an entry to the end of the audit log
+func (l *Log) Append(entry *Entry) error {
+	if l == nil {
+		return ErrDisabled
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, err := l.file.Write(line); err != nil {
+		return err
+	}
+	return l.file.Sync()
+}
+
+// reader returns a reader of the entries written so far. The size is taken
+// while holding the lock, so that the reader never sees a partially written line.
+func (l *Log) reader() (io.Reader, error) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	info, err := l.file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return io.NewSectionReader(l.file, 0, info.Size()), nil
+}
+
+// scan calls f for each entry matching the filter, in the order they were written
+func (l *Log) scan(filter Filter, f func(entry *Entry, line []byte) error) error {
+	if l == nil {
+		return ErrDisabled
+	}
+
+	r, err := l.reader()
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*MaxLoggedBodySize)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		entry := &Entry{}
+		if err := json.Unmarshal(line, entry); err != nil {
+			return err
+		}
+		if !filter.Matches(entry) {
+			continue
+		}
+		if err := f(entry, line); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Query returns the latest entries (up to limit, 0 = all) matching the filter, newest first
+func (l *Log) Query(filter Filter, limit int) ([]*Entry, error) {
+	entries := make([]*Entry, 0)
+	err := l.scan(filter, func(entry *Entry, _ []byte) error {
+		entries = append(entries, entry)
+		if limit > 0 && len(entries) > limit {
+			entries = entries[1:]
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// Export writes the entries matching the filter as JSON lines, oldest first
+func (l *Log) Export(w io.Writer, filter Filter) error {
+	return l.scan(filter, func(_ *Entry, line []byte) error {
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+		_, err := w.Write([]byte{'\n'})
+		return err
+	})
+}
+
+// Middleware records the mutating calls (POST, PUT, DELETE, PATCH) to the
+// routes it is applied to. It must run before the authentication, so that the
+// rejected calls are recorded too. The username returns the authenticated user
+// of the call.
+func (l *Log) Middleware(username func(c echo.Context) string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if l == nil || !isMutating(c.Request().Method) {
+				return next(c)
+			}
+
+			entry := &Entry{
+				Timestamp:    time.Now().UTC(),
+				SourceIP:     echo.ExtractIPDirect()(c.Request()),
+				ForwardedFor: c.Request().Header.Get(echo.HeaderXForwardedFor),
+				Method:       c.Request().Method,
+				Route:        c.Path(),
+				Path:         c.Request().URL.Path,
+				Params:       callParams(c),
+				Body:         readBody(c),
+			}
+
+			err := next(c)
+			if err != nil {
+				// let the error handler write the response, to know its status
+				c.Error(err)
+				entry.Error = err.Error()
+			}
+
+			// the user is known only after the authentication of the call
+			entry.User = username(c)
+			entry.Status = c.Response().Status
+			if err != nil && entry.Status == http.StatusOK {
+				entry.Status = http.StatusInternalServerError
+			}
+
+			if appendErr := l.Append(entry); appendErr != nil {
+				l.log.Errorf("failed to write the audit log entry of %s %s: %s", entry.Method, entry.Path, appendErr)
+			}
+			return err
+		}
+	}
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+func callParams(c echo.Context) map[string]string {
+	params := make(map[string]string)
+	for i, name := range c.ParamNames() {
+		if i < len(c.ParamValues()) {
+			params[name] = c.ParamValues()[i]
+		}
+	}
+	for name, values := range c.QueryParams() {
+		params[name] = strings.Join(values, ",")
+	}
+	if len(params) == 0 {
+		return nil
+	}
+	return params
+}
+
+// readBody reads the request body (restoring it for the handler) and returns
+// it with the sensitive fields redacted. Bodies that are too large or no JSON
+// are recorded as a note about their size only.
+func readBody(c echo.Context) json.RawMessage {
+	body, err := util.PeekRequestBody(c.Request(), MaxLoggedBodySize)
+	if err != nil || len(body) == 0 {
+		return nil
+	}
+
+	if len(body) > MaxLoggedBodySize {
+		// the size is only known from the header, if at all
+		return bodyNote("body too large to be recorded", int(c.Request().ContentLength))
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return bodyNote("body is no JSON", len(body))
+	}
+
+	b, err := json.Marshal(redact(value))
+	if err != nil {
+		return bodyNote("body can not be recorded", len(body))
+	}
+	return b
+}
+
+func bodyNote(note string, size int) json.RawMessage {
+	fields := map[string]interface{}{"note": note}
+	if size >= 0 {
+		fields["size"] = size
+	}
+	b, _ := json.Marshal(fields)
+	return b
+}
+
+func redact(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if SensitiveFields[strings.ToLower(key)] {
+				v[key] = redacted
+				continue
+			}
+			v[key] = redact(field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redact(item)
+		}
+	}
+	return value
+}
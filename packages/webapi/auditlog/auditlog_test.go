@@ -0,0 +1,178 @@
+package auditlog_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/auditlog"
+)
+
+func newTestServer(t *testing.T, log *auditlog.Log) (*echo.Echo, *[]string) {
+	e := echo.New()
+	e.HTTPErrorHandler = apierrors.HTTPErrorHandler()
+	audit := log.Middleware(func(c echo.Context) string {
+		return c.Request().Header.Get("X-Test-User")
+	})
+
+	var bodies []string
+	e.POST("/v1/users", func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+		return c.NoContent(http.StatusCreated)
+	}, audit)
+	e.DELETE("/v1/users/:username", func(c echo.Context) error {
+		return apierrors.UserNotFoundError(c.Param("username"))
+	}, audit)
+	return e, &bodies
+}
+
+func doRequest(e *echo.Echo, method, path, body, user string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = "192.0.2.1:1234"
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", user)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func openTestLog(t *testing.T, path string) *auditlog.Log {
+	log, err := auditlog.Open(testlogger.NewLogger(t), auditlog.Config{Enabled: true, FilePath: path})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = log.Close() })
+	return log
+}
+
+func TestAuditLogMiddleware(t *testing.T) {
+	log := openTestLog(t, filepath.Join(t.TempDir(), "audit", "audit.jsonl"))
+	e, bodies := newTestServer(t, log)
+
+	body := `{"username":"bob","password":"secret!","permissions":["read"]}`
+	rec := doRequest(e, http.MethodPost, "/v1/users?dryRun=false", body, "alice")
+	require.Equal(t, http.StatusCreated, rec.Code)
+	// the handler still gets the whole body
+	require.Equal(t, []string{body}, *bodies)
+
+	rec = doRequest(e, http.MethodDelete, "/v1/users/carol", "", "alice")
+	require.Equal(t, http.StatusNotFound, rec.Code)
+
+	entries, err := log.Query(auditlog.Filter{}, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// newest first
+	deleted, added := entries[0], entries[1]
+
+	require.Equal(t, "alice", added.User)
+	require.Equal(t, "192.0.2.1", added.SourceIP)
+	require.Equal(t, http.MethodPost, added.Method)
+	require.Equal(t, "/v1/users", added.Route)
+	require.Equal(t, map[string]string{"dryRun": "false"}, added.Params)
+	require.Equal(t, http.StatusCreated, added.Status)
+	require.Empty(t, added.Error)
+	require.NotContains(t, string(added.Body), "secret!")
+	var addedBody map[string]interface{}
+	require.NoError(t, json.Unmarshal(added.Body, &addedBody))
+	require.Equal(t, "bob", addedBody["username"])
+	require.Equal(t, "<redacted>", addedBody["password"])
+
+	require.Equal(t, "/v1/users/:username", deleted.Route)
+	require.Equal(t, "/v1/users/carol", deleted.Path)
+	require.Equal(t, map[string]string{"username": "carol"}, deleted.Params)
+	require.Equal(t, http.StatusNotFound, deleted.Status)
+	require.NotEmpty(t, deleted.Error)
+}
+
+func TestAuditLogLargeBody(t *testing.T) {
+	log := openTestLog(t, filepath.Join(t.TempDir(), "audit.jsonl"))
+	e, bodies := newTestServer(t, log)
+
+	body := `{"username":"` + strings.Repeat("a", auditlog.MaxLoggedBodySize) + `"}`
+	require.Equal(t, http.StatusCreated, doRequest(e, http.MethodPost, "/v1/users", body, "alice").Code)
+	require.Equal(t, []string{body}, *bodies)
+
+	entries, err := log.Query(auditlog.Filter{}, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.JSONEq(t, fmt.Sprintf(`{"note":"body too large to be recorded","size":%d}`, len(body)), string(entries[0].Body))
+}
+
+func TestAuditLogQueryAndExport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	log := openTestLog(t, path)
+
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, user := range []string{"alice", "bob", "alice", "alice"} {
+		require.NoError(t, log.Append(&auditlog.Entry{
+			Timestamp: start.Add(time.Duration(i) * time.Hour),
+			User:      user,
+			Method:    http.MethodPost,
+			Route:     "/v1/chains/:chainID/activate",
+			Path:      "/v1/chains/abc/activate",
+			Status:    http.StatusOK,
+		}))
+	}
+
+	entries, err := log.Query(auditlog.Filter{User: "alice"}, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	require.Equal(t, start.Add(3*time.Hour), entries[0].Timestamp)
+	require.Equal(t, start.Add(2*time.Hour), entries[1].Timestamp)
+
+	entries, err = log.Query(auditlog.Filter{Since: start.Add(time.Hour), Until: start.Add(3 * time.Hour)}, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	entries, err = log.Query(auditlog.Filter{Route: "/deactivate"}, 0)
+	require.NoError(t, err)
+	require.Empty(t, entries)
+
+	var buf bytes.Buffer
+	require.NoError(t, log.Export(&buf, auditlog.Filter{User: "alice"}))
+	scanner := bufio.NewScanner(&buf)
+	var exported []auditlog.Entry
+	for scanner.Scan() {
+		var entry auditlog.Entry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		exported = append(exported, entry)
+	}
+	require.Len(t, exported, 3)
+	// oldest first
+	require.Equal(t, start, exported[0].Timestamp)
+
+	// the entries survive reopening the log
+	require.NoError(t, log.Close())
+	reopened := openTestLog(t, path)
+	entries, err = reopened.Query(auditlog.Filter{}, 0)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+}
+
+func TestAuditLogDisabled(t *testing.T) {
+	log, err := auditlog.Open(testlogger.NewLogger(t), auditlog.Config{Enabled: false})
+	require.NoError(t, err)
+	require.Nil(t, log)
+
+	e, bodies := newTestServer(t, log)
+	rec := doRequest(e, http.MethodPost, "/v1/users", `{}`, "alice")
+	require.Equal(t, http.StatusCreated, rec.Code)
+	require.Len(t, *bodies, 1)
+
+	_, err = log.Query(auditlog.Filter{}, 0)
+	require.ErrorIs(t, err, auditlog.ErrDisabled)
+}
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -27,14 +28,27 @@ func ParseReceipt(chain chainpkg.Chain, receipt *blocklog.RequestReceipt) (*isc.
 }
 
 func CallView(ch chainpkg.Chain, contractName, functionName isc.Hname, params dict.Dict, blockIndexOrHash string) (dict.Dict, error) {
-	var chainState state.State
-	var err error
+	chainState, err := StateByBlockIndexOrTrieRoot(ch, blockIndexOrHash)
+	if err != nil {
+		return nil, err
+	}
+	return chainutil.CallView(chainState, ch, contractName, functionName, params)
+}
+
+// ErrStateNotFound is returned by StateByBlockIndexOrTrieRoot, if the state at
+// the given block index or trie root is not available, e.g. it was pruned.
+var ErrStateNotFound = errors.New("state not found")
+
+// StateByBlockIndexOrTrieRoot returns the chain state at the given block index
+// or (0x-prefixed) trie root, or the latest state, if blockIndexOrHash is empty.
+func StateByBlockIndexOrTrieRoot(ch chainpkg.Chain, blockIndexOrHash string) (state.State, error) {
 	switch {
 	case blockIndexOrHash == "":
-		chainState, err = ch.LatestState(chainpkg.ActiveOrCommittedState)
+		chainState, err := ch.LatestState(chainpkg.ActiveOrCommittedState)
 		if err != nil {
 			return nil, fmt.Errorf("error getting latest chain state: %w", err)
 		}
+		return chainState, nil
 	case strings.HasPrefix(blockIndexOrHash, "0x"):
 		hashBytes, err := iotago.DecodeHex(blockIndexOrHash)
 		if err != nil {
@@ -44,21 +58,22 @@ func CallView(ch chainpkg.Chain, contractName, functionName isc.Hname, params di
 		if err != nil {
 			return nil, fmt.Errorf("invalid block hash: %v", blockIndexOrHash)
 		}
-		chainState, err = ch.Store().StateByTrieRoot(trieRoot)
+		chainState, err := ch.Store().StateByTrieRoot(trieRoot)
 		if err != nil {
-			return nil, fmt.Errorf("error getting block by trie root: %w", err)
+			return nil, fmt.Errorf("%w: error getting block by trie root: %w", ErrStateNotFound, err)
 		}
+		return chainState, nil
 	default:
 		blockIndex, err := strconv.ParseUint(blockIndexOrHash, 10, 32)
 		if err != nil {
 			return nil, fmt.Errorf("invalid block number: %v", blockIndexOrHash)
 		}
-		chainState, err = ch.Store().StateByIndex(uint32(blockIndex))
+		chainState, err := ch.Store().StateByIndex(uint32(blockIndex))
 		if err != nil {
-			return nil, fmt.Errorf("error getting block by index: %w", err)
+			return nil, fmt.Errorf("%w: error getting block by index: %w", ErrStateNotFound, err)
 		}
+		return chainState, nil
 	}
-	return chainutil.CallView(chainState, ch, contractName, functionName, params)
 }
 
 func EstimateGas(ch chainpkg.Chain, req isc.Request) (*isc.Receipt, error) {
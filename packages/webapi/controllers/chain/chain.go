@@ -2,12 +2,17 @@ package chain
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/labstack/echo/v4"
 
 	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/state"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/common"
 	"github.com/iotaledger/wasp/packages/webapi/controllers/controllerutils"
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
 	"github.com/iotaledger/wasp/packages/webapi/models"
@@ -140,3 +145,56 @@ func (c *Controller) getState(e echo.Context) error {
 
 	return e.JSON(http.StatusOK, response)
 }
+
+const (
+	defaultStateDiffLimit = 1000
+	maxStateDiffLimit     = 10000
+)
+
+func (c *Controller) getStateDiff(e echo.Context) error {
+	controllerutils.SetOperation(e, "get_state_diff")
+	chainID, err := controllerutils.ChainIDFromParams(e, c.chainService)
+	if err != nil {
+		return err
+	}
+
+	from := e.QueryParam(params.ParamFromBlock)
+	if from == "" {
+		return apierrors.InvalidPropertyError(params.ParamFromBlock, errors.New("missing block index or trie root"))
+	}
+
+	var contract *isc.Hname
+	if hnameStr := e.QueryParam(params.ParamContractHName); hnameStr != "" {
+		hname, err := isc.HnameFromString(hnameStr)
+		if err != nil {
+			return apierrors.InvalidPropertyError(params.ParamContractHName, err)
+		}
+		contract = &hname
+	}
+
+	limit := defaultStateDiffLimit
+	if limitStr := e.QueryParam(params.ParamLimit); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit <= 0 || limit > maxStateDiffLimit {
+			return apierrors.InvalidPropertyError(params.ParamLimit, fmt.Errorf("must be between 1 and %d", maxStateDiffLimit))
+		}
+	}
+
+	var fromKey []byte
+	if keyStr := e.QueryParam(params.ParamContinuationKey); keyStr != "" {
+		fromKey, err = iotago.DecodeHex(keyStr)
+		if err != nil {
+			return apierrors.InvalidPropertyError(params.ParamContinuationKey, err)
+		}
+	}
+
+	diff, err := c.chainService.GetStateDiff(chainID, from, e.QueryParam(params.ParamToBlock), contract, fromKey, limit)
+	if err != nil {
+		if errors.Is(err, state.ErrTrieRootNotFound) || errors.Is(err, common.ErrStateNotFound) {
+			return apierrors.NoRecordFoundError(err)
+		}
+		return err
+	}
+
+	return e.JSON(http.StatusOK, models.MapStateDiffResponse(diff))
+}
@@ -125,54 +125,66 @@ func (c *Controller) RegisterPublic(publicAPI echoswagger.ApiGroup, mocker inter
 }
 
 func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfaces.Mocker) {
-	adminAPI.GET("chains", c.getChainList, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains", c.getChainList, authentication.ValidatePermissions([]string{permissions.ChainsRead})).
 		AddResponse(http.StatusOK, "A list of all available chains", mocker.Get([]models.ChainInfoResponse{}), nil).
 		SetOperationId("getChains").
 		SetSummary("Get a list of all chains")
 
-	adminAPI.POST("chains/:chainID/activate", c.activateChain, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("chains/:chainID/activate", c.activateChain, authentication.ValidatePermissions([]string{permissions.ChainsWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddResponse(http.StatusNotModified, "Chain was not activated", nil, nil).
 		AddResponse(http.StatusOK, "Chain was successfully activated", nil, nil).
 		SetOperationId("activateChain").
 		SetSummary("Activate a chain")
 
-	adminAPI.POST("chains/:chainID/deactivate", c.deactivateChain, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("chains/:chainID/deactivate", c.deactivateChain, authentication.ValidatePermissions([]string{permissions.ChainsWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddResponse(http.StatusNotModified, "Chain was not deactivated", nil, nil).
 		AddResponse(http.StatusOK, "Chain was successfully deactivated", nil, nil).
 		SetOperationId("deactivateChain").
 		SetSummary("Deactivate a chain")
 
-	adminAPI.GET("chains/:chainID/committee", c.getCommitteeInfo, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains/:chainID/committee", c.getCommitteeInfo, authentication.ValidatePermissions([]string{permissions.ChainsRead})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
 		AddResponse(http.StatusOK, "A list of all nodes tied to the chain", mocker.Get(models.CommitteeInfoResponse{}), nil).
 		SetOperationId("getCommitteeInfo").
 		SetSummary("Get information about the deployed committee")
 
-	adminAPI.GET("chains/:chainID/contracts", c.getContracts, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("chains/:chainID/contracts", c.getContracts, authentication.ValidatePermissions([]string{permissions.ChainsRead})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamQuery("", params.ParamBlockIndexOrTrieRoot, params.DescriptionBlockIndexOrTrieRoot, false).
 		AddResponse(http.StatusOK, "A list of all available contracts", mocker.Get([]models.ContractInfoResponse{}), nil).
 		SetOperationId("getContracts").
 		SetSummary("Get all available chain contracts")
 
-	adminAPI.POST("chains/:chainID/chainrecord", c.setChainRecord, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.GET("chains/:chainID/state-diff", c.getStateDiff, authentication.ValidatePermissions([]string{permissions.ChainsRead})).
+		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
+		AddParamQuery("", params.ParamFromBlock, params.DescriptionFromBlock, true).
+		AddParamQuery("", params.ParamToBlock, params.DescriptionToBlock, false).
+		AddParamQuery("", params.ParamContractHName, params.DescriptionContractHName, false).
+		AddParamQuery(0, params.ParamLimit, params.DescriptionStateDiffLimit, false).
+		AddParamQuery("", params.ParamContinuationKey, params.DescriptionContinuationKey, false).
+		AddResponse(http.StatusNotFound, "State not found", nil, nil).
+		AddResponse(http.StatusOK, "The keys which differ between the two states", mocker.Get(models.StateDiffResponse{}), nil).
+		SetOperationId("getStateDiff").
+		SetSummary("Get the key-level difference between the chain states at two blocks")
+
+	adminAPI.POST("chains/:chainID/chainrecord", c.setChainRecord, authentication.ValidatePermissions([]string{permissions.ChainsWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamBody(mocker.Get(models.ChainRecord{}), "ChainRecord", "Chain Record", true).
 		AddResponse(http.StatusCreated, "Chain record was saved", nil, nil).
 		SetSummary("Sets the chain record.").
 		SetOperationId("setChainRecord")
 
-	adminAPI.PUT("chains/:chainID/access-node/:peer", c.addAccessNode, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.PUT("chains/:chainID/access-node/:peer", c.addAccessNode, authentication.ValidatePermissions([]string{permissions.ChainsWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamPath("", params.ParamPeer, params.DescriptionPeer).
 		AddResponse(http.StatusCreated, "Access node was successfully added", nil, nil).
 		SetSummary("Configure a trusted node to be an access node.").
 		SetOperationId("addAccessNode")
 
-	adminAPI.DELETE("chains/:chainID/access-node/:peer", c.removeAccessNode, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.DELETE("chains/:chainID/access-node/:peer", c.removeAccessNode, authentication.ValidatePermissions([]string{permissions.ChainsWrite})).
 		AddParamPath("", params.ParamChainID, params.DescriptionChainID).
 		AddParamPath("", params.ParamPeer, params.DescriptionPeer).
 		AddResponse(http.StatusOK, "Access node was successfully removed", nil, nil).
@@ -0,0 +1,99 @@
+package node
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/samber/lo"
+
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/auditlog"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/params"
+)
+
+const defaultAuditLogLimit = 100
+
+// MIMEApplicationJSONLines is the content type of the exported audit log
+const MIMEApplicationJSONLines = "application/x-ndjson"
+
+func parseTimeParam(e echo.Context, name string) (time.Time, error) {
+	value := e.QueryParam(name)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, apierrors.InvalidPropertyError(name, err)
+	}
+	return t, nil
+}
+
+func parseAuditLogFilter(e echo.Context) (auditlog.Filter, error) {
+	since, err := parseTimeParam(e, params.ParamSince)
+	if err != nil {
+		return auditlog.Filter{}, err
+	}
+
+	until, err := parseTimeParam(e, params.ParamUntil)
+	if err != nil {
+		return auditlog.Filter{}, err
+	}
+
+	return auditlog.Filter{
+		User:   e.QueryParam(params.ParamUser),
+		Method: e.QueryParam(params.ParamMethod),
+		Route:  e.QueryParam(params.ParamRoute),
+		Since:  since,
+		Until:  until,
+	}, nil
+}
+
+func (c *Controller) getAuditLog(e echo.Context) error {
+	filter, err := parseAuditLogFilter(e)
+	if err != nil {
+		return err
+	}
+
+	limit := defaultAuditLogLimit
+	if limitStr := e.QueryParam(params.ParamLimit); limitStr != "" {
+		limit, err = strconv.Atoi(limitStr)
+		if err != nil || limit < 0 {
+			return apierrors.InvalidPropertyError(params.ParamLimit, err)
+		}
+	}
+
+	entries, err := c.auditLog.Query(filter, limit)
+	if errors.Is(err, auditlog.ErrDisabled) {
+		return apierrors.AuditLogDisabledError()
+	}
+	if err != nil {
+		return err
+	}
+
+	return e.JSON(http.StatusOK, lo.Map(entries, func(entry *auditlog.Entry, _ int) models.AuditLogEntry {
+		return models.MapAuditLogEntry(entry)
+	}))
+}
+
+func (c *Controller) exportAuditLog(e echo.Context) error {
+	filter, err := parseAuditLogFilter(e)
+	if err != nil {
+		return err
+	}
+
+	if c.auditLog == nil {
+		return apierrors.AuditLogDisabledError()
+	}
+
+	e.Response().Header().Set(echo.HeaderContentType, MIMEApplicationJSONLines)
+	e.Response().Header().Set(echo.HeaderContentDisposition, `attachment; filename="audit.jsonl"`)
+	e.Response().WriteHeader(http.StatusOK)
+
+	// the response is already committed, errors can only cut the export short
+	return c.auditLog.Export(e.Response(), filter)
+}
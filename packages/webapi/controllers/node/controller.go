@@ -8,6 +8,7 @@ import (
 	"github.com/iotaledger/hive.go/app/configuration"
 	"github.com/iotaledger/wasp/packages/authentication"
 	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
+	"github.com/iotaledger/wasp/packages/webapi/auditlog"
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
 	"github.com/iotaledger/wasp/packages/webapi/models"
 	"github.com/iotaledger/wasp/packages/webapi/params"
@@ -20,15 +21,17 @@ type Controller struct {
 	dkgService     *services.DKGService
 	nodeService    interfaces.NodeService
 	peeringService interfaces.PeeringService
+	auditLog       *auditlog.Log
 }
 
-func NewNodeController(waspVersion string, config *configuration.Configuration, dkgService *services.DKGService, nodeService interfaces.NodeService, peeringService interfaces.PeeringService) interfaces.APIController {
+func NewNodeController(waspVersion string, config *configuration.Configuration, dkgService *services.DKGService, nodeService interfaces.NodeService, peeringService interfaces.PeeringService, auditLog *auditlog.Log) interfaces.APIController {
 	return &Controller{
 		waspVersion:    waspVersion,
 		config:         config,
 		dkgService:     dkgService,
 		nodeService:    nodeService,
 		peeringService: peeringService,
+		auditLog:       auditLog,
 	}
 }
 
@@ -49,63 +52,89 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		SetOperationId("getInfo").
 		SetSummary("Returns private information about this node.")
 
-	adminAPI.GET("node/peers/trusted", c.getTrustedPeers, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/peers/trusted", c.getTrustedPeers, authentication.ValidatePermissions([]string{permissions.PeeringRead})).
 		AddResponse(http.StatusOK, "A list of trusted peers", mocker.Get([]models.PeeringNodeIdentityResponse{}), nil).
 		SetSummary("Get trusted peers").
 		SetOperationId("getTrustedPeers")
 
-	adminAPI.DELETE("node/peers/trusted/:peer", c.distrustPeer, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.DELETE("node/peers/trusted/:peer", c.distrustPeer, authentication.ValidatePermissions([]string{permissions.PeeringWrite})).
 		AddParamPath("", params.ParamPeer, params.DescriptionPeer).
 		AddResponse(http.StatusNotFound, "Peer not found", nil, nil).
 		AddResponse(http.StatusOK, "Peer was successfully distrusted", nil, nil).
 		SetSummary("Distrust a peering node").
 		SetOperationId("distrustPeer")
 
-	adminAPI.GET("node/owner/certificate", c.nodeOwnerCertificate, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/owner/certificate", c.nodeOwnerCertificate, authentication.ValidatePermissions([]string{permissions.NodeRead})).
 		AddResponse(http.StatusOK, "Node Certificate", mocker.Get(models.NodeOwnerCertificateResponse{}), nil).
 		SetSummary("Gets the node owner").
 		SetOperationId("ownerCertificate")
 
-	adminAPI.POST("node/peers/trusted", c.trustPeer, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("node/peers/trusted", c.trustPeer, authentication.ValidatePermissions([]string{permissions.PeeringWrite})).
 		AddParamBody(mocker.Get(models.PeeringTrustRequest{}), "", "Info of the peer to trust", true).
 		AddResponse(http.StatusOK, "Peer was successfully trusted", nil, nil).
 		SetSummary("Trust a peering node").
 		SetOperationId("trustPeer")
 
-	adminAPI.POST("node/dks", c.generateDKS, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("node/dks", c.generateDKS, authentication.ValidatePermissions([]string{permissions.DKGWrite})).
 		AddParamBody(mocker.Get(models.DKSharesPostRequest{}), "DKSharesPostRequest", "Request parameters", true).
 		AddResponse(http.StatusOK, "DK shares info", mocker.Get(models.DKSharesInfo{}), nil).
 		SetSummary("Generate a new distributed key").
 		SetOperationId("generateDKS")
 
-	adminAPI.GET("node/dks/:sharedAddress", c.getDKSInfo, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/dks/:sharedAddress", c.getDKSInfo, authentication.ValidatePermissions([]string{permissions.DKGRead})).
 		AddParamPath("", params.ParamSharedAddress, params.DescriptionSharedAddress).
 		AddResponse(http.StatusNotFound, "Shared address not found", nil, nil).
 		AddResponse(http.StatusOK, "DK shares info", mocker.Get(models.DKSharesInfo{}), nil).
 		SetSummary("Get information about the shared address DKS configuration").
 		SetOperationId("getDKSInfo")
 
-	adminAPI.GET("node/peers/identity", c.getIdentity, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/peers/identity", c.getIdentity, authentication.ValidatePermissions([]string{permissions.PeeringRead})).
 		AddResponse(http.StatusOK, "This node peering identity", mocker.Get(models.PeeringNodeIdentityResponse{}), nil).
 		SetSummary("Get basic peer info of the current node").
 		SetOperationId("getPeeringIdentity")
 
-	adminAPI.GET("node/peers", c.getRegisteredPeers, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/peers", c.getRegisteredPeers, authentication.ValidatePermissions([]string{permissions.PeeringRead})).
 		AddResponse(http.StatusOK, "A list of all peers", mocker.Get([]models.PeeringNodeStatusResponse{}), nil).
 		SetSummary("Get basic information about all configured peers").
 		SetOperationId("getAllPeers")
 
-	adminAPI.POST("node/shutdown", c.shutdownNode, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("node/shutdown", c.shutdownNode, authentication.ValidatePermissions([]string{permissions.NodeWrite})).
 		AddResponse(http.StatusOK, "The node has been shut down", nil, nil).
 		SetSummary("Shut down the node").
 		SetOperationId("shutdownNode")
 
+	adminAPI.GET("node/audit", c.getAuditLog, authentication.ValidatePermissions([]string{permissions.AuditRead})).
+		AddParamQuery("", params.ParamUser, params.DescriptionUser, false).
+		AddParamQuery("", params.ParamMethod, params.DescriptionMethod, false).
+		AddParamQuery("", params.ParamRoute, params.DescriptionRoute, false).
+		AddParamQuery("", params.ParamSince, params.DescriptionSince, false).
+		AddParamQuery("", params.ParamUntil, params.DescriptionUntil, false).
+		AddParamQuery(0, params.ParamLimit, params.DescriptionLimit, false).
+		AddResponse(http.StatusBadRequest, "Invalid filter", nil, nil).
+		AddResponse(http.StatusNotFound, "The audit log is disabled", nil, nil).
+		AddResponse(http.StatusOK, "The audit log entries", mocker.Get([]models.AuditLogEntry{}), nil).
+		SetOperationId("getAuditLog").
+		SetSummary("Get the log of the administrative calls changing the node")
+
+	adminAPI.GET("node/audit/export", c.exportAuditLog, authentication.ValidatePermissions([]string{permissions.AuditRead})).
+		AddParamQuery("", params.ParamUser, params.DescriptionUser, false).
+		AddParamQuery("", params.ParamMethod, params.DescriptionMethod, false).
+		AddParamQuery("", params.ParamRoute, params.DescriptionRoute, false).
+		AddParamQuery("", params.ParamSince, params.DescriptionSince, false).
+		AddParamQuery("", params.ParamUntil, params.DescriptionUntil, false).
+		AddResponse(http.StatusBadRequest, "Invalid filter", nil, nil).
+		AddResponse(http.StatusNotFound, "The audit log is disabled", nil, nil).
+		AddResponse(http.StatusOK, "The audit log entries as JSON lines, oldest first", "", nil).
+		SetResponseContentType(MIMEApplicationJSONLines).
+		SetOperationId("exportAuditLog").
+		SetSummary("Export the log of the administrative calls changing the node as JSON lines")
+
 	fakeConfigMap := make(map[string]interface{})
 	fakeConfigMap["app.checkForUpdates"] = true
 	fakeConfigMap["logger.level"] = "info"
 	fakeConfigMap["inx.maxConnectionAttempts"] = 30
 
-	adminAPI.GET("node/config", c.getConfiguration, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("node/config", c.getConfiguration, authentication.ValidatePermissions([]string{permissions.NodeRead})).
 		AddResponse(http.StatusOK, "Dumped configuration", fakeConfigMap, nil).
 		SetOperationId("getConfiguration").
 		SetSummary("Return the Wasp configuration")
@@ -18,7 +18,7 @@ import (
 
 func TestNodeVersion(t *testing.T) {
 	version := "testVersion"
-	c := node.NewNodeController(version, nil, nil, nil, nil)
+	c := node.NewNodeController(version, nil, nil, nil, nil, nil)
 	e := echo.New()
 	server := echoswagger.New(e, "/doc", &echoswagger.Info{
 		Title:       "Test Wasp API",
@@ -35,5 +35,6 @@ func (c *Controller) getInfo(e echo.Context) error {
 		PublicKey:  identity.PublicKey.String(),
 		PeeringURL: identity.PeeringURL,
 		L1Params:   models.MapL1Params(l1Params),
+		Retention:  c.nodeService.NodeRetention(),
 	})
 }
@@ -0,0 +1,106 @@
+package users
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/params"
+)
+
+func (c *Controller) addAPIKey(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+
+	if userName == "" {
+		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
+	}
+
+	var addAPIKeyModel models.AddAPIKeyRequest
+
+	if err := e.Bind(&addAPIKeyModel); err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	if addAPIKeyModel.Name == "" {
+		return apierrors.InvalidPropertyError("name", errors.New("name is empty"))
+	}
+
+	var expiresAt time.Time
+	if addAPIKeyModel.ExpiresAt != nil {
+		expiresAt = *addAPIKeyModel.ExpiresAt
+		if !expiresAt.After(time.Now()) {
+			return apierrors.InvalidPropertyError("expiresAt", errors.New("expiry time is in the past"))
+		}
+	}
+
+	if err := c.validateTargetUser(e, userName); err != nil {
+		return err
+	}
+
+	if err := validatePermissions(e, addAPIKeyModel.Permissions); err != nil {
+		return err
+	}
+
+	apiKey, err := c.userService.AddAPIKey(userName, addAPIKeyModel.Name, addAPIKeyModel.Permissions, expiresAt)
+	if err != nil {
+		return apierrors.InvalidPropertyError("body", err)
+	}
+
+	return e.JSON(http.StatusCreated, apiKey)
+}
+
+func (c *Controller) getAPIKeys(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+
+	if userName == "" {
+		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
+	}
+
+	apiKeys, err := c.userService.GetAPIKeys(userName)
+	if err != nil {
+		return apierrors.UserNotFoundError(userName)
+	}
+
+	return e.JSON(http.StatusOK, apiKeys)
+}
+
+func (c *Controller) deleteAPIKey(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+	apiKeyName := e.Param(params.ParamAPIKeyName)
+
+	if userName == "" {
+		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
+	}
+
+	if err := c.validateTargetUser(e, userName); err != nil {
+		return err
+	}
+
+	if err := c.userService.DeleteAPIKey(userName, apiKeyName); err != nil {
+		return apierrors.APIKeyNotFoundError(userName, apiKeyName)
+	}
+
+	return e.NoContent(http.StatusOK)
+}
+
+func (c *Controller) revokeUserTokens(e echo.Context) error {
+	userName := e.Param(params.ParamUsername)
+
+	if userName == "" {
+		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
+	}
+
+	if err := c.validateTargetUser(e, userName); err != nil {
+		return err
+	}
+
+	if err := c.userService.RevokeUserTokens(userName); err != nil {
+		return apierrors.UserNotFoundError(userName)
+	}
+
+	return e.NoContent(http.StatusOK)
+}
@@ -30,33 +30,33 @@ func (c *Controller) RegisterPublic(publicAPI echoswagger.ApiGroup, mocker inter
 }
 
 func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfaces.Mocker) {
-	adminAPI.GET("users", c.getUsers, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("users", c.getUsers, authentication.ValidatePermissions([]string{permissions.UsersRead})).
 		AddResponse(http.StatusOK, "A list of all users", mocker.Get([]models.User{}), nil).
 		SetOperationId("getUsers").
 		SetSummary("Get a list of all users")
 
-	adminAPI.GET("users/:username", c.getUser, authentication.ValidatePermissions([]string{permissions.Read})).
+	adminAPI.GET("users/:username", c.getUser, authentication.ValidatePermissions([]string{permissions.UsersRead})).
 		AddParamPath("", params.ParamUsername, params.DescriptionUsername).
 		AddResponse(http.StatusNotFound, "User not found", nil, nil).
 		AddResponse(http.StatusOK, "Returns a specific user", mocker.Get(models.User{}), nil).
 		SetOperationId("getUser").
 		SetSummary("Get a user")
 
-	adminAPI.DELETE("users/:username", c.deleteUser, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.DELETE("users/:username", c.deleteUser, authentication.ValidatePermissions([]string{permissions.UsersWrite})).
 		AddParamPath("", params.ParamUsername, params.DescriptionUsername).
 		AddResponse(http.StatusNotFound, "User not found", nil, nil).
 		AddResponse(http.StatusOK, "Deletes a specific user", nil, nil).
 		SetOperationId("deleteUser").
 		SetSummary("Deletes a user")
 
-	adminAPI.POST("users", c.addUser, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.POST("users", c.addUser, authentication.ValidatePermissions([]string{permissions.UsersWrite})).
 		AddParamBody(mocker.Get(models.AddUserRequest{}), "", "The user data", true).
 		AddResponse(http.StatusBadRequest, "Invalid request", nil, nil).
 		AddResponse(http.StatusCreated, "User successfully added", nil, nil).
 		SetOperationId("addUser").
 		SetSummary("Add a user")
 
-	adminAPI.PUT("users/:username/permissions", c.updateUserPermissions, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.PUT("users/:username/permissions", c.updateUserPermissions, authentication.ValidatePermissions([]string{permissions.UsersWrite})).
 		AddParamPath("", params.ParamUsername, params.DescriptionUsername).
 		AddParamBody(mocker.Get(models.UpdateUserPermissionsRequest{}), "", "The users new permissions", true).
 		AddResponse(http.StatusBadRequest, "Invalid request", nil, nil).
@@ -65,7 +65,7 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		SetOperationId("changeUserPermissions").
 		SetSummary("Change user permissions")
 
-	adminAPI.PUT("users/:username/password", c.updateUserPassword, authentication.ValidatePermissions([]string{permissions.Write})).
+	adminAPI.PUT("users/:username/password", c.updateUserPassword, authentication.ValidatePermissions([]string{permissions.UsersWrite})).
 		AddParamPath("", params.ParamUsername, params.DescriptionUsername).
 		AddParamBody(mocker.Get(models.UpdateUserPasswordRequest{}), "", "The users new password", true).
 		AddResponse(http.StatusBadRequest, "Invalid request", nil, nil).
@@ -73,4 +73,35 @@ func (c *Controller) RegisterAdmin(adminAPI echoswagger.ApiGroup, mocker interfa
 		AddResponse(http.StatusOK, "User successfully updated", nil, nil).
 		SetOperationId("changeUserPassword").
 		SetSummary("Change user password")
+
+	adminAPI.GET("users/:username/apikeys", c.getAPIKeys, authentication.ValidatePermissions([]string{permissions.UsersRead})).
+		AddParamPath("", params.ParamUsername, params.DescriptionUsername).
+		AddResponse(http.StatusNotFound, "User not found", nil, nil).
+		AddResponse(http.StatusOK, "The API keys of the user", mocker.Get([]models.UserAPIKey{}), nil).
+		SetOperationId("getAPIKeys").
+		SetSummary("Get the API keys of a user")
+
+	adminAPI.POST("users/:username/apikeys", c.addAPIKey, authentication.ValidatePermissions([]string{permissions.UsersWrite})).
+		AddParamPath("", params.ParamUsername, params.DescriptionUsername).
+		AddParamBody(mocker.Get(models.AddAPIKeyRequest{}), "", "The API key data", true).
+		AddResponse(http.StatusBadRequest, "Invalid request", nil, nil).
+		AddResponse(http.StatusNotFound, "User not found", nil, nil).
+		AddResponse(http.StatusCreated, "API key successfully added", mocker.Get(models.AddAPIKeyResponse{}), nil).
+		SetOperationId("addAPIKey").
+		SetSummary("Add an API key to a user")
+
+	adminAPI.DELETE("users/:username/apikeys/:apiKeyName", c.deleteAPIKey, authentication.ValidatePermissions([]string{permissions.UsersWrite})).
+		AddParamPath("", params.ParamUsername, params.DescriptionUsername).
+		AddParamPath("", params.ParamAPIKeyName, params.DescriptionAPIKeyName).
+		AddResponse(http.StatusNotFound, "User or API key not found", nil, nil).
+		AddResponse(http.StatusOK, "API key successfully deleted", nil, nil).
+		SetOperationId("deleteAPIKey").
+		SetSummary("Delete an API key of a user")
+
+	adminAPI.POST("users/:username/revoke-tokens", c.revokeUserTokens, authentication.ValidatePermissions([]string{permissions.UsersWrite})).
+		AddParamPath("", params.ParamUsername, params.DescriptionUsername).
+		AddResponse(http.StatusNotFound, "User not found", nil, nil).
+		AddResponse(http.StatusOK, "All tokens of the user were revoked", nil, nil).
+		SetOperationId("revokeUserTokens").
+		SetSummary("Revoke all tokens issued to a user (the API keys are not affected)")
 }
@@ -0,0 +1,109 @@
+package users_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/pangpanglabs/echoswagger/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/authentication"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
+	"github.com/iotaledger/wasp/packages/cryptolib"
+	"github.com/iotaledger/wasp/packages/users"
+	"github.com/iotaledger/wasp/packages/webapi"
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	webapiusers "github.com/iotaledger/wasp/packages/webapi/controllers/users"
+	"github.com/iotaledger/wasp/packages/webapi/services"
+)
+
+// newTestServer serves the users routes, authenticated by the token of the
+// returned operator, who holds only the users:write permission.
+func newTestServer(t *testing.T) (*echo.Echo, *users.UserManager, string) {
+	userManager := users.NewUserManager(func([]*users.User) error {
+		return nil
+	})
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "admin",
+		Permissions: map[string]struct{}{permissions.Write: {}},
+	}))
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "operator",
+		Permissions: map[string]struct{}{permissions.UsersWrite: {}},
+	}))
+	require.NoError(t, userManager.AddUser(&users.User{
+		Name:        "peer",
+		Permissions: map[string]struct{}{permissions.UsersWrite: {}},
+	}))
+
+	nodeIDKeypair := cryptolib.KeyPairFromSeed(cryptolib.SeedFromBytes([]byte("abc")))
+	jwtAuth, middleware := authentication.GetJWTAuthMiddleware(
+		authentication.JWTAuthConfiguration{Duration: time.Hour},
+		nodeIDKeypair,
+		userManager,
+	)
+	token, err := jwtAuth.IssueJWT("operator", &authentication.WaspClaims{
+		Permissions: map[string]struct{}{permissions.UsersWrite: {}},
+	})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.HTTPErrorHandler = apierrors.HTTPErrorHandler()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("auth", &authentication.AuthContext{})
+			return next(c)
+		}
+	})
+	e.Use(middleware)
+	server := echoswagger.New(e, "/doc", &echoswagger.Info{Title: "Test Wasp API"})
+	c := webapiusers.NewUsersController(services.NewUserService(userManager))
+	c.RegisterAdmin(server.Group(c.Name(), "/v0/"), webapi.NewMocker())
+
+	return e, userManager, token
+}
+
+func call(e *echo.Echo, token, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	return res
+}
+
+func TestAPIKeysRequireTargetUserPermissions(t *testing.T) {
+	e, userManager, token := newTestServer(t)
+
+	_, _, err := userManager.AddAPIKey("admin", "existing", map[string]struct{}{permissions.Write: {}}, time.Time{})
+	require.NoError(t, err)
+
+	// an API key with no permissions would still identify as the admin
+	res := call(e, token, http.MethodPost, "/v0/users/admin/apikeys", `{"name":"key","permissions":[]}`)
+	require.Equal(t, http.StatusUnauthorized, res.Code)
+
+	res = call(e, token, http.MethodDelete, "/v0/users/admin/apikeys/existing", "")
+	require.Equal(t, http.StatusUnauthorized, res.Code)
+
+	res = call(e, token, http.MethodPost, "/v0/users/admin/revoke-tokens", "")
+	require.Equal(t, http.StatusUnauthorized, res.Code)
+
+	admin, err := userManager.User("admin")
+	require.NoError(t, err)
+	require.Len(t, admin.APIKeys, 1)
+	require.True(t, admin.TokensNotBefore.IsZero())
+
+	// the routes are allowed for users without more permissions
+	res = call(e, token, http.MethodPost, "/v0/users/peer/apikeys", `{"name":"key","permissions":[]}`)
+	require.Equal(t, http.StatusCreated, res.Code)
+
+	res = call(e, token, http.MethodDelete, "/v0/users/peer/apikeys/key", "")
+	require.Equal(t, http.StatusOK, res.Code)
+
+	res = call(e, token, http.MethodPost, "/v0/users/peer/revoke-tokens", "")
+	require.Equal(t, http.StatusOK, res.Code)
+}
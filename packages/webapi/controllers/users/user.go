@@ -7,6 +7,8 @@ import (
 	"github.com/labstack/echo/v4"
 
 	"github.com/iotaledger/wasp/packages/authentication"
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
+	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/webapi/apierrors"
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
 	"github.com/iotaledger/wasp/packages/webapi/models"
@@ -20,6 +22,10 @@ func (c *Controller) addUser(e echo.Context) error {
 		return apierrors.InvalidPropertyError("body", err)
 	}
 
+	if err := validatePermissions(e, addUserModel.Permissions); err != nil {
+		return err
+	}
+
 	if err := c.userService.AddUser(addUserModel.Username, addUserModel.Password, addUserModel.Permissions); err != nil {
 		panic(err)
 	}
@@ -40,6 +46,10 @@ func (c *Controller) updateUserPassword(e echo.Context) error {
 		return apierrors.InvalidPropertyError("body", err)
 	}
 
+	if err := c.validateTargetUser(e, userName); err != nil {
+		return err
+	}
+
 	if err := c.userService.UpdateUserPassword(userName, updateUserPasswordModel.Password); err != nil {
 		return apierrors.UserNotFoundError(userName)
 	}
@@ -65,7 +75,14 @@ func (c *Controller) updateUserPermissions(e echo.Context) error {
 		return apierrors.InvalidPropertyError("body", err)
 	}
 
-	// TODO: Later on, compare the permissions with the permission Wasp actually uses.
+	if err := c.validateTargetUser(e, userName); err != nil {
+		return err
+	}
+
+	if err := validatePermissions(e, updateUserPermissionsModel.Permissions); err != nil {
+		return err
+	}
+
 	if err := c.userService.UpdateUserPermissions(userName, updateUserPermissionsModel.Permissions); err != nil {
 		return apierrors.UserNotFoundError(userName)
 	}
@@ -73,6 +90,51 @@ func (c *Controller) updateUserPermissions(e echo.Context) error {
 	return e.NoContent(http.StatusOK)
 }
 
+// validatePermissions checks that the permissions are valid, and that the
+// user of the request holds them, so that no one can grant more than they have.
+func validatePermissions(e echo.Context, userPermissions []string) error {
+	authContext := e.Get("auth").(*authentication.AuthContext)
+
+	for _, permission := range userPermissions {
+		_, chainID, _, err := permissions.Parse(permission)
+		if err != nil {
+			return apierrors.InvalidPropertyError("permissions", err)
+		}
+
+		if chainID != "" {
+			if _, err := isc.ChainIDFromString(chainID); err != nil {
+				return apierrors.InvalidPropertyError("permissions", err)
+			}
+		}
+
+		if !authContext.IsGranted(permission) {
+			return apierrors.MissingPermissionError(permission)
+		}
+	}
+
+	return nil
+}
+
+// validateTargetUser checks that the user of the request holds every
+// permission of the user being modified, so that no one can take over, strip
+// or delete an account with more permissions than they have.
+func (c *Controller) validateTargetUser(e echo.Context, userName string) error {
+	authContext := e.Get("auth").(*authentication.AuthContext)
+
+	user, err := c.userService.GetUser(userName)
+	if err != nil {
+		return apierrors.UserNotFoundError(userName)
+	}
+
+	for _, permission := range user.Permissions {
+		if !authContext.IsGranted(permission) {
+			return apierrors.MissingPermissionError(permission)
+		}
+	}
+
+	return nil
+}
+
 func (c *Controller) deleteUser(e echo.Context) error {
 	userName := e.Param(params.ParamUsername)
 	authContext := e.Get("auth").(*authentication.AuthContext)
@@ -85,6 +147,10 @@ func (c *Controller) deleteUser(e echo.Context) error {
 		return apierrors.InvalidPropertyError(params.ParamUsername, errors.New("username is empty"))
 	}
 
+	if err := c.validateTargetUser(e, userName); err != nil {
+		return err
+	}
+
 	if err := c.userService.DeleteUser(userName); err != nil {
 		if errors.Is(err, interfaces.ErrCantDeleteLastUser) {
 			return apierrors.UserCanNotBeDeleted(userName, err.Error())
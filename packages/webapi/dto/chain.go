@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/trie"
 	"github.com/iotaledger/wasp/packages/vm/core/root"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 )
@@ -10,6 +11,17 @@ type (
 	ContractsMap map[isc.Hname]*root.ContractRecord
 )
 
+type StateDiff struct {
+	FromBlockIndex uint32
+	FromTrieRoot   trie.Hash
+	ToBlockIndex   uint32
+	ToTrieRoot     trie.Hash
+	Entries        []*trie.KeyDiff
+	// NextKey is the key, from which the next page starts; nil if there are
+	// no more keys.
+	NextKey []byte
+}
+
 type PublicChainMetadata struct {
 	EVMJsonRPCURL   string `json:"evmJsonRpcUrl" swagger:"desc(The EVM json rpc url),required"`
 	EVMWebSocketURL string `json:"evmWebSocketUrl" swagger:"desc(The EVM websocket url)),required"`
@@ -39,6 +39,7 @@ type ChainService interface {
 	GetContracts(chainID isc.ChainID, blockIndexOrTrieRoot string) (dto.ContractsMap, error)
 	GetEVMChainID(chainID isc.ChainID, blockIndexOrTrieRoot string) (uint16, error)
 	GetState(chainID isc.ChainID, stateKey []byte) (state []byte, err error)
+	GetStateDiff(chainID isc.ChainID, fromBlockIndexOrTrieRoot, toBlockIndexOrTrieRoot string, contract *isc.Hname, fromKey []byte, limit int) (*dto.StateDiff, error)
 	WaitForRequestProcessed(ctx context.Context, chainID isc.ChainID, requestID isc.RequestID, waitForL1Confirmation bool, timeout time.Duration) (*isc.Receipt, error)
 }
 
@@ -61,6 +62,7 @@ type NodeService interface {
 	AddAccessNode(chainID isc.ChainID, peer string) error
 	DeleteAccessNode(chainID isc.ChainID, peer string) error
 	NodeOwnerCertificate() []byte
+	NodeRetention() *models.NodeRetention
 	ShutdownNode()
 }
 
@@ -94,6 +96,10 @@ type UserService interface {
 	GetUsers() []*models.User
 	UpdateUserPassword(username string, password string) error
 	UpdateUserPermissions(username string, permissions []string) error
+	AddAPIKey(username string, name string, permissions []string, expiresAt time.Time) (*models.AddAPIKeyResponse, error)
+	DeleteAPIKey(username string, name string) error
+	GetAPIKeys(username string) ([]*models.UserAPIKey, error)
+	RevokeUserTokens(username string) error
 }
 
 type Mocker interface {
@@ -0,0 +1,37 @@
+package models
+
+import (
+	"time"
+
+	"github.com/iotaledger/wasp/packages/webapi/auditlog"
+)
+
+type AuditLogEntry struct {
+	Timestamp    time.Time         `json:"timestamp" swagger:"desc(The time of the call),required"`
+	User         string            `json:"user" swagger:"desc(The authenticated user),required"`
+	SourceIP     string            `json:"sourceIP" swagger:"desc(The IP address the call came from),required"`
+	ForwardedFor string            `json:"forwardedFor,omitempty" swagger:"desc(The X-Forwarded-For header of the call)"`
+	Method       string            `json:"method" swagger:"desc(The HTTP method),required"`
+	Route        string            `json:"route" swagger:"desc(The route pattern),required"`
+	Path         string            `json:"path" swagger:"desc(The called path),required"`
+	Params       map[string]string `json:"params,omitempty" swagger:"desc(The path and query parameters)"`
+	Body         string            `json:"body,omitempty" swagger:"desc(The request body (JSON) with the sensitive fields redacted)"`
+	Status       int               `json:"status" swagger:"desc(The HTTP status of the response),required"`
+	Error        string            `json:"error,omitempty" swagger:"desc(The error of the call, if it failed)"`
+}
+
+func MapAuditLogEntry(entry *auditlog.Entry) AuditLogEntry {
+	return AuditLogEntry{
+		Timestamp:    entry.Timestamp,
+		User:         entry.User,
+		SourceIP:     entry.SourceIP,
+		ForwardedFor: entry.ForwardedFor,
+		Method:       entry.Method,
+		Route:        entry.Route,
+		Path:         entry.Path,
+		Params:       entry.Params,
+		Body:         string(entry.Body),
+		Status:       entry.Status,
+		Error:        entry.Error,
+	}
+}
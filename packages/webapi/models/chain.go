@@ -3,6 +3,8 @@ package models
 import (
 	"net/url"
 
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
 	"github.com/iotaledger/wasp/packages/vm/gas"
 	"github.com/iotaledger/wasp/packages/webapi/dto"
 	"github.com/iotaledger/wasp/packages/webapi/routes"
@@ -77,6 +79,53 @@ type StateResponse struct {
 	State string `json:"state" swagger:"desc(The state of the requested key (Hex-encoded)),required"`
 }
 
+type StateDiffEntry struct {
+	ContractHName string `json:"contractHName" swagger:"desc(The hname of the contract owning the key (Hex), empty if the key is not a contract key),required"`
+	Key           string `json:"key" swagger:"desc(The state key (Hex-encoded)),required"`
+	OldValue      string `json:"oldValue" swagger:"desc(The old value (Hex-encoded), empty if the key was added),required"`
+	NewValue      string `json:"newValue" swagger:"desc(The new value (Hex-encoded), empty if the key was deleted),required"`
+}
+
+type StateDiffResponse struct {
+	FromBlockIndex uint32           `json:"fromBlockIndex" swagger:"desc(The block index of the old state),required,min(0)"`
+	FromTrieRoot   string           `json:"fromTrieRoot" swagger:"desc(The trie root of the old state (Hex)),required"`
+	ToBlockIndex   uint32           `json:"toBlockIndex" swagger:"desc(The block index of the new state),required,min(0)"`
+	ToTrieRoot     string           `json:"toTrieRoot" swagger:"desc(The trie root of the new state (Hex)),required"`
+	Entries        []StateDiffEntry `json:"entries" swagger:"desc(The keys which were added, updated or deleted, sorted by key),required"`
+	NextKey        string           `json:"nextKey" swagger:"desc(The key (Hex-encoded) to pass as continuationKey to get the next page, empty if there are no more keys),required"`
+}
+
+func MapStateDiffResponse(diff *dto.StateDiff) *StateDiffResponse {
+	entries := make([]StateDiffEntry, len(diff.Entries))
+	for i, entry := range diff.Entries {
+		entries[i] = StateDiffEntry{
+			Key:      iotago.EncodeHex(entry.Key),
+			OldValue: encodeHexOrEmpty(entry.OldValue),
+			NewValue: encodeHexOrEmpty(entry.NewValue),
+		}
+		if len(entry.Key) >= isc.HnameLength {
+			if hname, err := isc.HnameFromBytes(entry.Key[:isc.HnameLength]); err == nil {
+				entries[i].ContractHName = hname.String()
+			}
+		}
+	}
+	return &StateDiffResponse{
+		FromBlockIndex: diff.FromBlockIndex,
+		FromTrieRoot:   iotago.EncodeHex(diff.FromTrieRoot.Bytes()),
+		ToBlockIndex:   diff.ToBlockIndex,
+		ToTrieRoot:     iotago.EncodeHex(diff.ToTrieRoot.Bytes()),
+		Entries:        entries,
+		NextKey:        encodeHexOrEmpty(diff.NextKey),
+	}
+}
+
+func encodeHexOrEmpty(b []byte) string {
+	if b == nil {
+		return ""
+	}
+	return iotago.EncodeHex(b)
+}
+
 func mapMetadataUrls(response *ChainInfoResponse) {
 	if response.PublicURL == "" {
 		return
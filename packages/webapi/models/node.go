@@ -76,8 +76,19 @@ type VersionResponse struct {
 }
 
 type InfoResponse struct {
-	Version    string    `json:"version" swagger:"desc(The version of the node),required"`
-	PublicKey  string    `json:"publicKey" swagger:"desc(The public key of the node (Hex)),required"`
-	PeeringURL string    `json:"peeringURL" swagger:"desc(The net id of the node),required"`
-	L1Params   *L1Params `json:"l1Params" swagger:"desc(The L1 parameters),required"`
+	Version    string         `json:"version" swagger:"desc(The version of the node),required"`
+	PublicKey  string         `json:"publicKey" swagger:"desc(The public key of the node (Hex)),required"`
+	PeeringURL string         `json:"peeringURL" swagger:"desc(The net id of the node),required"`
+	L1Params   *L1Params      `json:"l1Params" swagger:"desc(The L1 parameters),required"`
+	Retention  *NodeRetention `json:"retention" swagger:"desc(The history retention of the node),required"`
+}
+
+// NodeRetention describes how many latest blocks the node keeps for each of
+// the data classes; 0 means that the whole history is kept.
+type NodeRetention struct {
+	Mode     string `json:"mode" swagger:"desc(The node mode: archive, full or pruned),required"`
+	States   uint32 `json:"states" swagger:"desc(How many latest trie states are kept),required,min(0)"`
+	Blocks   uint32 `json:"blocks" swagger:"desc(How many latest blocks are kept in the write-ahead log),required,min(0)"`
+	Receipts uint32 `json:"receipts" swagger:"desc(For how many latest blocks the receipts and events are available),required,min(0)"`
+	EVMIndex uint32 `json:"evmIndex" swagger:"desc(How many latest blocks are kept in the EVM JSON-RPC index),required,min(0)"`
 }
@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type User struct {
 	Username    string   `json:"username" swagger:"required"`
 	Permissions []string `json:"permissions" swagger:"required"`
@@ -18,3 +20,21 @@ type UpdateUserPasswordRequest struct {
 type UpdateUserPermissionsRequest struct {
 	Permissions []string `json:"permissions" swagger:"required"`
 }
+
+type UserAPIKey struct {
+	Name        string     `json:"name" swagger:"required"`
+	Permissions []string   `json:"permissions" swagger:"required"`
+	CreatedAt   time.Time  `json:"createdAt" swagger:"required"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" swagger:"desc(The expiry time of the API key, omitted if it doesn't expire)"`
+}
+
+type AddAPIKeyRequest struct {
+	Name        string     `json:"name" swagger:"required"`
+	Permissions []string   `json:"permissions" swagger:"desc(The permissions of the API key, they must be granted to the user),required"`
+	ExpiresAt   *time.Time `json:"expiresAt,omitempty" swagger:"desc(The expiry time of the API key, it doesn't expire if omitted)"`
+}
+
+type AddAPIKeyResponse struct {
+	UserAPIKey
+	Key string `json:"key" swagger:"desc(The API key, to be used as a Bearer token. It can't be retrieved again.),required"`
+}
@@ -15,7 +15,17 @@ const (
 	ParamStateKey             = "stateKey"
 	ParamTxHash               = "txHash"
 	ParamUsername             = "username"
+	ParamAPIKeyName           = "apiKeyName"
 	ParamBlockIndexOrTrieRoot = "block"
+	ParamFromBlock            = "from"
+	ParamToBlock              = "to"
+	ParamUser                 = "user"
+	ParamMethod               = "method"
+	ParamRoute                = "route"
+	ParamSince                = "since"
+	ParamUntil                = "until"
+	ParamLimit                = "limit"
+	ParamContinuationKey      = "continuationKey"
 )
 
 const (
@@ -31,5 +41,16 @@ const (
 	DescriptionStateKey             = "State Key (Hex)"
 	DescriptionTxHash               = "Transaction hash (Hex)"
 	DescriptionUsername             = "The username"
+	DescriptionAPIKeyName           = "The name of the API key"
 	DescriptionBlockIndexOrTrieRoot = "Block index or trie root"
+	DescriptionFromBlock            = "Block index or trie root of the old state"
+	DescriptionToBlock              = "Block index or trie root of the new state (latest, if omitted)"
+	DescriptionUser                 = "Only the calls of this user"
+	DescriptionMethod               = "Only the calls with this HTTP method"
+	DescriptionRoute                = "Only the calls to routes containing this string"
+	DescriptionSince                = "Only the calls at or after this time (RFC3339)"
+	DescriptionUntil                = "Only the calls before this time (RFC3339)"
+	DescriptionLimit                = "The maximum amount of entries returned, newest first (default: 100, 0 = all)"
+	DescriptionStateDiffLimit       = "The maximum amount of keys returned (default: 1000, max: 10000)"
+	DescriptionContinuationKey      = "The key (Hex), from which the page starts: the nextKey of the previous page"
 )
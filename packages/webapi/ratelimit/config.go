@@ -0,0 +1,36 @@
+package ratelimit
+
+import "time"
+
+// Budget is a token bucket: RequestsPerSecond tokens are added to the bucket
+// of each client every second, up to Burst tokens.
+type Budget struct {
+	RequestsPerSecond float64 `usage:"the sustained amount of requests per second allowed per client. 0 = unlimited"`
+	Burst             int     `usage:"the maximum amount of requests allowed at once per client"`
+}
+
+type Config struct {
+	Enabled bool `default:"false" usage:"whether the rate limiting of the web API and EVM JSON-RPC requests per client is enabled"`
+
+	// Default is consumed by every request
+	Default Budget `usage:"the budget for all requests"`
+	// Expensive is consumed, in addition, by each call to an expensive route
+	// (e.g. callview, estimategas, eth_call, eth_getLogs)
+	Expensive Budget `usage:"the additional budget for the expensive requests"`
+
+	ClientExpiration      time.Duration `default:"10m" usage:"the time after which the buckets of an idle client are removed"`
+	TrustForwardedHeaders bool          `default:"false" usage:"whether the client IP is taken from the X-Forwarded-For/X-Real-IP headers (only behind a trusted reverse proxy)"`
+}
+
+var DefaultConfig = Config{
+	Enabled: false,
+	Default: Budget{
+		RequestsPerSecond: 20,
+		Burst:             100,
+	},
+	Expensive: Budget{
+		RequestsPerSecond: 5,
+		Burst:             20,
+	},
+	ClientExpiration: 10 * time.Minute,
+}
@@ -0,0 +1,246 @@
+// Package ratelimit limits the rate of the web API and EVM JSON-RPC requests
+// per client, with a token bucket per client for each Budget.
+//
+// A client is the authenticated user, if the request carries a valid token,
+// or the IP address otherwise. Every request consumes a token of the Default
+// budget; the calls to expensive routes (see IsExpensiveRoute and
+// ExpensiveEVMMethods) consume a token each of the Expensive budget too.
+// Rejected requests are answered with 429 Too Many Requests.
+package ratelimit
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+
+	"github.com/iotaledger/wasp/packages/util"
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/routes"
+)
+
+// MaxInspectedBodySize is the size up to which the EVM JSON-RPC request bodies
+// are inspected for calls to expensive methods. Larger bodies are not parsed,
+// they consume a single token of the Expensive budget.
+const MaxInspectedBodySize = 1024 * 1024
+
+const (
+	BudgetDefault   = "default"
+	BudgetExpensive = "expensive"
+
+	ClientTypeIP   = "ip"
+	ClientTypeUser = "user"
+)
+
+// ExpensiveRoutes are the suffixes of the web API routes consuming the Expensive budget
+var ExpensiveRoutes = []string{
+	"/callview",
+	"/estimategas-onledger",
+	"/estimategas-offledger",
+}
+
+// ExpensiveEVMMethods are the EVM JSON-RPC methods consuming the Expensive budget
+var ExpensiveEVMMethods = map[string]bool{
+	"eth_call":                 true,
+	"eth_estimateGas":          true,
+	"eth_getLogs":              true,
+	"debug_traceTransaction":   true,
+	"debug_traceBlockByNumber": true,
+	"debug_traceBlockByHash":   true,
+	"debug_traceCall":          true,
+}
+
+// Metrics is implemented by the prometheus metrics of the node
+type Metrics interface {
+	RateLimitedRequest(budget string, clientType string, allowed bool)
+}
+
+// ClientIdentifier returns the name of the authenticated user of the request, or "" if none
+type ClientIdentifier func(c echo.Context) string
+
+type Limiter struct {
+	config   Config
+	metrics  Metrics
+	username ClientIdentifier
+
+	defaultBuckets   *buckets
+	expensiveBuckets *buckets
+}
+
+func New(config Config, metrics Metrics, username ClientIdentifier) *Limiter {
+	return &Limiter{
+		config:           config,
+		metrics:          metrics,
+		username:         username,
+		defaultBuckets:   newBuckets(config.Default, config.ClientExpiration),
+		expensiveBuckets: newBuckets(config.Expensive, config.ClientExpiration),
+	}
+}
+
+func (l *Limiter) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if !l.config.Enabled {
+				return next(c)
+			}
+
+			clientType, clientID := ClientTypeIP, l.clientIP(c)
+			if l.username != nil {
+				if username := l.username(c); username != "" {
+					clientType, clientID = ClientTypeUser, username
+				}
+			}
+			clientID = clientType + ":" + clientID
+
+			now := time.Now()
+			if !l.allow(l.defaultBuckets, BudgetDefault, clientType, clientID, 1, now) {
+				return tooManyRequests(c)
+			}
+			if n := expensiveCalls(c); n > 0 &&
+				!l.allow(l.expensiveBuckets, BudgetExpensive, clientType, clientID, n, now) {
+				return tooManyRequests(c)
+			}
+			return next(c)
+		}
+	}
+}
+
+func (l *Limiter) clientIP(c echo.Context) string {
+	if l.config.TrustForwardedHeaders {
+		return c.RealIP()
+	}
+	return echo.ExtractIPDirect()(c.Request())
+}
+
+func (l *Limiter) allow(b *buckets, budget, clientType, clientID string, n int, now time.Time) bool {
+	if b == nil {
+		return true
+	}
+	allowed := b.allow(clientID, n, now)
+	if l.metrics != nil {
+		l.metrics.RateLimitedRequest(budget, clientType, allowed)
+	}
+	return allowed
+}
+
+func tooManyRequests(c echo.Context) error {
+	c.Response().Header().Set(echo.HeaderRetryAfter, "1")
+	return apierrors.TooManyRequestsError()
+}
+
+// IsExpensiveRoute checks whether the given route path consumes the Expensive budget
+func IsExpensiveRoute(path string) bool {
+	for _, suffix := range ExpensiveRoutes {
+		if strings.HasSuffix(path, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// expensiveCalls returns the amount of tokens of the Expensive budget
+// consumed by the request, i.e. 1 for the expensive routes and the amount of
+// calls to the expensive methods in the EVM JSON-RPC requests (single or batch).
+func expensiveCalls(c echo.Context) int {
+	path := c.Path()
+	if IsExpensiveRoute(path) {
+		return 1
+	}
+	if c.Request().Method != http.MethodPost || !strings.HasSuffix(path, "/"+routes.EVMJsonRPCPathSuffix) {
+		return 0
+	}
+
+	body, err := util.PeekRequestBody(c.Request(), MaxInspectedBodySize)
+	if err != nil {
+		return 0
+	}
+	if len(body) > MaxInspectedBodySize {
+		return 1
+	}
+
+	type jsonRPCMessage struct {
+		Method string `json:"method"`
+	}
+	var msgs []jsonRPCMessage
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		if json.Unmarshal(body, &msgs) != nil {
+			return 0
+		}
+	} else {
+		var msg jsonRPCMessage
+		if json.Unmarshal(body, &msg) != nil {
+			return 0
+		}
+		msgs = append(msgs, msg)
+	}
+
+	n := 0
+	for _, msg := range msgs {
+		if ExpensiveEVMMethods[msg.Method] {
+			n++
+		}
+	}
+	return n
+}
+
+// buckets holds the token bucket of each client for a Budget
+type buckets struct {
+	budget     Budget
+	expiration time.Duration
+
+	mutex       sync.Mutex
+	clients     map[string]*bucket
+	lastCleanup time.Time
+}
+
+type bucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+func newBuckets(budget Budget, expiration time.Duration) *buckets {
+	if budget.RequestsPerSecond <= 0 {
+		return nil
+	}
+	return &buckets{
+		budget:      budget,
+		expiration:  expiration,
+		clients:     make(map[string]*bucket),
+		lastCleanup: time.Now(),
+	}
+}
+
+func (b *buckets) allow(clientID string, n int, now time.Time) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if b.expiration > 0 && now.Sub(b.lastCleanup) > b.expiration {
+		for id, client := range b.clients {
+			if now.Sub(client.lastSeen) > b.expiration {
+				delete(b.clients, id)
+			}
+		}
+		b.lastCleanup = now
+	}
+
+	client, ok := b.clients[clientID]
+	if !ok {
+		burst := b.budget.Burst
+		if burst <= 0 {
+			burst = int(b.budget.RequestsPerSecond)
+		}
+		if burst <= 0 {
+			burst = 1
+		}
+		client = &bucket{limiter: rate.NewLimiter(rate.Limit(b.budget.RequestsPerSecond), burst)}
+		b.clients[clientID] = client
+	}
+	client.lastSeen = now
+	return client.limiter.AllowN(now, n)
+}
@@ -0,0 +1,137 @@
+package ratelimit_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/packages/webapi/apierrors"
+	"github.com/iotaledger/wasp/packages/webapi/ratelimit"
+)
+
+type testMetrics struct {
+	requests map[string]int
+}
+
+func (m *testMetrics) RateLimitedRequest(budget string, clientType string, allowed bool) {
+	key := budget + "/" + clientType
+	if !allowed {
+		key += "/rejected"
+	}
+	m.requests[key]++
+}
+
+func newTestServer(t *testing.T, config ratelimit.Config) (*echo.Echo, *testMetrics, *[]string) {
+	e := echo.New()
+	e.HTTPErrorHandler = apierrors.HTTPErrorHandler()
+	metrics := &testMetrics{requests: map[string]int{}}
+	e.Use(ratelimit.New(config, metrics, func(c echo.Context) string {
+		return c.Request().Header.Get("X-Test-User")
+	}).Middleware())
+
+	var bodies []string
+	handler := func(c echo.Context) error {
+		body, err := io.ReadAll(c.Request().Body)
+		require.NoError(t, err)
+		bodies = append(bodies, string(body))
+		return c.NoContent(http.StatusOK)
+	}
+	e.GET("/v1/chains/:chainID", handler)
+	e.POST("/v1/chains/:chainID/callview", handler)
+	e.POST("/v1/chains/:chainID/evm", handler)
+	return e, metrics, &bodies
+}
+
+func doRequest(e *echo.Echo, method, path, body, remoteAddr, user string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.RemoteAddr = remoteAddr
+	req.Header.Set("X-Forwarded-For", "10.0.0.1")
+	if user != "" {
+		req.Header.Set("X-Test-User", user)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestDefaultBudget(t *testing.T) {
+	e, metrics, _ := newTestServer(t, ratelimit.Config{
+		Enabled:          true,
+		Default:          ratelimit.Budget{RequestsPerSecond: 0.001, Burst: 3},
+		ClientExpiration: time.Minute,
+	})
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, doRequest(e, http.MethodGet, "/v1/chains/foo", "", "1.1.1.1:1000", "").Code)
+	}
+	rec := doRequest(e, http.MethodGet, "/v1/chains/foo", "", "1.1.1.1:1001", "")
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	require.NotEmpty(t, rec.Header().Get(echo.HeaderRetryAfter))
+
+	// the forwarded headers are not trusted, other IPs and users have their own budget
+	require.Equal(t, http.StatusOK, doRequest(e, http.MethodGet, "/v1/chains/foo", "", "2.2.2.2:1000", "").Code)
+	require.Equal(t, http.StatusOK, doRequest(e, http.MethodGet, "/v1/chains/foo", "", "1.1.1.1:1000", "alice").Code)
+
+	require.Equal(t, map[string]int{
+		"default/ip":          4,
+		"default/ip/rejected": 1,
+		"default/user":        1,
+	}, metrics.requests)
+}
+
+func TestExpensiveBudget(t *testing.T) {
+	e, metrics, bodies := newTestServer(t, ratelimit.Config{
+		Enabled:   true,
+		Expensive: ratelimit.Budget{RequestsPerSecond: 0.001, Burst: 3},
+	})
+	const ip = "1.1.1.1:1000"
+
+	// the cheap requests are not limited
+	for i := 0; i < 10; i++ {
+		require.Equal(t, http.StatusOK, doRequest(e, http.MethodGet, "/v1/chains/foo", "", ip, "").Code)
+		require.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/v1/chains/foo/evm", `{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`, ip, "").Code)
+	}
+
+	// a batch of 2 eth_call and a callview exhaust the budget
+	batch := `[{"jsonrpc":"2.0","id":1,"method":"eth_call"},{"jsonrpc":"2.0","id":2,"method":"eth_chainId"},{"jsonrpc":"2.0","id":3,"method":"eth_call"}]`
+	require.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/v1/chains/foo/evm", batch, ip, "").Code)
+	require.Equal(t, batch, (*bodies)[len(*bodies)-1], "the handler gets the whole body")
+	require.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/v1/chains/foo/callview", "{}", ip, "").Code)
+	require.Equal(t, http.StatusTooManyRequests, doRequest(e, http.MethodPost, "/v1/chains/foo/evm", `{"method":"eth_getLogs"}`, ip, "").Code)
+	require.Equal(t, http.StatusTooManyRequests, doRequest(e, http.MethodPost, "/v1/chains/foo/callview", "{}", ip, "").Code)
+	require.Equal(t, http.StatusOK, doRequest(e, http.MethodGet, "/v1/chains/foo", "", ip, "").Code)
+
+	require.Equal(t, map[string]int{
+		"expensive/ip":          2,
+		"expensive/ip/rejected": 2,
+	}, metrics.requests)
+}
+
+func TestExpensiveBudgetLargeBody(t *testing.T) {
+	e, metrics, bodies := newTestServer(t, ratelimit.Config{
+		Enabled:   true,
+		Expensive: ratelimit.Budget{RequestsPerSecond: 0.001, Burst: 3},
+	})
+
+	// a body too large to be inspected consumes a single token, and is passed on as a whole
+	body := `{"method":"eth_call","params":["` + strings.Repeat("a", ratelimit.MaxInspectedBodySize) + `"]}`
+	require.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/v1/chains/foo/evm", body, "1.1.1.1:1000", "").Code)
+	require.Equal(t, body, (*bodies)[len(*bodies)-1])
+	require.Equal(t, map[string]int{"expensive/ip": 1}, metrics.requests)
+}
+
+func TestDisabled(t *testing.T) {
+	config := ratelimit.DefaultConfig
+	config.Default.Burst = 1
+	e, metrics, _ := newTestServer(t, config)
+	for i := 0; i < 10; i++ {
+		require.Equal(t, http.StatusOK, doRequest(e, http.MethodGet, "/v1/chains/foo", "", "1.1.1.1:1000", "").Code)
+	}
+	require.Empty(t, metrics.requests)
+}
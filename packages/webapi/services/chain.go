@@ -207,6 +207,44 @@ func (c *ChainService) GetState(chainID isc.ChainID, stateKey []byte) (state []b
 	return latestState.Get(kv.Key(stateKey)), nil
 }
 
+func (c *ChainService) GetStateDiff(chainID isc.ChainID, fromBlockIndexOrTrieRoot, toBlockIndexOrTrieRoot string, contract *isc.Hname, fromKey []byte, limit int) (*dto.StateDiff, error) {
+	ch, err := c.GetChainByID(chainID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromState, err := common.StateByBlockIndexOrTrieRoot(ch, fromBlockIndexOrTrieRoot)
+	if err != nil {
+		return nil, err
+	}
+	toState, err := common.StateByBlockIndexOrTrieRoot(ch, toBlockIndexOrTrieRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	var prefix kv.Key
+	if contract != nil {
+		prefix = kv.Key(contract.Bytes())
+	}
+	entries, nextKey, err := ch.Store().DiffStates(fromState.TrieRoot(), toState.TrieRoot(), prefix, kv.Key(fromKey), limit)
+	if err != nil {
+		return nil, err
+	}
+	var nextKeyBytes []byte
+	if nextKey != "" {
+		nextKeyBytes = []byte(nextKey)
+	}
+
+	return &dto.StateDiff{
+		FromBlockIndex: fromState.BlockIndex(),
+		FromTrieRoot:   fromState.TrieRoot(),
+		ToBlockIndex:   toState.BlockIndex(),
+		ToTrieRoot:     toState.TrieRoot(),
+		Entries:        entries,
+		NextKey:        nextKeyBytes,
+	}, nil
+}
+
 func (c *ChainService) WaitForRequestProcessed(ctx context.Context, chainID isc.ChainID, requestID isc.RequestID, waitForL1Confirmation bool, timeout time.Duration) (*isc.Receipt, error) {
 	ch, err := c.GetChainByID(chainID)
 	if err != nil {
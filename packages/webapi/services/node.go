@@ -10,6 +10,7 @@ import (
 	"github.com/iotaledger/wasp/packages/registry"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
 	"github.com/iotaledger/wasp/packages/webapi/interfaces"
+	"github.com/iotaledger/wasp/packages/webapi/models"
 )
 
 type NodeService struct {
@@ -71,6 +72,17 @@ func (n *NodeService) NodeOwnerCertificate() []byte {
 	return governance.NewNodeOwnershipCertificate(nodeIdentity, n.chainsProvider().ValidatorAddress())
 }
 
+func (n *NodeService) NodeRetention() *models.NodeRetention {
+	retention := n.chainsProvider().Retention()
+	return &models.NodeRetention{
+		Mode:     string(retention.Mode),
+		States:   retention.States,
+		Blocks:   retention.Blocks,
+		Receipts: retention.Receipts,
+		EVMIndex: retention.EVMIndex,
+	}
+}
+
 func (n *NodeService) ShutdownNode() {
 	n.shutdownHandler.SelfShutdown("wasp was shutdown via API", false)
 }
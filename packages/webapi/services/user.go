@@ -1,6 +1,9 @@
 package services
 
 import (
+	"sort"
+	"time"
+
 	"golang.org/x/exp/maps"
 
 	"github.com/iotaledger/wasp/packages/users"
@@ -98,3 +101,52 @@ func (u *UserService) GetUser(username string) (*models.User, error) {
 		Permissions: permissionsFromMap(user.Permissions),
 	}, nil
 }
+
+func apiKeyToModel(apiKey *users.APIKey) *models.UserAPIKey {
+	model := &models.UserAPIKey{
+		Name:        apiKey.Name,
+		Permissions: permissionsFromMap(apiKey.Permissions),
+		CreatedAt:   apiKey.CreatedAt,
+	}
+	if !apiKey.ExpiresAt.IsZero() {
+		expiresAt := apiKey.ExpiresAt
+		model.ExpiresAt = &expiresAt
+	}
+
+	return model
+}
+
+func (u *UserService) AddAPIKey(username, name string, permissions []string, expiresAt time.Time) (*models.AddAPIKeyResponse, error) {
+	key, apiKey, err := u.userManager.AddAPIKey(username, name, permissionsToMap(permissions), expiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.AddAPIKeyResponse{
+		UserAPIKey: *apiKeyToModel(apiKey),
+		Key:        key,
+	}, nil
+}
+
+func (u *UserService) DeleteAPIKey(username, name string) error {
+	return u.userManager.RemoveAPIKey(username, name)
+}
+
+func (u *UserService) GetAPIKeys(username string) ([]*models.UserAPIKey, error) {
+	user, err := u.userManager.User(username)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeys := make([]*models.UserAPIKey, 0, len(user.APIKeys))
+	for _, apiKey := range user.APIKeys {
+		apiKeys = append(apiKeys, apiKeyToModel(apiKey))
+	}
+	sort.Slice(apiKeys, func(i, j int) bool { return apiKeys[i].Name < apiKeys[j].Name })
+
+	return apiKeys, nil
+}
+
+func (u *UserService) RevokeUserTokens(username string) error {
+	return u.userManager.RevokeAllTokens(username)
+}
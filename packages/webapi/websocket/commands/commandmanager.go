@@ -40,7 +40,9 @@ type CommandManager struct {
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
 }
 
-func NewCommandHandler(log *logger.Logger, subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]) *CommandManager {
+// NewCommandHandler creates the handler of the commands of the clients.
+// The filters are optional, without them the subscriptions can't be filtered.
+func NewCommandHandler(log *logger.Logger, subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string], filters SubscriptionFilters) *CommandManager {
 	return &CommandManager{
 		log: log,
 		commands: []CommandHandler{
@@ -48,6 +50,7 @@ func NewCommandHandler(log *logger.Logger, subscriptionManager *subscriptionmana
 			&SubscriptionCommandHandler{
 				log:                 log,
 				subscriptionManager: subscriptionManager,
+				filters:             filters,
 			},
 		},
 		subscriptionManager: subscriptionManager,
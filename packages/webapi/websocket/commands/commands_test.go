@@ -26,7 +26,7 @@ func initTest() (*CommandManager, *websockethub.Hub, context.CancelFunc) {
 	subscriptionManager := subscriptionmanager.New[websockethub.ClientID, string]()
 	subscriptionManager.Connect(1)
 
-	manager := NewCommandHandler(log, subscriptionManager)
+	manager := NewCommandHandler(log, subscriptionManager, nil)
 	hub := websockethub.NewHub(log.Named("Hub"), &websocketserver.AcceptOptions{InsecureSkipVerify: true}, 500, 500, 500)
 
 	go func() { hub.Run(ctx) }()
@@ -128,3 +128,71 @@ func TestFailingSubscriptionDueToInvalidCommand(t *testing.T) {
 	err := sendNodeCommand(manager, client, SubscriptionCommand{})
 	require.ErrorIs(t, errors.Unwrap(err), ErrFailedToValidateCommand)
 }
+
+type testFilters struct {
+	filters map[string]*SubscriptionFilter
+}
+
+func (f *testFilters) SetFilter(clientID websockethub.ClientID, topic string, filter *SubscriptionFilter) error {
+	if filter.Contract == "invalid" {
+		return errors.New("invalid contract")
+	}
+	f.filters[topic] = filter
+	return nil
+}
+
+func (f *testFilters) RemoveFilter(clientID websockethub.ClientID, topic string) {
+	delete(f.filters, topic)
+}
+
+func TestFilteredSubscription(t *testing.T) {
+	manager, hub, _ := initTest()
+	filters := &testFilters{filters: map[string]*SubscriptionFilter{}}
+	manager = NewCommandHandler(manager.log, manager.subscriptionManager, filters)
+	client := websockethub.NewClient(hub, nil, func(client *websockethub.Client) {}, func(client *websockethub.Client) {})
+
+	filter := &SubscriptionFilter{Contract: "abcd"}
+	_ = sendNodeCommand(manager, client, SubscriptionCommand{
+		BaseCommand: BaseCommand{
+			Command: CommandSubscribe,
+		},
+		Topic:  "TEST",
+		Filter: filter,
+	})
+	require.True(t, manager.subscriptionManager.TopicHasSubscribers("TEST"))
+	require.Equal(t, filter, filters.filters["TEST"])
+
+	// subscribing again without a filter removes it
+	_ = sendNodeCommand(manager, client, SubscriptionCommand{
+		BaseCommand: BaseCommand{
+			Command: CommandSubscribe,
+		},
+		Topic: "TEST",
+	})
+	require.Empty(t, filters.filters)
+
+	err := sendNodeCommand(manager, client, SubscriptionCommand{
+		BaseCommand: BaseCommand{
+			Command: CommandSubscribe,
+		},
+		Topic:  "INVALID",
+		Filter: &SubscriptionFilter{Contract: "invalid"},
+	})
+	require.ErrorIs(t, errors.Unwrap(err), ErrFailedToValidateCommand)
+	require.False(t, manager.subscriptionManager.TopicHasSubscribers("INVALID"))
+}
+
+func TestFailingFilteredSubscriptionWithoutFilters(t *testing.T) {
+	manager, hub, _ := initTest()
+	client := websockethub.NewClient(hub, nil, func(client *websockethub.Client) {}, func(client *websockethub.Client) {})
+
+	err := sendNodeCommand(manager, client, SubscriptionCommand{
+		BaseCommand: BaseCommand{
+			Command: CommandSubscribe,
+		},
+		Topic:  "TEST",
+		Filter: &SubscriptionFilter{Contract: "abcd"},
+	})
+	require.ErrorIs(t, errors.Unwrap(err), ErrFailedToValidateCommand)
+	require.False(t, manager.subscriptionManager.TopicHasSubscribers("TEST"))
+}
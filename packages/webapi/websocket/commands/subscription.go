@@ -18,6 +18,29 @@ const (
 type SubscriptionCommand struct {
 	BaseCommand
 	Topic string `json:"topic"`
+	// Filter is optional. Subscribing again to the same topic replaces the filter.
+	Filter *SubscriptionFilter `json:"filter,omitempty"`
+}
+
+// SubscriptionFilter restricts the events sent to a client for a subscribed
+// topic, it is evaluated by the node. Empty fields match all events.
+type SubscriptionFilter struct {
+	// Contract is the hname (hex) of the contract that issued the events (block_events),
+	// or that is targeted by the requests (receipt).
+	Contract string `json:"contract,omitempty"`
+	// TopicPrefix is the prefix of the topic of the events (block_events)
+	TopicPrefix string `json:"topicPrefix,omitempty"`
+	// Sender is the AgentID of the sender of the requests (receipt)
+	Sender string `json:"sender,omitempty"`
+	// RequestID is the ID of the request (receipt)
+	RequestID string `json:"requestID,omitempty"`
+}
+
+// SubscriptionFilters keeps the filters of the subscriptions of the clients.
+// SetFilter fails if the filter is invalid or not supported by the topic.
+type SubscriptionFilters interface {
+	SetFilter(clientID websockethub.ClientID, topic string, filter *SubscriptionFilter) error
+	RemoveFilter(clientID websockethub.ClientID, topic string)
 }
 
 const (
@@ -27,12 +50,14 @@ const (
 
 type SubscriptionEvent struct {
 	BaseEvent
-	Topic string `json:"topic"`
+	Topic  string              `json:"topic"`
+	Filter *SubscriptionFilter `json:"filter,omitempty"`
 }
 
 type SubscriptionCommandHandler struct {
 	log                 *logger.Logger
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
+	filters             SubscriptionFilters
 }
 
 func (s *SubscriptionCommandHandler) SupportsCommand(commandType CommandType) bool {
@@ -51,17 +76,35 @@ func (s *SubscriptionCommandHandler) HandleCommand(client *websockethub.Client,
 		return errors.Wrap(ErrFailedToValidateCommand, "Topic is empty")
 	}
 
+	if command.Filter != nil && (command.Command != CommandSubscribe || s.filters == nil) {
+		return errors.Wrap(ErrFailedToValidateCommand, "Filter is not supported")
+	}
+
 	switch command.Command {
 	case CommandSubscribe:
+		if s.filters != nil {
+			if command.Filter != nil {
+				if err = s.filters.SetFilter(client.ID(), command.Topic, command.Filter); err != nil {
+					return errors.Wrap(ErrFailedToValidateCommand, err.Error())
+				}
+			} else {
+				s.filters.RemoveFilter(client.ID(), command.Topic)
+			}
+		}
+
 		s.subscriptionManager.Subscribe(client.ID(), command.Topic)
 		err = client.Send(client.Context(), SubscriptionEvent{
 			BaseEvent: BaseEvent{
 				Event: EventClientWasSubscribed,
 			},
-			Topic: command.Topic,
+			Topic:  command.Topic,
+			Filter: command.Filter,
 		})
 
 	case CommandUnsubscribe:
+		if s.filters != nil {
+			s.filters.RemoveFilter(client.ID(), command.Topic)
+		}
 		s.subscriptionManager.Unsubscribe(client.ID(), command.Topic)
 		err = client.Send(client.Context(), SubscriptionEvent{
 			BaseEvent: BaseEvent{
@@ -0,0 +1,106 @@
+package websocket
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/websocket/commands"
+)
+
+// eventFilter is a parsed commands.SubscriptionFilter. The values are kept in
+// the string representation used by the ISCEvents, to compare them as they are.
+type eventFilter struct {
+	contract    string
+	topicPrefix string
+	sender      string
+	requestID   string
+}
+
+// parseEventFilter validates the filter of a subscription to the given topic
+func parseEventFilter(topic string, filter *commands.SubscriptionFilter) (*eventFilter, error) {
+	f := &eventFilter{
+		topicPrefix: filter.TopicPrefix,
+	}
+
+	if filter.Contract != "" {
+		hname, err := isc.HnameFromString(filter.Contract)
+		if err != nil {
+			return nil, fmt.Errorf("invalid contract: %w", err)
+		}
+		f.contract = hname.String()
+	}
+
+	if filter.Sender != "" {
+		sender, err := isc.AgentIDFromString(filter.Sender)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sender: %w", err)
+		}
+		f.sender = sender.String()
+	}
+
+	if filter.RequestID != "" {
+		requestID, err := isc.RequestIDFromString(filter.RequestID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid requestID: %w", err)
+		}
+		f.requestID = requestID.String()
+	}
+
+	switch publisher.ISCEventType(topic) {
+	case publisher.ISCEventKindReceipt:
+		if f.topicPrefix != "" {
+			return nil, fmt.Errorf("topic %q can't be filtered by the event topic", topic)
+		}
+	case publisher.ISCEventKindBlockEvents:
+		// the events don't carry the request they were issued by
+		if f.sender != "" || f.requestID != "" {
+			return nil, fmt.Errorf("topic %q can't be filtered by the sender or the request ID", topic)
+		}
+	default:
+		return nil, fmt.Errorf("topic %q can't be filtered", topic)
+	}
+
+	return f, nil
+}
+
+// apply returns the part of the event the filter lets through, or nil if there is none.
+func (f *eventFilter) apply(iscEvent *ISCEvent) *ISCEvent {
+	switch payload := iscEvent.Payload.(type) {
+	case *models.ReceiptResponse:
+		if f.contract != "" && payload.Request.CallTarget.ContractHName != f.contract {
+			return nil
+		}
+		if f.sender != "" && iscEvent.Issuer != f.sender {
+			return nil
+		}
+		if f.requestID != "" && iscEvent.RequestID != f.requestID {
+			return nil
+		}
+		return iscEvent
+
+	case []*isc.Event:
+		events := make([]*isc.Event, 0, len(payload))
+		for _, event := range payload {
+			if f.contract != "" && event.ContractID.String() != f.contract {
+				continue
+			}
+			if !strings.HasPrefix(event.Topic, f.topicPrefix) {
+				continue
+			}
+			events = append(events, event)
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		filtered := *iscEvent
+		filtered.Payload = events
+		return &filtered
+
+	default:
+		return iscEvent
+	}
+}
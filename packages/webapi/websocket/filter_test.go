@@ -0,0 +1,123 @@
+package websocket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/webapi/models"
+	"github.com/iotaledger/wasp/packages/webapi/websocket/commands"
+)
+
+func receiptEvent(contract isc.Hname, sender isc.AgentID, requestID isc.RequestID) *ISCEvent {
+	return &ISCEvent{
+		Kind:      publisher.ISCEventKindReceipt,
+		Issuer:    sender.String(),
+		RequestID: requestID.String(),
+		Payload: &models.ReceiptResponse{
+			Request: models.RequestDetail{
+				CallTarget: models.MapCallTarget(isc.NewCallTarget(contract, isc.Hn("fn"))),
+			},
+		},
+	}
+}
+
+func TestParseEventFilter(t *testing.T) {
+	sender := isc.NewRandomAgentID()
+	requestID := isc.NewRequestID(iotago.TransactionID{1, 2, 3}, 1)
+
+	f, err := parseEventFilter(string(publisher.ISCEventKindReceipt), &commands.SubscriptionFilter{
+		Contract:  "0000abcd",
+		Sender:    sender.String(),
+		RequestID: requestID.String(),
+	})
+	require.NoError(t, err)
+	require.Equal(t, &eventFilter{contract: "0000abcd", sender: sender.String(), requestID: requestID.String()}, f)
+
+	_, err = parseEventFilter(string(publisher.ISCEventKindBlockEvents), &commands.SubscriptionFilter{
+		Contract:    "abcd",
+		TopicPrefix: "coreaccounts.",
+	})
+	require.NoError(t, err)
+
+	for _, invalid := range []struct {
+		topic  publisher.ISCEventType
+		filter commands.SubscriptionFilter
+	}{
+		{publisher.ISCEventKindReceipt, commands.SubscriptionFilter{Contract: "not-hex"}},
+		{publisher.ISCEventKindReceipt, commands.SubscriptionFilter{Sender: "invalid"}},
+		{publisher.ISCEventKindReceipt, commands.SubscriptionFilter{RequestID: "0x1234"}},
+		{publisher.ISCEventKindReceipt, commands.SubscriptionFilter{TopicPrefix: "x"}},
+		{publisher.ISCEventKindBlockEvents, commands.SubscriptionFilter{Sender: sender.String()}},
+		{publisher.ISCEventKindBlockEvents, commands.SubscriptionFilter{RequestID: requestID.String()}},
+		{publisher.ISCEventKindNewBlock, commands.SubscriptionFilter{Contract: "abcd"}},
+		{"chains", commands.SubscriptionFilter{Contract: "abcd"}},
+	} {
+		_, err := parseEventFilter(string(invalid.topic), &invalid.filter)
+		require.Error(t, err, "%s %+v", invalid.topic, invalid.filter)
+	}
+}
+
+func TestEventFilterReceipts(t *testing.T) {
+	contract := isc.Hn("test")
+	sender := isc.NewRandomAgentID()
+	requestID := isc.NewRequestID(iotago.TransactionID{1, 2, 3}, 1)
+	event := receiptEvent(contract, sender, requestID)
+
+	for _, filter := range []commands.SubscriptionFilter{
+		{},
+		{Contract: contract.String()},
+		{Sender: sender.String()},
+		{RequestID: requestID.String()},
+		{Contract: contract.String(), Sender: sender.String(), RequestID: requestID.String()},
+	} {
+		f, err := parseEventFilter(string(publisher.ISCEventKindReceipt), &filter)
+		require.NoError(t, err)
+		require.Same(t, event, f.apply(event), "%+v", filter)
+	}
+
+	for _, filter := range []commands.SubscriptionFilter{
+		{Contract: isc.Hn("other").String()},
+		{Sender: isc.NewRandomAgentID().String()},
+		{RequestID: isc.NewRequestID(iotago.TransactionID{1, 2, 3}, 2).String()},
+		{Contract: contract.String(), Sender: isc.NewRandomAgentID().String()},
+	} {
+		f, err := parseEventFilter(string(publisher.ISCEventKindReceipt), &filter)
+		require.NoError(t, err)
+		require.Nil(t, f.apply(event), "%+v", filter)
+	}
+}
+
+func TestEventFilterBlockEvents(t *testing.T) {
+	accounts := isc.Hn("accounts")
+	evm := isc.Hn("evm")
+	events := []*isc.Event{
+		{ContractID: accounts, Topic: "accounts.deposit"},
+		{ContractID: accounts, Topic: "accounts.withdraw"},
+		{ContractID: evm, Topic: "evm.log"},
+	}
+	event := &ISCEvent{
+		Kind:    publisher.ISCEventKindBlockEvents,
+		ChainID: "chain",
+		Payload: events,
+	}
+
+	f, err := parseEventFilter(string(publisher.ISCEventKindBlockEvents), &commands.SubscriptionFilter{Contract: accounts.String()})
+	require.NoError(t, err)
+	filtered := f.apply(event)
+	require.Equal(t, events[:2], filtered.Payload)
+	require.Equal(t, "chain", filtered.ChainID)
+	// the original event, shared by all clients, is left untouched
+	require.Len(t, event.Payload, 3)
+
+	f, err = parseEventFilter(string(publisher.ISCEventKindBlockEvents), &commands.SubscriptionFilter{TopicPrefix: "accounts.with"})
+	require.NoError(t, err)
+	require.Equal(t, events[1:2], f.apply(event).Payload)
+
+	f, err = parseEventFilter(string(publisher.ISCEventKindBlockEvents), &commands.SubscriptionFilter{Contract: evm.String(), TopicPrefix: "accounts."})
+	require.NoError(t, err)
+	require.Nil(t, f.apply(event))
+}
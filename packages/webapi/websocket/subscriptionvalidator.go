@@ -2,22 +2,79 @@ package websocket
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/iotaledger/hive.go/web/subscriptionmanager"
 	"github.com/iotaledger/hive.go/web/websockethub"
 	"github.com/iotaledger/wasp/packages/publisher"
+	"github.com/iotaledger/wasp/packages/webapi/websocket/commands"
 )
 
 type SubscriptionValidator struct {
 	messageTypes        map[publisher.ISCEventType]bool
 	subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]
+
+	filtersMutex sync.RWMutex
+	filters      map[websockethub.ClientID]map[publisher.ISCEventType]*eventFilter
 }
 
+var _ commands.SubscriptionFilters = &SubscriptionValidator{}
+
 func NewSubscriptionValidator(messageTypes map[publisher.ISCEventType]bool, subscriptionManager *subscriptionmanager.SubscriptionManager[websockethub.ClientID, string]) *SubscriptionValidator {
 	return &SubscriptionValidator{
 		messageTypes:        messageTypes,
 		subscriptionManager: subscriptionManager,
+		filters:             make(map[websockethub.ClientID]map[publisher.ISCEventType]*eventFilter),
+	}
+}
+
+// SetFilter sets the filter of the events of the given topic sent to a client
+func (p *SubscriptionValidator) SetFilter(clientID websockethub.ClientID, topic string, filter *commands.SubscriptionFilter) error {
+	f, err := parseEventFilter(topic, filter)
+	if err != nil {
+		return err
+	}
+
+	p.filtersMutex.Lock()
+	defer p.filtersMutex.Unlock()
+
+	if p.filters[clientID] == nil {
+		p.filters[clientID] = make(map[publisher.ISCEventType]*eventFilter)
+	}
+	p.filters[clientID][publisher.ISCEventType(topic)] = f
+	return nil
+}
+
+// RemoveFilter removes the filter of the events of the given topic sent to a client
+func (p *SubscriptionValidator) RemoveFilter(clientID websockethub.ClientID, topic string) {
+	p.filtersMutex.Lock()
+	defer p.filtersMutex.Unlock()
+
+	delete(p.filters[clientID], publisher.ISCEventType(topic))
+	if len(p.filters[clientID]) == 0 {
+		delete(p.filters, clientID)
+	}
+}
+
+// removeClient removes all filters of a client
+func (p *SubscriptionValidator) removeClient(clientID websockethub.ClientID) {
+	p.filtersMutex.Lock()
+	defer p.filtersMutex.Unlock()
+
+	delete(p.filters, clientID)
+}
+
+// filterEvent returns the part of the event that passes the filter the client
+// set for the kind of the event, or nil if nothing of it should be sent.
+func (p *SubscriptionValidator) filterEvent(client *websockethub.Client, iscEvent *ISCEvent) *ISCEvent {
+	p.filtersMutex.RLock()
+	filter := p.filters[client.ID()][iscEvent.Kind]
+	p.filtersMutex.RUnlock()
+
+	if filter == nil {
+		return iscEvent
 	}
+	return filter.apply(iscEvent)
 }
 
 func (p *SubscriptionValidator) hasClientSubscribedToAllChains(client *websockethub.Client) bool {
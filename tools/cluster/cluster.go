@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
@@ -38,8 +39,11 @@ import (
 	"github.com/iotaledger/wasp/packages/kv/dict"
 	"github.com/iotaledger/wasp/packages/l1connection"
 	"github.com/iotaledger/wasp/packages/origin"
+	"github.com/iotaledger/wasp/packages/parameters"
+	"github.com/iotaledger/wasp/packages/testutil/l1sim"
 	"github.com/iotaledger/wasp/packages/testutil/testkey"
 	"github.com/iotaledger/wasp/packages/testutil/testlogger"
+	"github.com/iotaledger/wasp/packages/testutil/utxodb"
 	"github.com/iotaledger/wasp/packages/transaction"
 	"github.com/iotaledger/wasp/packages/util"
 	"github.com/iotaledger/wasp/packages/vm/core/governance"
@@ -72,7 +76,14 @@ func New(name string, config *ClusterConfig, dataPath string, t *testing.T, log
 	}
 	evmlogger.Init(log)
 
-	config.setValidatorAddressIfNotSet() // privtangle prefix
+	var l1Client l1connection.Client
+	if config.L1Sim != nil {
+		l1Client = startL1Sim(config.L1Sim, t, log)
+	} else {
+		l1Client = l1connection.NewClient(config.L1, log)
+	}
+
+	config.setValidatorAddressIfNotSet()
 
 	return &Cluster{
 		Name:              name,
@@ -81,11 +92,44 @@ func New(name string, config *ClusterConfig, dataPath string, t *testing.T, log
 		waspCmds:          make([]*waspCmd, len(config.Wasp)),
 		t:                 t,
 		log:               log,
-		l1:                l1connection.NewClient(config.L1, log),
+		l1:                l1Client,
 		DataPath:          dataPath,
 	}
 }
 
+// startL1Sim starts the simulated L1 ledger and serves it to the nodes. It is
+// stopped together with the test, or runs until the process exits otherwise.
+func startL1Sim(config *L1SimConfig, t *testing.T, log *logger.Logger) l1connection.Client {
+	parameters.InitL1(parameters.L1ForTesting)
+	ledger := l1sim.NewLedger(log.Named("l1sim"), utxodb.New(), &l1sim.LedgerOptions{
+		MilestoneInterval: config.MilestoneInterval,
+	})
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", config.Port))
+	if err != nil {
+		panic(fmt.Errorf("cannot serve the simulated L1: %w", err))
+	}
+	server := &http.Server{
+		Handler:           l1sim.NewServer(ledger, log.Named("l1sim")),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx := context.Background()
+	if t != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		t.Cleanup(func() {
+			server.Close()
+			cancel()
+		})
+	}
+	go ledger.Run(ctx)
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("Serving the simulated L1 failed: %v", err)
+		}
+	}()
+	return ledger.NewClient()
+}
+
 func (clu *Cluster) Logf(format string, args ...any) {
 	if clu.t != nil {
 		clu.t.Logf(format, args...)
@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"os"
 	"path"
+	"time"
 
+	iotago "github.com/iotaledger/iota.go/v3"
 	"github.com/iotaledger/wasp/packages/cryptolib"
 	"github.com/iotaledger/wasp/packages/l1connection"
+	"github.com/iotaledger/wasp/packages/parameters"
 	"github.com/iotaledger/wasp/tools/cluster/templates"
 )
 
@@ -34,8 +37,28 @@ func (w *WaspConfig) WaspConfigTemplateParams(i int) templates.WaspConfigParams
 }
 
 type ClusterConfig struct {
-	Wasp []templates.WaspConfigParams
-	L1   l1connection.Config
+	Wasp  []templates.WaspConfigParams
+	L1    l1connection.Config
+	L1Sim *L1SimConfig `json:",omitempty"`
+}
+
+// L1SimConfig makes the cluster host a simulated L1 ledger (see l1sim) for
+// its nodes, instead of using the L1 node given by ClusterConfig.L1.
+type L1SimConfig struct {
+	// Port on localhost the simulator is served at.
+	Port              int
+	MilestoneInterval time.Duration
+}
+
+func DefaultL1SimConfig() *L1SimConfig {
+	return &L1SimConfig{
+		Port:              19600,
+		MilestoneInterval: 1 * time.Second,
+	}
+}
+
+func (c *L1SimConfig) URL() string {
+	return fmt.Sprintf("http://127.0.0.1:%d", c.Port)
 }
 
 func DefaultWaspConfig() WaspConfig {
@@ -71,6 +94,16 @@ func NewConfig(waspConfig WaspConfig, l1Config l1connection.Config, modifyConfig
 	}
 }
 
+// WithL1Sim makes the nodes connect to the simulated L1 hosted by the cluster.
+func (c *ClusterConfig) WithL1Sim(l1SimConfig *L1SimConfig) *ClusterConfig {
+	c.L1Sim = l1SimConfig
+	for i := range c.Wasp {
+		c.Wasp[i].L1INXAddress = ""
+		c.Wasp[i].L1SimURL = l1SimConfig.URL()
+	}
+	return c
+}
+
 func LoadConfig(dataPath string) (*ClusterConfig, error) {
 	b, err := os.ReadFile(configPath(dataPath))
 	if err != nil {
@@ -94,11 +127,15 @@ func configPath(dataPath string) string {
 }
 
 func (c *ClusterConfig) setValidatorAddressIfNotSet() {
+	hrp := iotago.NetworkPrefix("atoi") // privtangle bech32
+	if c.L1Sim != nil {
+		hrp = parameters.L1().Protocol.Bech32HRP
+	}
 	for i := range c.Wasp {
 		if c.Wasp[i].ValidatorKeyPair == nil {
 			kp := cryptolib.NewKeyPair()
 			c.Wasp[i].ValidatorKeyPair = kp
-			c.Wasp[i].ValidatorAddress = kp.Address().Bech32("atoi") // privtangle bech32
+			c.Wasp[i].ValidatorAddress = kp.Address().Bech32(hrp)
 		}
 	}
 }
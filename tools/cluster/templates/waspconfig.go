@@ -11,6 +11,7 @@ type WaspConfigParams struct {
 	APIPort                      int
 	PeeringPort                  int
 	L1INXAddress                 string
+	L1SimURL                     string // the nodes connect to the simulated L1 instead of INX, if set
 	ProfilingPort                int
 	MetricsPort                  int
 	OffledgerBroadcastUpToNPeers int // TODO this is unused, should it be removed?
@@ -52,6 +53,9 @@ var WaspConfig = `
     "maxConnectionAttempts": 30,
     "targetNetworkName": ""
   },
+  "l1sim": {
+    "url": "{{.L1SimURL}}"
+  },
   "db": {
     "engine": "rocksdb",
     "chainState": {
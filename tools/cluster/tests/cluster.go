@@ -17,6 +17,7 @@ type waspClusterOpts struct {
 	nNodes       int
 	modifyConfig templates.ModifyNodesConfigFn
 	dirName      string
+	l1Sim        bool // the cluster hosts a simulated L1 for the nodes instead of using the private tangle
 }
 
 // by default, when running the cluster tests we will automatically setup a private tangle,
@@ -32,7 +33,10 @@ func newCluster(t *testing.T, opt ...waspClusterOpts) *cluster.Cluster {
 	if testing.Short() {
 		t.Skip("Skipping cluster test in short mode")
 	}
-	l1.StartPrivtangleIfNecessary(t.Logf)
+	useL1Sim := len(opt) > 0 && opt[0].l1Sim
+	if !useL1Sim {
+		l1.StartPrivtangleIfNecessary(t.Logf)
+	}
 
 	dirname := "wasp-cluster"
 	var modifyNodesConfig templates.ModifyNodesConfigFn
@@ -54,6 +58,9 @@ func newCluster(t *testing.T, opt ...waspClusterOpts) *cluster.Cluster {
 		l1.Config,
 		modifyNodesConfig,
 	)
+	if useL1Sim {
+		clusterConfig.WithL1Sim(cluster.DefaultL1SimConfig())
+	}
 
 	dataPath := path.Join(os.TempDir(), dirname)
 	clu := cluster.New(t.Name(), clusterConfig, dataPath, t, nil)
@@ -0,0 +1,33 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/iotaledger/wasp/clients/apiextensions"
+	"github.com/iotaledger/wasp/contracts/native/inccounter"
+)
+
+// runs a chain with the nodes connected to the simulated L1 instead of the privtangle
+func TestL1Sim(t *testing.T) {
+	clu := newCluster(t, waspClusterOpts{
+		nNodes:  4,
+		dirName: "wasp-cluster-l1sim",
+		l1Sim:   true,
+	})
+	chain, err := clu.DeployDefaultChain()
+	require.NoError(t, err)
+	env := newChainEnv(t, clu, chain)
+	env.deployNativeIncCounterSC(0)
+
+	client := env.createNewClient()
+	for i := 0; i < 3; i++ {
+		tx, err := client.PostRequest(inccounter.FuncIncCounter.Name)
+		require.NoError(t, err)
+		_, err = apiextensions.APIWaitUntilAllRequestsProcessed(clu.WaspClient(0), chain.ChainID, tx, true, 30*time.Second)
+		require.NoError(t, err)
+	}
+	env.expectCounter(nativeIncCounterSCHname, 3)
+}
@@ -23,20 +23,39 @@ import (
 type processFunc func(context.Context, kvstore.KVStore)
 
 var (
-	blockIndex  int64
-	blockIndex2 int64
+	blockIndex        int64
+	blockIndex2       int64
+	walCompression    string
+	walSegmentMaxSize int64
+	walChainDir       string
+	dumpHname         string
+	dumpPrefix        string
+	dumpFormat        string
+	dumpOutput        string
 )
 
 func main() {
 	flag.Int64Var(&blockIndex, "b", -1, "Block index")
 	flag.Int64Var(&blockIndex2, "B", -1, "Block index 2")
+	flag.StringVar(&walCompression, "compression", "none", "WAL compression: none, zstd or snappy (wal-migrate)")
+	flag.Int64Var(&walSegmentMaxSize, "segment-size", 0, "WAL segment size in bytes; 0 - default (wal-migrate)")
+	flag.StringVar(&dumpHname, "hname", "", "contract hname (hex) or name; empty - all contracts (state-dump, state-keys)")
+	flag.StringVar(&dumpPrefix, "prefix", "", "key prefix, relative to the contract if -hname is given; 0x-prefixed hex or a string (state-dump, state-keys)")
+	flag.StringVar(&dumpFormat, "format", "json", "output format: json (JSON lines) or csv (state-dump)")
+	flag.StringVar(&dumpOutput, "o", "", "output file; empty - stdout (state-dump)")
+	flag.StringVar(&walChainDir, "wal", "", "WAL folder of the chain, i.e. <wal-dir>/<chainID> (wal-replay, wal-verify)")
 	flag.Parse()
 
 	if flag.NArg() != 2 {
-		log.Fatalf("usage: %s [-b index] <command> <chain-db-dir>", os.Args[0])
+		log.Fatalf("usage: %s [-b index] [-wal <wal-dir>/<chainID>] <command> <chain-db-dir>\n       %s [-compression c] [-segment-size s] wal-migrate <wal-dir>/<chainID>", os.Args[0], os.Args[0])
 	}
 	args := flag.Args()
+	if args[0] == "wal-migrate" {
+		walMigrate(args[1])
+		return
+	}
 	var f processFunc
+	readOnly := true
 	switch args[0] {
 	case "state-stats-per-hname":
 		f = stateStatsPerHname
@@ -44,11 +63,20 @@ func main() {
 		f = trieStats
 	case "trie-diff":
 		f = trieDiff
+	case "state-dump":
+		f = stateDump
+	case "state-keys":
+		f = stateKeys
+	case "wal-replay":
+		f = walReplay
+		readOnly = false
+	case "wal-verify":
+		f = walVerify
 	default:
 		log.Fatalf("unknown command: %s", args[0])
 	}
 
-	process(args[1], f)
+	process(args[1], readOnly, f)
 }
 
 func getState(kvs kvstore.KVStore, index int64) state.State {
@@ -63,26 +91,37 @@ func getState(kvs kvstore.KVStore, index int64) state.State {
 	return state
 }
 
-func process(dbDir string, f processFunc) {
-	rocksDatabase, err := rocksdb.OpenDBReadOnly(dbDir,
-		rocksdb.IncreaseParallelism(runtime.NumCPU()-1),
-		rocksdb.Custom([]string{
-			"periodic_compaction_seconds=43200",
-			"level_compaction_dynamic_level_bytes=true",
-			"keep_log_file_num=2",
-			"max_log_file_size=50000000", // 50MB per log file
-		}),
-	)
-	mustNoError(err)
+func process(dbDir string, readOnly bool, f processFunc) {
+	var kvs kvstore.KVStore
+	if readOnly {
+		rocksDatabase, err := rocksdb.OpenDBReadOnly(dbDir,
+			rocksdb.IncreaseParallelism(runtime.NumCPU()-1),
+			rocksdb.Custom([]string{
+				"periodic_compaction_seconds=43200",
+				"level_compaction_dynamic_level_bytes=true",
+				"keep_log_file_num=2",
+				"max_log_file_size=50000000", // 50MB per log file
+			}),
+		)
+		mustNoError(err)
 
-	db := database.New(
-		dbDir,
-		rocksdb.New(rocksDatabase),
-		hivedb.EngineRocksDB,
-		true,
-		func() bool { panic("should not be called") },
-	)
-	kvs := db.KVStore()
+		db := database.New(
+			dbDir,
+			rocksdb.New(rocksDatabase),
+			hivedb.EngineRocksDB,
+			true,
+			func() bool { panic("should not be called") },
+		)
+		kvs = db.KVStore()
+	} else {
+		db, err := database.DatabaseWithDefaultSettings(dbDir, true, hivedb.EngineRocksDB, false, database.AllowedEnginesStorage...)
+		mustNoError(err)
+		kvs = db.KVStore()
+		defer func() {
+			mustNoError(kvs.Flush())
+			mustNoError(kvs.Close())
+		}()
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	done := make(chan struct{}, 1)
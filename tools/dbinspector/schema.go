@@ -0,0 +1,243 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/kv/codec"
+	"github.com/iotaledger/wasp/packages/util/rwutil"
+	"github.com/iotaledger/wasp/packages/vm/core/accounts"
+	"github.com/iotaledger/wasp/packages/vm/core/blob"
+	"github.com/iotaledger/wasp/packages/vm/core/blocklog"
+	"github.com/iotaledger/wasp/packages/vm/core/errors"
+	"github.com/iotaledger/wasp/packages/vm/core/evm"
+	"github.com/iotaledger/wasp/packages/vm/core/governance"
+	"github.com/iotaledger/wasp/packages/vm/core/root"
+)
+
+type keyKind int
+
+const (
+	// keyKindVar is a single value stored at the key
+	keyKindVar keyKind = iota
+	// keyKindPrefix is a value stored at the key prefix followed by an
+	// arbitrary suffix (e.g. the account key)
+	keyKindPrefix
+	// keyKindMap is a collections.Map
+	keyKindMap
+	// keyKindArray is a collections.Array
+	keyKindArray
+)
+
+type valueDecoder func([]byte) (string, error)
+
+// keySchema describes the key (or the family of keys) of a core contract.
+type keySchema struct {
+	key    string
+	name   string
+	kind   keyKind
+	decode valueDecoder
+}
+
+// coreContractSchemas describes the known keys of the core contracts. The
+// values of the keys not described here (or not decodable) are dumped as hex.
+var coreContractSchemas = map[isc.Hname][]keySchema{
+	accounts.Contract.Hname(): {
+		{accounts.KeyAllAccounts, "allAccounts", keyKindMap, decodeBool},
+		{accounts.PrefixBaseTokens, "baseTokens", keyKindPrefix, decodeUint64},
+		{accounts.PrefixNativeTokens, "nativeTokens", keyKindPrefix, decodeBigInt},
+		{accounts.PrefixNFTs, "nfts", keyKindPrefix, nil},
+		{accounts.PrefixNFTsByCollection, "nftsByCollection", keyKindPrefix, nil},
+		{accounts.PrefixNewlyMintedNFTs, "newlyMintedNFTs", keyKindPrefix, nil},
+		{accounts.PrefixMintIDMap, "mintIDMap", keyKindPrefix, nil},
+		{accounts.PrefixFoundries, "foundries", keyKindPrefix, nil},
+		{accounts.KeyNonce, "nonce", keyKindPrefix, decodeUint64},
+		{accounts.KeyNativeTokenOutputMap, "nativeTokenOutputs", keyKindMap, nil},
+		{accounts.KeyFoundryOutputRecords, "foundryOutputs", keyKindMap, nil},
+		{accounts.KeyNFTOutputRecords, "nftOutputs", keyKindMap, nil},
+		{accounts.KeyNFTOwner, "nftOwner", keyKindMap, decodeAgentID},
+		{accounts.KeyNewNativeTokens, "newNativeTokens", keyKindArray, nil},
+		{accounts.KeyNewFoundries, "newFoundries", keyKindArray, nil},
+		{accounts.KeyNewNFTs, "newNFTs", keyKindArray, nil},
+	},
+	blob.Contract.Hname(): {
+		{blob.VarFieldProgramBinary, "programBinary", keyKindVar, nil},
+		{blob.VarFieldVMType, "vmType", keyKindVar, decodeString},
+		{blob.VarFieldProgramDescription, "programDescription", keyKindVar, decodeString},
+	},
+	blocklog.Contract.Hname(): {
+		{blocklog.PrefixBlockRegistry, "blockRegistry", keyKindArray, nil},
+		{blocklog.PrefixRequestLookupIndex, "requestLookupIndex", keyKindMap, nil},
+		{blocklog.PrefixRequestReceipts, "requestReceipts", keyKindMap, nil},
+		{blocklog.PrefixRequestEvents, "requestEvents", keyKindMap, nil},
+		{blocklog.PrefixUnprocessableRequests, "unprocessableRequests", keyKindMap, nil},
+		{blocklog.PrefixNewUnprocessableRequests, "newUnprocessableRequests", keyKindArray, nil},
+	},
+	errors.Contract.Hname(): {
+		{errors.PrefixErrorTemplateMap, "errorTemplates", keyKindPrefix, nil},
+	},
+	evm.Contract.Hname(): {
+		{evm.KeyEmulatorState, "emulatorState", keyKindPrefix, nil},
+		{evm.KeyISCMagic, "iscMagic", keyKindPrefix, nil},
+	},
+	governance.Contract.Hname(): {
+		{governance.VarAllowedStateControllerAddresses, "allowedStateControllerAddresses", keyKindMap, nil},
+		{governance.VarRotateToAddress, "rotateToAddress", keyKindVar, decodeAddress},
+		{governance.VarPayoutAgentID, "payoutAgentID", keyKindVar, decodeAgentID},
+		{governance.VarMinBaseTokensOnCommonAccount, "minBaseTokensOnCommonAccount", keyKindVar, decodeUint64},
+		{governance.VarChainOwnerID, "chainOwnerID", keyKindVar, decodeAgentID},
+		{governance.VarChainOwnerIDDelegated, "chainOwnerIDDelegated", keyKindVar, decodeAgentID},
+		{governance.VarGasFeePolicyBytes, "gasFeePolicy", keyKindVar, nil},
+		{governance.VarGasLimitsBytes, "gasLimits", keyKindVar, nil},
+		{governance.VarAccessNodes, "accessNodes", keyKindMap, nil},
+		{governance.VarAccessNodeCandidates, "accessNodeCandidates", keyKindMap, nil},
+		{governance.VarMaintenanceStatus, "maintenanceStatus", keyKindVar, decodeBool},
+		{governance.VarMetadata, "metadata", keyKindVar, nil},
+		{governance.VarPublicURL, "publicURL", keyKindVar, decodeString},
+		{governance.VarBlockKeepAmount, "blockKeepAmount", keyKindVar, decodeInt32},
+	},
+	root.Contract.Hname(): {
+		{root.VarSchemaVersion, "schemaVersion", keyKindVar, decodeUint32},
+		{root.VarContractRegistry, "contractRegistry", keyKindMap, nil},
+		{root.VarDeployPermissionsEnabled, "deployPermissionsEnabled", keyKindVar, decodeBool},
+		{root.VarDeployPermissions, "deployPermissions", keyKindMap, nil},
+	},
+}
+
+// decodeKey splits the state key into the contract hname and the
+// human-readable contract key; the value decoder is returned, if the key
+// schema is known.
+func decodeKey(key kv.Key) (isc.Hname, string, valueDecoder) {
+	if len(key) < isc.HnameLength {
+		return isc.HnameNil, formatBytes([]byte(key)), nil
+	}
+	hname, err := isc.HnameFromBytes([]byte(key[:isc.HnameLength]))
+	if err != nil {
+		return isc.HnameNil, formatBytes([]byte(key)), nil
+	}
+	contractKey := key[isc.HnameLength:]
+	var found *keySchema
+	for i := range coreContractSchemas[hname] {
+		schema := &coreContractSchemas[hname][i]
+		if !strings.HasPrefix(string(contractKey), schema.key) {
+			continue
+		}
+		if found == nil || len(schema.key) > len(found.key) {
+			found = schema
+		}
+	}
+	if found == nil {
+		return hname, formatBytes([]byte(contractKey)), nil
+	}
+	suffix := []byte(contractKey[len(found.key):])
+	switch found.kind {
+	case keyKindVar:
+		if len(suffix) == 0 {
+			return hname, found.name, found.decode
+		}
+	case keyKindPrefix:
+		return hname, found.name + "/" + formatBytes(suffix), found.decode
+	case keyKindMap:
+		if len(suffix) == 0 {
+			return hname, found.name + ".size", decodeSize
+		}
+		if suffix[0] == '.' {
+			return hname, found.name + "." + formatBytes(suffix[1:]), found.decode
+		}
+	case keyKindArray:
+		if len(suffix) == 0 {
+			return hname, found.name + ".size", decodeSize
+		}
+		if suffix[0] == '#' {
+			rr := rwutil.NewBytesReader(suffix[1:])
+			index := rr.ReadSizeWithLimit(math.MaxUint32)
+			rr.Close()
+			if rr.Err == nil {
+				return hname, fmt.Sprintf("%s[%d]", found.name, index), found.decode
+			}
+		}
+	}
+	return hname, formatBytes([]byte(contractKey)), nil
+}
+
+// decodeValue decodes the value with the given decoder; if the decoder is not
+// known or fails, the value is formatted as hex.
+func decodeValue(value []byte, decode valueDecoder) string {
+	if decode != nil {
+		if s, err := decode(value); err == nil {
+			return s
+		}
+	}
+	return iotago.EncodeHex(value)
+}
+
+// formatBytes returns the bytes as string, if they are printable, and as hex
+// otherwise.
+func formatBytes(b []byte) string {
+	for _, c := range b {
+		if c < 0x20 || c > 0x7e {
+			return iotago.EncodeHex(b)
+		}
+	}
+	return string(b)
+}
+
+func decodeBool(b []byte) (string, error) {
+	v, err := codec.DecodeBool(b)
+	return fmt.Sprint(v), err
+}
+
+func decodeInt32(b []byte) (string, error) {
+	v, err := codec.DecodeInt32(b)
+	return fmt.Sprint(v), err
+}
+
+func decodeUint32(b []byte) (string, error) {
+	v, err := codec.DecodeUint32(b)
+	return fmt.Sprint(v), err
+}
+
+// decodeSize decodes the size of a collection.
+func decodeSize(b []byte) (string, error) {
+	rr := rwutil.NewBytesReader(b)
+	size := rr.ReadSizeWithLimit(math.MaxUint32)
+	rr.Close()
+	return fmt.Sprint(size), rr.Err
+}
+
+func decodeUint64(b []byte) (string, error) {
+	v, err := codec.DecodeUint64(b)
+	return fmt.Sprint(v), err
+}
+
+func decodeBigInt(b []byte) (string, error) {
+	v, err := codec.DecodeBigIntAbs(b)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
+
+func decodeString(b []byte) (string, error) {
+	return codec.DecodeString(b)
+}
+
+func decodeAgentID(b []byte) (string, error) {
+	v, err := codec.DecodeAgentID(b)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
+
+func decodeAddress(b []byte) (string, error) {
+	v, err := codec.DecodeAddress(b)
+	if err != nil {
+		return "", err
+	}
+	return v.String(), nil
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	iotago "github.com/iotaledger/iota.go/v3"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/kv"
+	"github.com/iotaledger/wasp/packages/vm/core/corecontracts"
+)
+
+type stateDumpEntry struct {
+	Contract string `json:"contract"`
+	Key      string `json:"key"`
+	KeyHex   string `json:"keyHex"`
+	Value    string `json:"value"`
+	ValueHex string `json:"valueHex"`
+}
+
+type stateDumpWriter interface {
+	Write(*stateDumpEntry) error
+	Flush() error
+}
+
+type jsonLinesWriter struct {
+	enc *json.Encoder
+}
+
+func (w *jsonLinesWriter) Write(e *stateDumpEntry) error { return w.enc.Encode(e) }
+func (w *jsonLinesWriter) Flush() error                  { return nil }
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func (w *csvWriter) Write(e *stateDumpEntry) error {
+	return w.w.Write([]string{e.Contract, e.Key, e.KeyHex, e.Value, e.ValueHex})
+}
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func newStateDumpWriter(out io.Writer) stateDumpWriter {
+	switch dumpFormat {
+	case "json":
+		return &jsonLinesWriter{enc: json.NewEncoder(out)}
+	case "csv":
+		w := csv.NewWriter(out)
+		mustNoError(w.Write([]string{"contract", "key", "keyHex", "value", "valueHex"}))
+		return &csvWriter{w: w}
+	default:
+		mustNoError(fmt.Errorf("unknown format %q, expected json or csv", dumpFormat))
+		return nil
+	}
+}
+
+// stateDump writes the key/value pairs of the state at the block given by -b
+// (latest by default) as JSON lines or CSV. The keys can be filtered by the
+// contract (-hname) and by the key prefix (-prefix; relative to the contract,
+// if -hname is given). The keys and values of the core contracts are decoded
+// if their schema is known.
+func stateDump(ctx context.Context, kvs kvstore.KVStore) {
+	state := getState(kvs, blockIndex)
+
+	out := io.Writer(os.Stdout)
+	if dumpOutput != "" {
+		f, err := os.Create(dumpOutput)
+		mustNoError(err)
+		defer f.Close()
+		out = f
+	}
+	w := newStateDumpWriter(out)
+
+	n := 0
+	state.IterateSorted(dumpKeyPrefix(), func(k kv.Key, v []byte) bool {
+		if ctx.Err() != nil {
+			fmt.Fprintln(os.Stderr, ctx.Err())
+			return false
+		}
+		hname, key, decode := decodeKey(k)
+		mustNoError(w.Write(&stateDumpEntry{
+			Contract: contractName(hname),
+			Key:      key,
+			KeyHex:   iotago.EncodeHex([]byte(k)),
+			Value:    decodeValue(v, decode),
+			ValueHex: iotago.EncodeHex(v),
+		}))
+		n++
+		return true
+	})
+	mustNoError(w.Flush())
+	fmt.Fprintf(os.Stderr, "State index %d: %d key-value pairs dumped\n", state.BlockIndex(), n)
+}
+
+// stateKeys lists the keys of the state at the block given by -b with the
+// sizes of their values; the keys are filtered the same way as in stateDump.
+func stateKeys(ctx context.Context, kvs kvstore.KVStore) {
+	state := getState(kvs, blockIndex)
+
+	n := 0
+	totalSize := 0
+	state.IterateSorted(dumpKeyPrefix(), func(k kv.Key, v []byte) bool {
+		if ctx.Err() != nil {
+			fmt.Println(ctx.Err())
+			return false
+		}
+		hname, key, _ := decodeKey(k)
+		fmt.Printf("%s: %s -- %d bytes\n", contractName(hname), key, len(v))
+		n++
+		totalSize += len(k) + len(v)
+		return true
+	})
+	fmt.Printf("\nState index %d: %d keys, %d bytes\n", state.BlockIndex(), n, totalSize)
+}
+
+// dumpKeyPrefix returns the state key prefix according to -hname and -prefix.
+func dumpKeyPrefix() kv.Key {
+	prefix := parseBytesFlag(dumpPrefix)
+	if dumpHname == "" {
+		return kv.Key(prefix)
+	}
+	hname, err := parseHname(dumpHname)
+	mustNoError(err)
+	return kv.Key(hname.Bytes()) + kv.Key(prefix)
+}
+
+// parseHname accepts either a hex hname or a contract name.
+func parseHname(s string) (isc.Hname, error) {
+	for hname, contract := range corecontracts.All {
+		if contract.Name == s {
+			return hname, nil
+		}
+	}
+	if hname, err := isc.HnameFromString(s); err == nil {
+		return hname, nil
+	}
+	if s == "" {
+		return isc.HnameNil, fmt.Errorf("empty hname")
+	}
+	return isc.Hn(s), nil
+}
+
+// parseBytesFlag accepts either 0x-prefixed hex or a plain string.
+func parseBytesFlag(s string) []byte {
+	if strings.HasPrefix(s, "0x") {
+		b, err := iotago.DecodeHex(s)
+		mustNoError(err)
+		return b
+	}
+	return []byte(s)
+}
+
+func contractName(hname isc.Hname) string {
+	if contract := corecontracts.All[hname]; contract != nil {
+		return contract.Name
+	}
+	return hname.String()
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/iotaledger/hive.go/kvstore"
+	"github.com/iotaledger/hive.go/logger"
+	"github.com/iotaledger/wasp/packages/chain/statemanager/sm_gpa/sm_gpa_utils"
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/packages/metrics"
+	"github.com/iotaledger/wasp/packages/state"
+)
+
+// walMigrate moves the blocks of the chain WAL folder (`<wal-dir>/<chainID>`)
+// from one file per block to the segments. The node must not be running.
+func walMigrate(walChainDir string) {
+	walChainDir = filepath.Clean(walChainDir)
+	chainID, err := isc.ChainIDFromString(filepath.Base(walChainDir))
+	mustNoError(err)
+	compression, err := sm_gpa_utils.ParseBlockWALCompression(walCompression)
+	mustNoError(err)
+
+	options := sm_gpa_utils.NewSegmentedBlockWALOptions()
+	options.Compression = compression
+	if walSegmentMaxSize > 0 {
+		options.SegmentMaxSize = walSegmentMaxSize
+	}
+	walMetrics := metrics.NewChainMetricsProvider().GetChainMetrics(chainID).BlockWAL
+	count, err := sm_gpa_utils.MigrateBlockWAL(newLogger(), filepath.Dir(walChainDir), chainID, walMetrics, options)
+	mustNoError(err)
+	fmt.Printf("Blocks migrated: %d\n", count)
+}
+
+// walReplay commits the blocks of the chain WAL, which are missing in the chain
+// DB, up to the block index given by -b (if any). The DB must contain the
+// state, from which the blocks of the WAL continue (e.g. the origin state or
+// a state loaded from a snapshot).
+func walReplay(ctx context.Context, kvs kvstore.KVStore) {
+	options := sm_gpa_utils.NewBlockWALReplayOptions()
+	options.TargetIndex = blockIndex
+	options.SetLatest = true
+	replayWAL(ctx, kvs, options)
+}
+
+// walVerify checks the blocks of the chain WAL against the states in the chain
+// DB up to the block index given by -b (if any); the DB is not modified.
+func walVerify(ctx context.Context, kvs kvstore.KVStore) {
+	options := sm_gpa_utils.NewBlockWALReplayOptions()
+	options.TargetIndex = blockIndex
+	options.VerifyOnly = true
+	replayWAL(ctx, kvs, options)
+}
+
+func replayWAL(ctx context.Context, kvs kvstore.KVStore, options sm_gpa_utils.BlockWALReplayOptions) {
+	if walChainDir == "" {
+		mustNoError(fmt.Errorf("WAL folder of the chain must be provided with -wal flag"))
+	}
+	log := newLogger()
+	wal := openWAL(log, walChainDir)
+	store := state.NewStoreWithUniqueWriteMutex(kvs)
+
+	result, err := sm_gpa_utils.ReplayBlockWAL(ctx, log, store, wal, options)
+	if errors.Is(err, context.Canceled) {
+		fmt.Printf("Interrupted\n")
+	} else {
+		mustNoError(err)
+	}
+
+	fmt.Printf("Blocks committed: %d\n", result.BlocksCommitted)
+	fmt.Printf("Blocks verified: %d\n", result.BlocksVerified)
+	if options.VerifyOnly {
+		fmt.Printf("Blocks missing in DB: %d\n", result.BlocksMissing)
+	}
+	fmt.Printf("Blocks skipped: %d\n", result.BlocksSkipped)
+	for _, gap := range result.Gaps {
+		fmt.Printf("  gap: #%d..#%d, previous state not available\n", gap.FromIndex, gap.ToIndex)
+	}
+	for _, fork := range result.Forks {
+		fmt.Printf("  fork: several blocks with index #%d\n", fork)
+	}
+	if result.LastBlock != nil {
+		fmt.Printf("Last block: #%d %s\n", result.LastBlock.StateIndex(), result.LastBlock.L1Commitment())
+	}
+	if result.FirstInconsistency != nil {
+		fmt.Printf("First inconsistent block: #%d, expected %s, got %s\n",
+			result.FirstInconsistency.StateIndex, result.FirstInconsistency.Expected, result.FirstInconsistency.Actual)
+	}
+}
+
+// openWAL opens the segmented WAL, if the chain WAL folder contains segments,
+// and the WAL of one file per block otherwise. The WAL is only read, so it is
+// opened read only: neither the segments nor their indexes are modified.
+func openWAL(log *logger.Logger, walChainDir string) sm_gpa_utils.BlockWAL {
+	walChainDir = filepath.Clean(walChainDir)
+	chainID, err := isc.ChainIDFromString(filepath.Base(walChainDir))
+	mustNoError(err)
+	walMetrics := metrics.NewChainMetricsProvider().GetChainMetrics(chainID).BlockWAL
+	var wal sm_gpa_utils.BlockWAL
+	if _, err = os.Stat(filepath.Join(walChainDir, "segments")); err == nil {
+		options := sm_gpa_utils.NewSegmentedBlockWALOptions()
+		options.ReadOnly = true
+		wal, err = sm_gpa_utils.NewSegmentedBlockWAL(log, filepath.Dir(walChainDir), chainID, walMetrics, options)
+	} else {
+		wal, err = sm_gpa_utils.NewReadOnlyBlockWAL(log, filepath.Dir(walChainDir), chainID, walMetrics)
+	}
+	mustNoError(err)
+	return wal
+}
+
+func newLogger() *logger.Logger {
+	log, err := logger.NewRootLogger(logger.Config{
+		Level:             "info",
+		Encoding:          "console",
+		OutputPaths:       []string{"stdout"},
+		DisableEvents:     true,
+		DisableCaller:     true,
+		DisableStacktrace: true,
+	})
+	mustNoError(err)
+	return log
+}
@@ -0,0 +1,130 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+type filterFlags struct {
+	user   string
+	method string
+	route  string
+	since  string
+	until  string
+}
+
+func (f *filterFlags) register(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&f.user, "user", "", "only the calls of this user")
+	cmd.Flags().StringVar(&f.method, "method", "", "only the calls with this HTTP method")
+	cmd.Flags().StringVar(&f.route, "route", "", "only the calls to routes containing this string")
+	cmd.Flags().StringVar(&f.since, "since", "", "only the calls at or after this time (RFC3339)")
+	cmd.Flags().StringVar(&f.until, "until", "", "only the calls before this time (RFC3339)")
+}
+
+func initAuditCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "audit <command>",
+		Short: "Inspect the audit log of the administrative calls to a Wasp node.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Check(cmd.Help())
+		},
+	}
+}
+
+func Init(rootCmd *cobra.Command) {
+	auditCmd := initAuditCmd()
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.AddCommand(initListCmd())
+	auditCmd.AddCommand(initExportCmd())
+}
+
+func initListCmd() *cobra.Command {
+	var node string
+	var filter filterFlags
+	var limit int32
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the latest administrative calls, newest first.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			entries, _, err := cliclients.WaspClient(node).NodeApi.GetAuditLog(context.Background()).
+				User(filter.user).
+				Method(filter.method).
+				Route(filter.route).
+				Since(filter.since).
+				Until(filter.until).
+				Limit(limit).
+				Execute()
+			log.Check(err)
+
+			header := []string{"Time", "User", "Source IP", "Call", "Status", "Error"}
+			rows := make([][]string, len(entries))
+			for i, entry := range entries {
+				rows[i] = []string{
+					entry.Timestamp.Format(time.RFC3339),
+					entry.User,
+					entry.SourceIP,
+					entry.Method + " " + entry.Path,
+					strconv.Itoa(int(entry.Status)),
+					entry.GetError(),
+				}
+			}
+			log.PrintTable(header, rows)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	filter.register(cmd)
+	cmd.Flags().Int32Var(&limit, "limit", 100, "the maximum amount of entries listed (0 = all)")
+	return cmd
+}
+
+func initExportCmd() *cobra.Command {
+	var node string
+	var filter filterFlags
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the administrative calls as JSON lines, oldest first.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			lines, _, err := cliclients.WaspClient(node).NodeApi.ExportAuditLog(context.Background()).
+				User(filter.user).
+				Method(filter.method).
+				Route(filter.route).
+				Since(filter.since).
+				Until(filter.until).
+				Execute()
+			log.Check(err)
+
+			if output == "" {
+				fmt.Print(lines)
+				return
+			}
+			log.Check(os.WriteFile(output, []byte(lines), 0o600))
+			log.Printf("Audit log exported to %s\n", output)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	filter.register(cmd)
+	cmd.Flags().StringVarP(&output, "output", "o", "", "the file to write to (default: stdout)")
+	return cmd
+}
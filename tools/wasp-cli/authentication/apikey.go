@@ -0,0 +1,126 @@
+package authentication
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/user"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func initAPIKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apikey <command>",
+		Short: "Manage the API keys of a user",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Check(cmd.Help())
+		},
+	}
+
+	cmd.AddCommand(initAPIKeyCreateCmd())
+	cmd.AddCommand(initAPIKeyListCmd())
+	cmd.AddCommand(initAPIKeyDeleteCmd())
+	return cmd
+}
+
+func initAPIKeyCreateCmd() *cobra.Command {
+	var node string
+	var chainName string
+	var expiresIn time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "create <username> <name> [<permission>...]",
+		Short: "Create an API key for a user",
+		Long: `Create an API key for a user.
+The key can only be given permissions the user has, by default it gets all of them.
+The key is only shown once.`,
+		Args: cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			req := apiclient.AddAPIKeyRequest{
+				Name:        args[1],
+				Permissions: user.ParsePermissions(args[2:], chainName),
+			}
+			if expiresIn > 0 {
+				req.SetExpiresAt(time.Now().Add(expiresIn))
+			}
+
+			res, _, err := cliclients.WaspClient(node).UsersApi.
+				AddAPIKey(context.Background(), args[0]).
+				AddAPIKeyRequest(req).
+				Execute()
+			log.Check(err)
+
+			log.Printf("API key %s created for user %s:\n%s\n", res.Name, args[0], res.Key)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	user.WithChainFlag(cmd, &chainName)
+	cmd.Flags().DurationVar(&expiresIn, "expires-in", 0, "validity of the key (default: the key doesn't expire)")
+	return cmd
+}
+
+func initAPIKeyListCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "list <username>",
+		Short: "List the API keys of a user",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			keys, _, err := cliclients.WaspClient(node).UsersApi.GetAPIKeys(context.Background(), args[0]).Execute()
+			log.Check(err)
+
+			header := []string{"name", "permissions", "created", "expires"}
+			rows := make([][]string, len(keys))
+			for i, key := range keys {
+				expires := "never"
+				if key.HasExpiresAt() {
+					expires = key.GetExpiresAt().Format(time.RFC3339)
+				}
+				rows[i] = []string{
+					key.Name,
+					strings.Join(key.Permissions, ", "),
+					key.CreatedAt.Format(time.RFC3339),
+					expires,
+				}
+			}
+			log.PrintTable(header, rows)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
+
+func initAPIKeyDeleteCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "delete <username> <name>",
+		Short: "Delete an API key of a user",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			_, err := cliclients.WaspClient(node).UsersApi.DeleteAPIKey(context.Background(), args[0], args[1]).Execute()
+			log.Check(err)
+
+			log.Printf("API key %s of user %s deleted\n", args[1], args[0])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
@@ -27,7 +27,12 @@ func Init(rootCmd *cobra.Command) {
 
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(infoCmd)
+	authCmd.AddCommand(initRefreshCmd())
+	authCmd.AddCommand(initRevokeCmd())
+	authCmd.AddCommand(initRevokeAllCmd())
+	authCmd.AddCommand(initAPIKeyCmd())
 
 	loginCmd.PersistentFlags().StringVarP(&username, "username", "u", "", "username")
 	loginCmd.PersistentFlags().StringVarP(&password, "password", "p", "", "password")
+	loginCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "use the given API key instead of logging in with username and password")
 }
@@ -19,6 +19,7 @@ import (
 var (
 	username string
 	password string
+	apiKey   string
 )
 
 func initLoginCmd() *cobra.Command {
@@ -29,6 +30,14 @@ func initLoginCmd() *cobra.Command {
 		// Args:  cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
 			node = waspcmd.DefaultWaspNodeFallback(node)
+			if apiKey != "" {
+				// API keys are used as they are, there is no login needed
+				config.SetToken(node, apiKey)
+				config.SetRefreshToken(node, "")
+				log.Printf("API key stored\n")
+				return
+			}
+
 			if username == "" || password == "" {
 				scanner := bufio.NewScanner(os.Stdin)
 
@@ -62,6 +71,7 @@ func initLoginCmd() *cobra.Command {
 			log.Check(err)
 
 			config.SetToken(node, token.Jwt)
+			config.SetRefreshToken(node, token.GetRefreshToken())
 
 			log.Printf("\nSuccessfully authenticated\n")
 		},
@@ -0,0 +1,111 @@
+package authentication
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/packages/users"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func initRefreshCmd() *cobra.Command {
+	var node string
+	cmd := &cobra.Command{
+		Use:   "refresh",
+		Short: "Get a new token with the refresh token received at login",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			refreshToken := config.GetRefreshToken(node)
+			if refreshToken == "" {
+				log.Fatalf("no refresh token stored for node %s, please login", node)
+			}
+
+			token, _, err := cliclients.WaspClient(node).AuthApi.
+				RefreshToken(context.Background()).
+				RefreshTokenRequest(apiclient.RefreshTokenRequest{
+					RefreshToken: refreshToken,
+				}).Execute()
+			log.Check(err)
+
+			config.SetToken(node, token.Jwt)
+			config.SetRefreshToken(node, token.GetRefreshToken())
+
+			log.Printf("Successfully refreshed\n")
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
+
+func revokeToken(node, token string) {
+	_, err := cliclients.WaspClient(node).AuthApi.
+		RevokeToken(context.Background()).
+		RevokeTokenRequest(apiclient.RevokeTokenRequest{
+			Token: token,
+		}).Execute()
+	log.Check(err)
+}
+
+func initRevokeCmd() *cobra.Command {
+	var node string
+	cmd := &cobra.Command{
+		Use:   "revoke [<token>]",
+		Short: "Revoke a token",
+		Long: `Revoke the given token (JWT or refresh token).
+Without a token, the tokens received at login are revoked and removed from the config (logout).`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			if len(args) == 1 {
+				revokeToken(node, args[0])
+				log.Printf("Token revoked\n")
+				return
+			}
+
+			token := config.GetToken(node)
+			refreshToken := config.GetRefreshToken(node)
+			if token == "" && refreshToken == "" {
+				log.Fatalf("no token stored for node %s", node)
+			}
+			// API keys can't be revoked this way, they have to be deleted
+			if token != "" && !users.IsAPIKey(token) {
+				revokeToken(node, token)
+			}
+			if refreshToken != "" {
+				revokeToken(node, refreshToken)
+			}
+			config.SetToken(node, "")
+			config.SetRefreshToken(node, "")
+
+			log.Printf("Tokens revoked\n")
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
+
+func initRevokeAllCmd() *cobra.Command {
+	var node string
+	cmd := &cobra.Command{
+		Use:   "revoke-all <username>",
+		Short: "Revoke all tokens issued to a user (the API keys of the user are not affected)",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			_, err := cliclients.WaspClient(node).UsersApi.RevokeUserTokens(context.Background(), args[0]).Execute()
+			log.Check(err)
+
+			log.Printf("All tokens of user %s revoked\n", args[0])
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
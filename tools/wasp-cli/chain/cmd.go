@@ -53,4 +53,5 @@ func Init(rootCmd *cobra.Command) {
 	chainCmd.AddCommand(initRegisterERC20NativeTokenOnRemoteChainCmd())
 	chainCmd.AddCommand(initCreateFoundryCmd())
 	chainCmd.AddCommand(initMetadataCmd())
+	chainCmd.AddCommand(initStateDiffCmd())
 }
@@ -0,0 +1,76 @@
+package chain
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/packages/isc"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func initStateDiffCmd() *cobra.Command {
+	var node string
+	var chain string
+	var contract string
+	var limit int32
+	var fromKey string
+
+	cmd := &cobra.Command{
+		Use:   "state-diff <from> [<to>]",
+		Short: "Show the state keys changed between two blocks (block index or trie root); <to> defaults to the latest block",
+		Args:  cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			chain = defaultChainFallback(chain)
+
+			client := cliclients.WaspClient(node)
+			req := client.ChainsApi.
+				GetStateDiff(context.Background(), config.GetChain(chain).String()).
+				From(args[0])
+			if len(args) > 1 {
+				req = req.To(args[1])
+			}
+			if contract != "" {
+				req = req.ContractHname(isc.Hn(contract).String())
+			}
+			if limit > 0 {
+				req = req.Limit(limit)
+			}
+			if fromKey != "" {
+				req = req.ContinuationKey(fromKey)
+			}
+			diff, _, err := req.Execute() //nolint:bodyclose // false positive
+			log.Check(err)
+
+			log.Printf("State diff from block %d (%s) to block %d (%s): %d keys\n",
+				diff.FromBlockIndex, diff.FromTrieRoot, diff.ToBlockIndex, diff.ToTrieRoot, len(diff.Entries))
+
+			header := []string{"", "contract", "key", "old value", "new value"}
+			rows := make([][]string, len(diff.Entries))
+			for i, entry := range diff.Entries {
+				change := "~"
+				switch {
+				case entry.OldValue == "":
+					change = "+"
+				case entry.NewValue == "":
+					change = "-"
+				}
+				rows[i] = []string{change, entry.ContractHName, entry.Key, entry.OldValue, entry.NewValue}
+			}
+			log.PrintTable(header, rows)
+			if diff.NextKey != "" {
+				log.Printf("More keys follow, use --from-key %s to get them\n", diff.NextKey)
+			}
+		},
+	}
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	withChainFlag(cmd, &chain)
+	cmd.Flags().StringVar(&contract, "contract", "", "show only the keys of the given contract (name)")
+	cmd.Flags().Int32Var(&limit, "limit", 0, "the maximum amount of keys shown (default: decided by the node)")
+	cmd.Flags().StringVar(&fromKey, "from-key", "", "show the keys starting at the given key (hex)")
+	return cmd
+}
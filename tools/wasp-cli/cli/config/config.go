@@ -85,6 +85,14 @@ func SetToken(node, token string) {
 	Set(fmt.Sprintf("authentication.wasp.%s.token", node), token)
 }
 
+func GetRefreshToken(node string) string {
+	return viper.GetString(fmt.Sprintf("authentication.wasp.%s.refreshToken", node))
+}
+
+func SetRefreshToken(node, token string) {
+	Set(fmt.Sprintf("authentication.wasp.%s.refreshToken", node), token)
+}
+
 func MustWaspAPIURL(nodeName string) string {
 	apiAddress := WaspAPIURL(nodeName)
 	if apiAddress == "" {
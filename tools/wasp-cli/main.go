@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/iotaledger/wasp/components/app"
+	"github.com/iotaledger/wasp/tools/wasp-cli/audit"
 	"github.com/iotaledger/wasp/tools/wasp-cli/authentication"
 	"github.com/iotaledger/wasp/tools/wasp-cli/chain"
 	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
@@ -20,6 +21,7 @@ import (
 	"github.com/iotaledger/wasp/tools/wasp-cli/log"
 	"github.com/iotaledger/wasp/tools/wasp-cli/metrics"
 	"github.com/iotaledger/wasp/tools/wasp-cli/peering"
+	"github.com/iotaledger/wasp/tools/wasp-cli/user"
 	"github.com/iotaledger/wasp/tools/wasp-cli/wallet"
 	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
 )
@@ -69,6 +71,8 @@ func init() {
 	decode.Init(rootCmd)
 	peering.Init(rootCmd)
 	metrics.Init(rootCmd)
+	user.Init(rootCmd)
+	audit.Init(rootCmd)
 }
 
 func main() {
@@ -0,0 +1,109 @@
+package user
+
+import (
+	"context"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func readPassword(password string) string {
+	if password != "" {
+		return password
+	}
+
+	log.Printf("Password: ")
+	// int cast is needed for windows
+	passwordBytes, err := term.ReadPassword(int(syscall.Stdin)) //nolint:unconvert
+	log.Check(err)
+	log.Printf("\n")
+
+	if len(passwordBytes) == 0 {
+		log.Fatalf("the password must not be empty")
+	}
+	return string(passwordBytes)
+}
+
+func initAddCmd() *cobra.Command {
+	var node string
+	var password string
+	var chainName string
+
+	cmd := &cobra.Command{
+		Use:   "add <username> [<permission>...]",
+		Short: "Add a user to the node.",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			userPermissions := ParsePermissions(args[1:], chainName)
+
+			_, err := cliclients.WaspClient(node).UsersApi.AddUser(context.Background()).
+				AddUserRequest(apiclient.AddUserRequest{
+					Username:    args[0],
+					Password:    readPassword(password),
+					Permissions: userPermissions,
+				}).Execute()
+			log.Check(err)
+
+			log.Printf("User %s added\n", args[0])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	WithChainFlag(cmd, &chainName)
+	cmd.Flags().StringVarP(&password, "password", "p", "", "the password of the user (asked, if not given)")
+	return cmd
+}
+
+func initDeleteCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "delete <username>",
+		Short: "Delete a user of the node.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			_, err := cliclients.WaspClient(node).UsersApi.DeleteUser(context.Background(), args[0]).Execute()
+			log.Check(err)
+
+			log.Printf("User %s deleted\n", args[0])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
+
+func initSetPasswordCmd() *cobra.Command {
+	var node string
+	var password string
+
+	cmd := &cobra.Command{
+		Use:   "set-password <username>",
+		Short: "Change the password of a user.",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			_, err := cliclients.WaspClient(node).UsersApi.ChangeUserPassword(context.Background(), args[0]).
+				UpdateUserPasswordRequest(apiclient.UpdateUserPasswordRequest{
+					Password: readPassword(password),
+				}).Execute()
+			log.Check(err)
+
+			log.Printf("Password of user %s changed\n", args[0])
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	cmd.Flags().StringVarP(&password, "password", "p", "", "the new password of the user (asked, if not given)")
+	return cmd
+}
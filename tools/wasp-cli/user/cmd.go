@@ -0,0 +1,62 @@
+package user
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/packages/authentication/shared/permissions"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/config"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+)
+
+func initUserCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "user <command>",
+		Short: "Manage the users of a Wasp node.",
+		Long: `Manage the users of a Wasp node and their permissions.
+
+The permissions are "read" and "write" (which implies "read") for the whole node,
+or restricted to a capability as "<capability>:<read|write>", where the capability
+is one of: chains, peering, users, dkg, node, audit.
+The chains capability can be restricted to a single chain as "chains/<chainID>:<read|write>"
+(or with the --chain flag).`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			log.Check(cmd.Help())
+		},
+	}
+}
+
+func Init(rootCmd *cobra.Command) {
+	userCmd := initUserCmd()
+	rootCmd.AddCommand(userCmd)
+
+	userCmd.AddCommand(initListCmd())
+	userCmd.AddCommand(initAddCmd())
+	userCmd.AddCommand(initDeleteCmd())
+	userCmd.AddCommand(initSetPasswordCmd())
+	userCmd.AddCommand(initSetPermissionsCmd())
+	userCmd.AddCommand(initGrantCmd())
+	userCmd.AddCommand(initRevokeCmd())
+}
+
+func WithChainFlag(cmd *cobra.Command, chainName *string) {
+	cmd.Flags().StringVar(chainName, "chain", "", "restrict the given permission levels (read, write) to the chain with this name")
+}
+
+// ParsePermissions validates the given permissions, restricting them to the
+// given chain, if any
+func ParsePermissions(args []string, chainName string) []string {
+	ret := make([]string, len(args))
+	for i, permission := range args {
+		if chainName != "" {
+			if permission != permissions.Read && permission != permissions.Write {
+				log.Fatalf("only the read and write permissions can be restricted to a chain, got %q", permission)
+			}
+			permission = permissions.ChainScoped(config.GetChain(chainName).String(), permission)
+		}
+		_, _, _, err := permissions.Parse(permission)
+		log.Check(err)
+		ret[i] = permission
+	}
+	return ret
+}
@@ -0,0 +1,41 @@
+package user
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func initListCmd() *cobra.Command {
+	var node string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the users of the node and their permissions.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+
+			users, _, err := cliclients.WaspClient(node).UsersApi.GetUsers(context.Background()).Execute()
+			log.Check(err)
+
+			sort.Slice(users, func(i, j int) bool { return users[i].Username < users[j].Username })
+			header := []string{"Username", "Permissions"}
+			rows := make([][]string, len(users))
+			for i, user := range users {
+				sort.Strings(user.Permissions)
+				rows[i] = []string{user.Username, strings.Join(user.Permissions, ", ")}
+			}
+			log.PrintTable(header, rows)
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	return cmd
+}
@@ -0,0 +1,91 @@
+package user
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/samber/lo"
+	"github.com/spf13/cobra"
+
+	"github.com/iotaledger/wasp/clients/apiclient"
+	"github.com/iotaledger/wasp/tools/wasp-cli/cli/cliclients"
+	"github.com/iotaledger/wasp/tools/wasp-cli/log"
+	"github.com/iotaledger/wasp/tools/wasp-cli/waspcmd"
+)
+
+func setPermissions(node, username string, userPermissions []string) {
+	sort.Strings(userPermissions)
+	_, err := cliclients.WaspClient(node).UsersApi.ChangeUserPermissions(context.Background(), username).
+		UpdateUserPermissionsRequest(apiclient.UpdateUserPermissionsRequest{
+			Permissions: userPermissions,
+		}).Execute()
+	log.Check(err)
+
+	log.Printf("Permissions of user %s: %s\n", username, strings.Join(userPermissions, ", "))
+}
+
+func getPermissions(node, username string) []string {
+	user, _, err := cliclients.WaspClient(node).UsersApi.GetUser(context.Background(), username).Execute()
+	log.Check(err)
+	return user.Permissions
+}
+
+func initSetPermissionsCmd() *cobra.Command {
+	var node string
+	var chainName string
+
+	cmd := &cobra.Command{
+		Use:   "set-permissions <username> [<permission>...]",
+		Short: "Replace the permissions of a user.",
+		Args:  cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			setPermissions(node, args[0], ParsePermissions(args[1:], chainName))
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	WithChainFlag(cmd, &chainName)
+	return cmd
+}
+
+func initGrantCmd() *cobra.Command {
+	var node string
+	var chainName string
+
+	cmd := &cobra.Command{
+		Use:   "grant <username> <permission>...",
+		Short: "Add permissions to a user.",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			granted := ParsePermissions(args[1:], chainName)
+			setPermissions(node, args[0], lo.Union(getPermissions(node, args[0]), granted))
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	WithChainFlag(cmd, &chainName)
+	return cmd
+}
+
+func initRevokeCmd() *cobra.Command {
+	var node string
+	var chainName string
+
+	cmd := &cobra.Command{
+		Use:   "revoke <username> <permission>...",
+		Short: "Remove permissions from a user.",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			node = waspcmd.DefaultWaspNodeFallback(node)
+			revoked := ParsePermissions(args[1:], chainName)
+			setPermissions(node, args[0], lo.Without(getPermissions(node, args[0]), revoked...))
+		},
+	}
+
+	waspcmd.WithWaspNodeFlag(cmd, &node)
+	WithChainFlag(cmd, &chainName)
+	return cmd
+}